@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -8,22 +10,40 @@ import (
 	"strings"
 
 	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/cache"
 	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/script"
 	"github.com/justyntemme/webby-t/internal/ui"
+	"github.com/justyntemme/webby-t/internal/update"
+	"github.com/justyntemme/webby-t/pkg/models"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// lowPowerFPS caps the renderer frame rate in low-power mode (bubbletea defaults to 60)
+const lowPowerFPS = 15
+
 func main() {
 	// Define flags
 	uploadFiles := flag.String("upload", "", "Upload epub file(s) to the server (comma-separated or glob pattern)")
 	flag.StringVar(uploadFiles, "u", "", "Upload epub file(s) (shorthand)")
 	serverURL := flag.String("url", "", "Server URL (e.g., http://myserver:8080)")
+	profile := flag.String("profile", "", "Use a named server profile from the config")
 	flag.StringVar(serverURL, "s", "", "Server URL (shorthand)")
 	showHelp := flag.Bool("help", false, "Show help message")
 	flag.BoolVar(showHelp, "h", false, "Show help (shorthand)")
 	debug := flag.Bool("debug", false, "Show debug information")
 	apiDebug := flag.Bool("api-debug", false, "Log all API requests to stderr")
+	apiLog := flag.String("api-log", os.Getenv("WEBBY_API_LOG"), "Log all API requests to a file (env WEBBY_API_LOG)")
+	apiLogBodies := flag.Bool("api-log-bodies", os.Getenv("WEBBY_API_LOG_BODIES") != "", "Include request and response bodies in the API log (env WEBBY_API_LOG_BODIES)")
+	exportBookmarks := flag.String("export-bookmarks", "", "Export bookmarks to a file (- for stdout)")
+	importBookmarks := flag.String("import-bookmarks", "", "Import bookmarks from a file (- for stdin)")
+	bookmarkFormat := flag.String("bookmark-format", "", "Bookmark file format: json or csv (default: from file extension)")
+	scriptFile := flag.String("script", "", "Run a JSON or YAML script of actions without the TUI (- for stdin)")
+	caFile := flag.String("ca-file", "", "Trust the certificates in this PEM file (for self-signed servers)")
+	insecure := flag.Bool("insecure", false, "Skip server certificate verification (testing only)")
+	lowPower := flag.Bool("low-power", false, "Reduce redraws and background refreshes to save battery")
+	eink := flag.Bool("eink", false, "Grayscale, high-contrast comics and fewer redraws for e-ink displays")
 
 	flag.Parse()
 
@@ -32,6 +52,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle the version subcommand before positional args are treated as uploads
+	if flag.Arg(0) == "version" {
+		if err := handleVersion(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if flag.Arg(0) == "cache" {
+		if err := handleCache(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -39,6 +76,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Switch server profile if asked, before any URL override applies to it
+	if *profile != "" {
+		if err := cfg.UseProfile(*profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Override server URL if provided via flag
 	if *serverURL != "" {
 		cfg.ServerURL = *serverURL
@@ -48,21 +93,94 @@ func main() {
 		}
 	}
 
+	// TLS settings can be given per run without saving them to config
+	cfg.SetRunTLS(*caFile, *insecure)
+	if cfg.SkipTLSVerify() {
+		fmt.Fprintln(os.Stderr, "Warning: server certificates are not verified")
+	}
+
 	// Debug mode
 	if *debug {
-		fmt.Printf("Config path: ~/.config/webby-t/config.json\n")
+		fmt.Printf("Config path: %s\n", cfg.Path())
 		fmt.Printf("Server URL: %s\n", cfg.ServerURL)
+		if cfg.Profile != "" {
+			fmt.Printf("Profile: %s\n", cfg.Profile)
+		}
 		fmt.Printf("Authenticated: %v\n", cfg.IsAuthenticated())
+		fmt.Printf("Token storage: %s\n", cfg.TokenStorage())
+		if state := cfg.StateFile(); state != "" {
+			fmt.Printf("Local state: %s\n", state)
+		}
 		if cfg.Username != "" {
 			fmt.Printf("Username: %s\n", cfg.Username)
 		}
 		os.Exit(0)
 	}
 
-	// API debug logging
+	// API debug logging, to stderr or (so it doesn't disturb the TUI) a file
 	if *apiDebug {
 		api.Debug = true
 	}
+	if *apiLog != "" {
+		logFile, err := os.OpenFile(*apiLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening API log: %v\n", err)
+			os.Exit(1)
+		}
+		defer logFile.Close()
+		api.SetDebugLog(logFile, *apiLogBodies)
+	} else if *apiLogBodies {
+		api.SetDebugLog(os.Stderr, true)
+	}
+
+	if flag.Arg(0) == "download" {
+		if err := handleDownload(cfg, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if flag.Arg(0) == "edit" {
+		if err := handleEdit(cfg, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if flag.Arg(0) == "config" {
+		if err := handleConfig(cfg, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle headless script mode
+	if *scriptFile != "" {
+		if err := handleScript(cfg, *scriptFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle bookmark export/import
+	if *exportBookmarks != "" {
+		if err := handleExportBookmarks(cfg, *exportBookmarks, *bookmarkFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *importBookmarks != "" {
+		if err := handleImportBookmarks(cfg, *importBookmarks, *bookmarkFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
 	// Handle upload mode
 	if *uploadFiles != "" {
@@ -83,9 +201,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Low-power and e-ink modes can be enabled per run without saving them to config
+	if *lowPower {
+		cfg.SetRunLowPower()
+	}
+	if *eink {
+		cfg.SetRunEInk()
+	}
+
 	// Run TUI mode
 	app := ui.NewApp(cfg)
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	// Mouse reporting lets the comic viewer zoom with the wheel
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if cfg.ReduceRedraws() {
+		// Fewer frames per second coalesces bursts of key repeats into one redraw
+		opts = append(opts, tea.WithFPS(lowPowerFPS))
+	}
+	p := tea.NewProgram(app, opts...)
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
@@ -100,19 +232,69 @@ func printUsage() {
 	fmt.Println("  webby-t [files...]          Upload epub files to server")
 	fmt.Println("  webby-t -u <files>          Upload epub files (comma-separated)")
 	fmt.Println("  webby-t -u '*.epub'         Upload files matching glob pattern")
+	fmt.Println("  webby-t version [--check]   Print the version (and check for a newer release)")
+	fmt.Println("  webby-t cache [clear]       Show (or delete) cached comic pages")
+	fmt.Println("  webby-t download <book> [dir]  Save a book's file (by ID or title) to dir or the download dir")
+	fmt.Println("  webby-t edit <book> [--title t] [--author a] [--series s] [--series-index n]  Change a book's metadata")
+	fmt.Println("  webby-t config export [file]   Back up settings, bookmarks, favorites and the queue (default stdout)")
+	fmt.Println("  webby-t config import <file>   Restore a backup, merging it with this machine's (- for stdin)")
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -s, --url <url>        Set server URL (saved to config)")
-	fmt.Println("  -u, --upload <files>   Upload epub file(s) to the server")
-	fmt.Println("  -h, --help             Show this help message")
+	fmt.Println("  -s, --url <url>            Set server URL (saved to config)")
+	fmt.Println("  --profile <name>           Switch to a server profile from the config (saved)")
+	fmt.Println("  -u, --upload <files>       Upload epub file(s) to the server")
+	fmt.Println("  --export-bookmarks <file>  Export bookmarks (- for stdout)")
+	fmt.Println("  --import-bookmarks <file>  Import bookmarks (- for stdin)")
+	fmt.Println("  --bookmark-format <fmt>    json or csv (default: from file extension)")
+	fmt.Println("  --script <file>            Run a JSON or YAML script without the TUI (- for stdin)")
+	fmt.Println("  --ca-file <file>           Trust the certificates in a PEM file (self-signed servers)")
+	fmt.Println("  --insecure                 Skip server certificate verification (testing only)")
+	fmt.Println("  --api-debug                Log API requests to stderr")
+	fmt.Println("  --api-log <file>           Log API requests with status and timing to a file (or WEBBY_API_LOG)")
+	fmt.Println("  --api-log-bodies           Also log request and response bodies, secrets masked (or WEBBY_API_LOG_BODIES=1)")
+	fmt.Println("  --low-power                Fewer redraws and no cursor blink (battery saving)")
+	fmt.Println("  --eink                     Grayscale, high-contrast comics with fewer redraws (e-ink displays)")
+	fmt.Println("  -h, --help                 Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  webby-t --url http://myserver:8080")
 	fmt.Println("  webby-t book.epub")
 	fmt.Println("  webby-t book1.epub book2.epub")
 	fmt.Println("  webby-t -u 'books/*.epub'")
+	fmt.Println("  webby-t --export-bookmarks bookmarks.csv")
+	fmt.Println("  webby-t config export webby-t-backup.yaml")
+	fmt.Println("  webby-t edit 'Dune' --series 'Dune' --series-index 1")
+	fmt.Println(`  echo '[{"action":"search","query":"dune"},{"action":"open"}]' | webby-t --script -`)
+	fmt.Println("  webby-t --script nightly.yaml")
+	fmt.Println()
+	fmt.Println("Script actions (one JSON result line is printed per step):")
+	fmt.Println(`  {"action":"login","username":"me","password_env":"WEBBY_PASSWORD"}`)
+	fmt.Println(`  {"action":"search","query":"dune","limit":10}`)
+	fmt.Println(`  {"action":"open","book":"<id or title>","chapter":0}`)
+	fmt.Println(`  {"action":"export_bookmarks","format":"csv","path":"bookmarks.csv"}`)
 	fmt.Println()
-	fmt.Println("Config: ~/.config/webby-t/config.json")
+	fmt.Println("Config: ~/.config/webby-t/config.json (or config.toml / config.yaml, which take precedence)")
+	fmt.Println(`  Set "check_updates": true to be told about new releases on startup`)
+	fmt.Println(`  Profiles: "profiles" (e.g. [{"name": "home", "url": "http://nas:8080"}, {"name": "friend", "url": "https://books.example.com"}]); pick one with --profile or P in the library`)
+	fmt.Println(`  Set "token_keyring": true to keep the login token in the OS keyring (Secret Service, Keychain or Credential Manager) instead of the config file`)
+	fmt.Println(`  Sixel images: "sixel_palette" (median-cut or plan9), "sixel_colors" (2-256), "sixel_dither" (floyd-steinberg or none)`)
+	fmt.Println(`  Comic panning: "pan_step_percent" (share of the screen per pan step, default 10), "comic_zoom_lock" (keep zoom between pages)`)
+	fmt.Println(`  Reading defaults: "text_scale", "continuous_scroll", "comic_right_to_left" (manga), "comic_fit_mode" (page, width or height); changes made while reading are kept per book`)
+	fmt.Println(`  Downloads: "download_dir" (where D saves book files, default ~/Downloads)`)
+	fmt.Println(`  Library: "page_size" (books per page, 10-500, default 50), "infinite_scroll" (load the next page while scrolling)`)
+	fmt.Println(`  Network: "retry_attempts" (tries per read request on network errors, default 3, 1 disables retries)`)
+	fmt.Println(`  Rate limit: "rate_limit" (API requests per second on average, in bursts of up to twice that, default 10, -1 disables)`)
+	fmt.Println(`  TLS: "ca_file" (PEM bundle of extra trusted certificates), "insecure_skip_verify" (accept any certificate, testing only)`)
+	fmt.Println(`  Mutual TLS: "client_cert" and "client_key" (PEM files presented to servers that require a client certificate)`)
+	fmt.Println(`  Proxy: "proxy" (http://, https://, socks5:// or socks5h:// URL; default from HTTPS_PROXY, HTTP_PROXY or ALL_PROXY)`)
+	fmt.Println(`  Extra headers: "headers" (e.g. {"CF-Access-Client-Id": "$CF_ID"}; $VAR values come from the environment)`)
+	fmt.Println(`  Timeouts: "request_timeout" (seconds per API call, default 30), "transfer_timeout" (seconds per book upload or download, default no limit)`)
+	fmt.Println(`  Library refresh: "refresh_seconds" (how often to check the server for new books, default 60, -1 disables)`)
+	fmt.Println(`  Home: a dashboard of books in progress, the queue and new uploads opens after login; set "start_in_library": true to skip it`)
+	fmt.Println(`  Themes: "themes" (e.g. [{"name": "mine", "base": "nord", "primary": "#FF79C6"}]) or one theme per JSON, TOML or YAML file in themes.d next to the config; T cycles through them`)
+	fmt.Println(`  Keys: "key_preset" (vim, arrows or emacs), "keys" (remaps by view, e.g. {"reader": {"n": "p", "p": "n"}, "global": {"ctrl+q": "quit"}})`)
+	fmt.Println(`  Favorites, the reading queue, bookmarks, reading progress and history and other per-book data are kept in state.db next to the config`)
+	fmt.Println(`  Favorites and the reading queue sync with the server when it supports it; set "disable_sync": true to keep them local`)
 }
 
 func handleUpload(cfg *config.Config, filesArg string) error {
@@ -122,7 +304,10 @@ func handleUpload(cfg *config.Config, filesArg string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.ServerURL, cfg.Token)
+	client, err := cfg.NewClient()
+	if err != nil {
+		return err
+	}
 
 	// Expand files (handle comma-separated and globs)
 	var files []string
@@ -173,7 +358,7 @@ func handleUpload(cfg *config.Config, filesArg string) error {
 	for _, filePath := range epubFiles {
 		fmt.Printf("  Uploading %s... ", filepath.Base(filePath))
 
-		book, err := client.UploadBook(filePath)
+		book, err := client.UploadBookResumable(context.Background(), filePath, cfg)
 		if err != nil {
 			fmt.Printf("FAILED: %v\n", err)
 			continue
@@ -196,3 +381,356 @@ func handleUpload(cfg *config.Config, filesArg string) error {
 
 	return nil
 }
+
+// bookmarkFileFormat returns the explicit format, or one inferred from the file name
+func bookmarkFileFormat(path, format string) (string, error) {
+	switch format {
+	case "":
+		return config.BookmarkFormatForPath(path), nil
+	case config.BookmarkFormatJSON, config.BookmarkFormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown bookmark format %q (use json or csv)", format)
+	}
+}
+
+func handleExportBookmarks(cfg *config.Config, path, format string) error {
+	format, err := bookmarkFileFormat(path, format)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		return cfg.ExportBookmarks(os.Stdout, format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := cfg.ExportBookmarks(file, format); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d bookmark(s) to %s\n", len(cfg.GetBookmarks()), path)
+	return nil
+}
+
+func handleImportBookmarks(cfg *config.Config, path, format string) error {
+	format, err := bookmarkFileFormat(path, format)
+	if err != nil {
+		return err
+	}
+
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	added, err := cfg.ImportBookmarks(input, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d new bookmark(s)\n", added)
+	return nil
+}
+
+func handleScript(cfg *config.Config, path string) error {
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	steps, err := script.Parse(input)
+	if err != nil {
+		return err
+	}
+	runner, err := script.NewRunner(cfg, os.Stdout)
+	if err != nil {
+		return err
+	}
+	return runner.Run(steps)
+}
+
+// changelogLines limits the release notes printed by "version --check"
+const changelogLines = 15
+
+// handleVersion prints the running version and, with --check, the latest release
+func handleVersion(args []string) error {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	check := fs.Bool("check", false, "Check GitHub for a newer release")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Printf("webby-t %s\n", update.Version)
+	if !*check {
+		return nil
+	}
+
+	release, err := update.Latest()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Latest release: %s\n", release.TagName)
+	if !update.IsNewer(update.Version, release.TagName) {
+		fmt.Println("You are up to date.")
+		return nil
+	}
+
+	fmt.Println("An update is available.")
+	if release.HTMLURL != "" {
+		fmt.Println(release.HTMLURL)
+	}
+	if excerpt := release.ChangelogExcerpt(changelogLines); excerpt != "" {
+		fmt.Println()
+		fmt.Println(excerpt)
+	}
+	return nil
+}
+
+// handleCache reports the size of the comic page cache, or clears it
+func handleCache(args []string) error {
+	dir, err := cache.PageDir()
+	if err != nil {
+		return err
+	}
+	pages := cache.NewDiskCache(dir, 0)
+
+	switch {
+	case len(args) == 0:
+		fmt.Printf("Page cache: %s (%.1f MB)\n", dir, float64(pages.Size())/(1024*1024))
+		return nil
+	case args[0] == "clear":
+		if err := pages.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("Page cache cleared")
+		return nil
+	}
+	return fmt.Errorf("unknown cache command %q (expected clear)", args[0])
+}
+
+// handleConfig exports the settings and local state to a file, or imports them
+// from one. The file's extension picks JSON, TOML or YAML.
+func handleConfig(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: webby-t config export [file] | import <file>")
+	}
+	switch args[0] {
+	case "export":
+		if len(args) > 2 {
+			return fmt.Errorf("usage: webby-t config export [file]")
+		}
+		if len(args) == 1 || args[1] == "-" {
+			return cfg.Export(os.Stdout, "-")
+		}
+		path := args[1]
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		if err := cfg.Export(file, path); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Exported settings and local state to %s\n", path)
+		return nil
+	case "import":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: webby-t config import <file>")
+		}
+		path := args[1]
+		input := os.Stdin
+		if path != "-" {
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open %s: %w", path, err)
+			}
+			defer file.Close()
+			input = file
+		}
+		counts, err := cfg.Import(input, path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported settings, %d new bookmark(s), %d favorite(s), %d queued book(s) and reading data for %d book(s)\n",
+			counts.Bookmarks, counts.Favorites, counts.Queue, counts.Books)
+		return nil
+	}
+	return fmt.Errorf("unknown config command %q (expected export or import)", args[0])
+}
+
+// handleDownload saves a book's original file, showing progress on stderr
+func handleDownload(cfg *config.Config, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("usage: webby-t download <book id or title> [dir]")
+	}
+	if !cfg.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Please run webby-t and log in first")
+	}
+	client, err := cfg.NewClient()
+	if err != nil {
+		return err
+	}
+
+	book, err := findBook(client, args[0])
+	if err != nil {
+		return err
+	}
+	dir := cfg.GetDownloadDir()
+	if len(args) == 2 {
+		dir = args[1]
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, book.FileName())
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	// Download to a partial file, renamed into place once complete
+	partial := path + ".part"
+	file, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(partial)
+
+	lastPercent := -1
+	err = client.DownloadBookProgress(book.ID, file, func(written, total int64) {
+		if total <= 0 {
+			fmt.Fprintf(os.Stderr, "\rDownloading %s... %.1f MB", book.Title, float64(written)/(1024*1024))
+			return
+		}
+		if percent := int(written * 100 / total); percent != lastPercent {
+			lastPercent = percent
+			fmt.Fprintf(os.Stderr, "\rDownloading %s... %d%% of %.1f MB", book.Title, percent, float64(total)/(1024*1024))
+		}
+	})
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return err
+	}
+	fmt.Printf("Saved to %s\n", path)
+	return nil
+}
+
+// handleEdit changes a book's title, author or series on the server. Only the
+// flags given are sent; an empty value clears the field.
+func handleEdit(cfg *config.Config, args []string) error {
+	usage := fmt.Errorf("usage: webby-t edit <book id or title> [--title t] [--author a] [--series s] [--series-index n]")
+	if len(args) == 0 {
+		return usage
+	}
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	title := fs.String("title", "", "New title")
+	author := fs.String("author", "", "New author")
+	series := fs.String("series", "", "New series (empty to clear)")
+	seriesIndex := fs.Float64("series-index", 0, "New position in the series")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usage
+	}
+
+	var fields api.BookUpdate
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			fields.Title = title
+		case "author":
+			fields.Author = author
+		case "series":
+			fields.Series = series
+		case "series-index":
+			fields.SeriesIndex = seriesIndex
+		}
+	})
+	if fields == (api.BookUpdate{}) {
+		return fmt.Errorf("nothing to change: give --title, --author, --series or --series-index")
+	}
+	if fields.Title != nil && strings.TrimSpace(*fields.Title) == "" {
+		return fmt.Errorf("the title can't be empty")
+	}
+
+	if !cfg.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Please run webby-t and log in first")
+	}
+	client, err := cfg.NewClient()
+	if err != nil {
+		return err
+	}
+	book, err := findBook(client, args[0])
+	if err != nil {
+		return err
+	}
+	updated, err := client.UpdateBook(book.ID, fields)
+	if errors.Is(err, api.ErrNotSupported) {
+		return fmt.Errorf("this server doesn't support editing books")
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s: %s by %s", updated.ID, updated.Title, updated.Author)
+	if updated.Series != "" {
+		fmt.Printf(" (%s #%g)", updated.Series, updated.SeriesIndex)
+	}
+	fmt.Println()
+	return nil
+}
+
+// findBook looks a book up by ID, then by title: an exact (case-insensitive)
+// match, or the only search result
+func findBook(client *api.Client, ref string) (*models.Book, error) {
+	if book, err := client.GetBook(ref); err == nil {
+		return book, nil
+	}
+	resp, err := client.ListBooks(1, 20, "title", "asc", ref, "")
+	if err != nil {
+		return nil, err
+	}
+	for i, book := range resp.Books {
+		if strings.EqualFold(book.Title, ref) {
+			return &resp.Books[i], nil
+		}
+	}
+	switch len(resp.Books) {
+	case 0:
+		return nil, fmt.Errorf("no book matches %q", ref)
+	case 1:
+		return &resp.Books[0], nil
+	}
+	titles := make([]string, len(resp.Books))
+	for i, book := range resp.Books {
+		titles[i] = fmt.Sprintf("  %s  %s", book.ID, book.Title)
+	}
+	return nil, fmt.Errorf("%q matches %d books; use an ID:\n%s", ref, len(resp.Books), strings.Join(titles, "\n"))
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// ErrWrongPassword is returned when the server refuses the current password
+// given to confirm an account change
+var ErrWrongPassword = errors.New("current password is incorrect")
+
+// ChangePassword sets a new password, confirming it with the current one. Servers
+// that end other sessions on a password change send a new token, which the
+// client switches to and returns; otherwise the token is "". Returns
+// ErrNotSupported if the server can't change passwords.
+func (c *Client) ChangePassword(current, newPassword string) (string, error) {
+	resp, err := c.request("PUT", "/api/auth/password", map[string]string{
+		"current_password": current,
+		"new_password":     newPassword,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := accountError(resp); err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		return "", nil
+	}
+
+	result, err := parseResponse[map[string]interface{}](resp)
+	if err != nil {
+		return "", err
+	}
+	token, _ := result["token"].(string)
+	if token != "" {
+		c.SetToken(token)
+	}
+	return token, nil
+}
+
+// UpdateEmail changes the account's email address and returns the updated user
+func (c *Client) UpdateEmail(email string) (*models.User, error) {
+	resp, err := c.request("PATCH", "/api/auth/me", map[string]string{"email": email})
+	if err != nil {
+		return nil, err
+	}
+	if err := accountError(resp); err != nil {
+		return nil, err
+	}
+
+	result, err := parseResponse[map[string]*models.User](resp)
+	if err != nil {
+		return nil, err
+	}
+	if result["user"] == nil {
+		return c.GetCurrentUser()
+	}
+	return result["user"], nil
+}
+
+// DeleteAccount permanently deletes the account and its books, confirming with
+// the password. The client is logged out afterwards.
+func (c *Client) DeleteAccount(password string) error {
+	resp, err := c.request("DELETE", "/api/auth/account", map[string]string{"password": password})
+	if err != nil {
+		return err
+	}
+	if err := accountError(resp); err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to delete account: %s", string(body)))
+	}
+	c.SetToken("")
+	return nil
+}
+
+// accountError closes resp and returns an error for a server without account
+// management or a refused password, or returns nil leaving resp open
+func accountError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return ErrNotSupported
+	case http.StatusForbidden:
+		resp.Body.Close()
+		return withRequestID(resp, ErrWrongPassword)
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+func TestLoginAndListBooks(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddUser("reader", "secret")
+	dune := s.AddBook(models.Book{Title: "Dune", Author: "Frank Herbert"}, "chapter one")
+	s.AddBook(models.Book{Title: "Emma", Author: "Jane Austen"})
+
+	client := s.Client("")
+	if _, err := client.Login("reader", "wrong"); err == nil {
+		t.Fatal("login with a wrong password succeeded")
+	}
+	auth, err := client.Login("reader", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.User.Username != "reader" || auth.Token == "" {
+		t.Fatalf("login = %+v", auth)
+	}
+	client.SetToken(auth.Token)
+
+	books, err := client.ListBooks(1, 20, "title", "asc", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if books.Total != 2 || len(books.Books) != 2 || books.Books[0].Title != "Dune" {
+		t.Fatalf("books = %+v", books)
+	}
+	book, err := client.GetBook(dune.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "Dune" || book.Author != "Frank Herbert" {
+		t.Fatalf("book = %+v", book)
+	}
+	if _, err := client.GetBook("missing"); err == nil {
+		t.Fatal("got a book that doesn't exist")
+	}
+}
+
+func TestExpiredTokenIsRenewed(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	book := s.AddBook(models.Book{Title: "Dune"})
+	client := s.Client("reader")
+	if _, err := client.GetBook(book.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	s.ExpireAccess()
+	if _, err := client.GetBook(book.ID); err != nil {
+		t.Fatalf("request after the token lapsed: %v", err)
+	}
+	select {
+	case event := <-client.AuthEvents():
+		if event.Token == "" {
+			t.Fatal("session reported expired instead of renewed")
+		}
+	default:
+		t.Fatal("no event for the renewed token")
+	}
+	requests := s.Requests()
+	if want := []string{"GET /api/books/" + book.ID, "POST /api/auth/refresh", "GET /api/books/" + book.ID}; !slices.Equal(requests[1:], want) {
+		t.Fatalf("requests = %v, want %v after the first", requests, want)
+	}
+
+	// A token that can't be renewed ends the session
+	s.ExpireTokens()
+	if _, err := client.GetBook(book.ID); err == nil {
+		t.Fatal("request succeeded with an expired session")
+	}
+	select {
+	case event := <-client.AuthEvents():
+		if event.Token != "" {
+			t.Fatal("session renewed after the tokens were revoked")
+		}
+	default:
+		t.Fatal("no event for the expired session")
+	}
+}
+
+func TestBusyServerIsRetried(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	book := s.AddBook(models.Book{Title: "Dune"})
+	var tries atomic.Int32
+	s.Handle("GET /api/books/"+book.ID, func(w http.ResponseWriter, r *http.Request) {
+		if tries.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			writeError(w, http.StatusServiceUnavailable, "busy")
+			return
+		}
+		s.mux.ServeHTTP(w, r)
+	})
+
+	client := s.Client("reader")
+	got, err := client.GetBook(book.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Dune" || tries.Load() != 3 {
+		t.Fatalf("book = %+v after %d tries, want Dune after 3", got, tries.Load())
+	}
+	select {
+	case event := <-client.BusyEvents():
+		if event.Wait != 0 {
+			t.Fatalf("busy wait = %v, want the Retry-After of 0", event.Wait)
+		}
+	default:
+		t.Fatal("no busy event")
+	}
+
+	// Out of tries, the last answer is returned
+	tries.Store(0)
+	client.SetMaxAttempts(2)
+	if _, err := client.GetBook(book.ID); err == nil {
+		t.Fatal("no error after running out of tries")
+	}
+	if tries.Load() != 2 {
+		t.Fatalf("tries = %d, want 2", tries.Load())
+	}
+}
+
+func TestChunkedUpload(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.SetUploadChunkSize(4)
+	client := s.Client("reader")
+
+	file := []byte("0123456789")
+	session, err := client.StartUpload("big.epub", int64(len(file)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.ChunkSize != 4 {
+		t.Fatalf("chunk size = %d, want 4", session.ChunkSize)
+	}
+	ctx := context.Background()
+	for _, index := range []int{0, 2} {
+		if err := client.PutUploadChunk(ctx, session.ID, index, file[index*4:min(len(file), index*4+4)]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.CompleteUpload(session.ID); err == nil {
+		t.Fatal("completed an upload with a chunk missing")
+	}
+
+	// Resuming: the server lists the chunks it has
+	resumed, err := client.GetUpload(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(resumed.Received, []int{0, 2}) {
+		t.Fatalf("received = %v, want [0 2]", resumed.Received)
+	}
+	if err := client.PutUploadChunk(ctx, session.ID, 1, file[4:8]); err != nil {
+		t.Fatal(err)
+	}
+	book, err := client.CompleteUpload(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "big" {
+		t.Fatalf("book = %+v", book)
+	}
+	var downloaded bytes.Buffer
+	if err := client.DownloadBookProgress(book.ID, &downloaded, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(downloaded.Bytes(), file) {
+		t.Fatalf("file = %q, want %q", downloaded.Bytes(), file)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	book := s.AddBook(models.Book{Title: "Dune"})
+	client := s.Client("reader")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.SubscribeEvents(ctx)
+	waitFor := func(want api.Event) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event := <-events:
+				if event == want {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("no %+v event", want)
+			}
+		}
+	}
+
+	// Pushed until one arrives, as the stream may not be open yet
+	deadline := time.Now().Add(5 * time.Second)
+	for received := false; !received; {
+		s.SendEvent(api.Event{Type: "ping"})
+		select {
+		case <-events:
+			received = true
+		case <-time.After(20 * time.Millisecond):
+			if time.Now().After(deadline) {
+				t.Fatal("event stream never opened")
+			}
+		}
+	}
+
+	other := s.Client("reader")
+	if err := other.SavePosition(book.ID, "3", 0.5); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(api.Event{Type: api.EventPositionUpdated, BookID: book.ID, Chapter: "3", Position: 0.5})
+	added := s.AddBook(models.Book{Title: "Emma"})
+	waitFor(api.Event{Type: api.EventBookAdded, BookID: added.ID})
+}
+
+func TestComicPageSet(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	comic := s.AddBook(models.Book{Title: "Saga", ContentType: models.ContentTypeComic})
+	s.SetComicPages(comic.ID, []byte("page 0"), []byte("page 1"), []byte("page 2"))
+	client := s.Client("reader")
+
+	pages := client.GetComicPageSet(context.Background(), comic.ID, []int{2, 0, 7})
+	if len(pages) != 3 {
+		t.Fatalf("got %d pages, want 3", len(pages))
+	}
+	for i, want := range []string{"page 2", "page 0"} {
+		if pages[i].Err != nil || string(pages[i].Data) != want {
+			t.Errorf("page %d = %q, %v; want %q", pages[i].Index, pages[i].Data, pages[i].Err, want)
+		}
+	}
+	if pages[2].Index != 7 || pages[2].Err == nil {
+		t.Errorf("missing page = %+v, want an error", pages[2])
+	}
+}
@@ -0,0 +1,84 @@
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/justyntemme/webby-t/internal/api"
+)
+
+// streamEvent is an event with its ID in the event stream
+type streamEvent struct {
+	id    int
+	event api.Event
+}
+
+// SendEvent pushes an event to every open event stream. Books added or
+// deleted and positions saved through the fake API send their own events.
+func (s *Server) SendEvent(event api.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publishLocked(event)
+}
+
+// publishLocked logs an event and sends it to the open streams, dropping it
+// for any that are too far behind; the caller holds mu
+func (s *Server) publishLocked(event api.Event) {
+	sent := streamEvent{id: len(s.events) + 1, event: event}
+	s.events = append(s.events, sent)
+	for listener := range s.listeners {
+		select {
+		case listener <- sent:
+		default:
+		}
+	}
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request, username string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "streaming unsupported")
+		return
+	}
+
+	listener := make(chan streamEvent, 16)
+	s.mu.Lock()
+	var missed []streamEvent
+	if lastID, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil && lastID >= 0 {
+		missed = append(missed, s.events[min(lastID, len(s.events)):]...)
+	}
+	s.listeners[listener] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.listeners, listener)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, sent := range missed {
+		writeEvent(w, sent)
+	}
+	flusher.Flush()
+	for {
+		select {
+		case sent := <-listener:
+			writeEvent(w, sent)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// writeEvent writes an event in the server-sent events format
+func writeEvent(w http.ResponseWriter, sent streamEvent) {
+	data, _ := json.Marshal(sent.event)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", sent.id, sent.event.Type, data)
+}
@@ -0,0 +1,617 @@
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// routes registers the fake API's endpoints
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	s.mux.HandleFunc("GET /api/info", s.handleInfo)
+
+	s.mux.HandleFunc("POST /api/auth/login", s.handleLogin)
+	s.mux.HandleFunc("POST /api/auth/register", s.handleRegister)
+	s.mux.HandleFunc("POST /api/auth/refresh", s.handleRefresh)
+	s.mux.HandleFunc("GET /api/auth/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]bool{"registration_enabled": true})
+	})
+	s.mux.HandleFunc("GET /api/auth/me", s.authed(s.handleMe))
+	s.mux.HandleFunc("PATCH /api/auth/me", s.authed(s.handleUpdateMe))
+	s.mux.HandleFunc("PUT /api/auth/password", s.authed(s.handleChangePassword))
+	s.mux.HandleFunc("DELETE /api/auth/account", s.authed(s.handleDeleteAccount))
+
+	s.mux.HandleFunc("GET /api/books", s.authed(s.handleListBooks))
+	s.mux.HandleFunc("POST /api/books", s.authed(s.handleUpload))
+	s.mux.HandleFunc("GET /api/books/stats", s.authed(s.handleStats))
+	s.mux.HandleFunc("GET /api/books/by-author", s.authed(s.handleGrouped("authors", func(b models.Book) string { return b.Author })))
+	s.mux.HandleFunc("GET /api/books/by-series", s.authed(s.handleGrouped("series", func(b models.Book) string { return b.Series })))
+	s.mux.HandleFunc("GET /api/books/shared", s.authed(func(w http.ResponseWriter, r *http.Request, username string) {
+		writeJSON(w, http.StatusOK, models.BooksResponse{Books: []models.Book{}})
+	}))
+	s.mux.HandleFunc("GET /api/books/{id}", s.authed(s.withBook(s.handleGetBook)))
+	s.mux.HandleFunc("PATCH /api/books/{id}", s.authed(s.withBook(s.handleUpdateBook)))
+	s.mux.HandleFunc("PUT /api/books/{id}", s.authed(s.withBook(s.handleUpdateBook)))
+	s.mux.HandleFunc("DELETE /api/books/{id}", s.authed(s.withBook(s.handleDeleteBook)))
+	s.mux.HandleFunc("PUT /api/books/{id}/tags", s.authed(s.withBook(s.handleSetTags)))
+	s.mux.HandleFunc("GET /api/books/{id}/file", s.authed(s.withBook(s.handleFile)))
+	s.mux.HandleFunc("GET /api/books/{id}/toc", s.authed(s.withBook(s.handleTOC)))
+	s.mux.HandleFunc("GET /api/books/{id}/text/{chapter}", s.authed(s.withBook(s.handleChapterText)))
+	s.mux.HandleFunc("GET /api/books/{id}/position", s.authed(s.withBook(s.handleGetPosition)))
+	s.mux.HandleFunc("POST /api/books/{id}/position", s.authed(s.withBook(s.handleSavePosition)))
+	s.mux.HandleFunc("GET /api/books/{id}/cover", s.authed(s.withBook(s.handleGetCover)))
+	s.mux.HandleFunc("PUT /api/books/{id}/cover", s.authed(s.withBook(s.handlePutCover)))
+	s.mux.HandleFunc("GET /api/books/{id}/cbz/info", s.authed(s.withBook(s.handleComicInfo)))
+	s.mux.HandleFunc("GET /api/books/{id}/cbz/page/{page}", s.authed(s.withBook(s.handleComicPage)))
+
+	s.mux.HandleFunc("GET /api/collections", s.authed(s.handleListCollections))
+	s.mux.HandleFunc("POST /api/collections", s.authed(s.handleCreateCollection))
+	s.mux.HandleFunc("DELETE /api/collections/{id}", s.authed(s.withCollection(s.handleDeleteCollection)))
+	s.mux.HandleFunc("GET /api/collections/{id}/books", s.authed(s.withCollection(s.handleCollectionBooks)))
+	s.mux.HandleFunc("POST /api/collections/{id}/books/{book}", s.authed(s.withCollection(s.handleCollectionAdd)))
+	s.mux.HandleFunc("DELETE /api/collections/{id}/books/{book}", s.authed(s.withCollection(s.handleCollectionRemove)))
+
+	s.mux.HandleFunc("GET /api/user/data/{key}", s.authed(s.handleGetUserData))
+	s.mux.HandleFunc("PUT /api/user/data/{key}", s.authed(s.handlePutUserData))
+
+	s.mux.HandleFunc("POST /api/uploads", s.authed(s.handleStartUpload))
+	s.mux.HandleFunc("GET /api/uploads/{id}", s.authed(s.withUpload(s.handleGetUpload)))
+	s.mux.HandleFunc("PUT /api/uploads/{id}/chunks/{index}", s.authed(s.withUpload(s.handlePutChunk)))
+	s.mux.HandleFunc("POST /api/uploads/{id}/complete", s.authed(s.handleCompleteUpload))
+
+	s.mux.HandleFunc("GET /api/events", s.authed(s.handleEvents))
+}
+
+// withBook wraps a handler for a book's endpoint, answering 404 for unknown
+// books. The handler runs with mu held.
+func (s *Server) withBook(handler func(w http.ResponseWriter, r *http.Request, username string, b *book)) func(http.ResponseWriter, *http.Request, string) {
+	return func(w http.ResponseWriter, r *http.Request, username string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		b := s.findLocked(r.PathValue("id"))
+		if b == nil {
+			writeError(w, http.StatusNotFound, "book not found")
+			return
+		}
+		handler(w, r, username, b)
+	}
+}
+
+// withCollection wraps a handler for one of the user's collections, answering
+// 404 for other collections. The handler runs with mu held.
+func (s *Server) withCollection(handler func(w http.ResponseWriter, r *http.Request, c *collection)) func(http.ResponseWriter, *http.Request, string) {
+	return func(w http.ResponseWriter, r *http.Request, username string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		c, ok := s.collections[r.PathValue("id")]
+		if !ok || c.owner != username {
+			writeError(w, http.StatusNotFound, "collection not found")
+			return
+		}
+		handler(w, r, c)
+	}
+}
+
+// decode reads a JSON request body into v, answering 400 if it can't
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// Server info and accounts
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	info := s.info
+	s.mu.Unlock()
+	if info == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var creds struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &creds) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[creds.Username]
+	if !ok || u.password != creds.Password {
+		writeError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.AuthResponse{Token: s.issueTokenLocked(u.Username), User: u.User})
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var creds struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &creds) {
+		return
+	}
+	if creds.Username == "" || creds.Password == "" {
+		writeError(w, http.StatusBadRequest, "username and password are required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, taken := s.users[creds.Username]; taken {
+		writeError(w, http.StatusConflict, "username already taken")
+		return
+	}
+	u := s.addUserLocked(creds.Username, creds.Email, creds.Password)
+	writeJSON(w, http.StatusCreated, models.AuthResponse{Token: s.issueTokenLocked(u.Username), Message: "registered", User: u})
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Token string `json:"token"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	username, ok := s.tokens[body.Token]
+	if !ok {
+		username, ok = s.stale[body.Token]
+	}
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+	delete(s.tokens, body.Token)
+	delete(s.stale, body.Token)
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.issueTokenLocked(username)})
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request, username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]models.User{"user": s.users[username].User})
+}
+
+func (s *Server) handleUpdateMe(w http.ResponseWriter, r *http.Request, username string) {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	if !strings.Contains(body.Email, "@") {
+		writeError(w, http.StatusBadRequest, "invalid email address")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.users[username]
+	u.Email = body.Email
+	writeJSON(w, http.StatusOK, map[string]models.User{"user": u.User})
+}
+
+// handleChangePassword sets a new password, ending the user's other sessions
+// and issuing a new token
+func (s *Server) handleChangePassword(w http.ResponseWriter, r *http.Request, username string) {
+	var body struct {
+		Current string `json:"current_password"`
+		New     string `json:"new_password"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.users[username]
+	if u.password != body.Current {
+		writeError(w, http.StatusForbidden, "current password is incorrect")
+		return
+	}
+	if body.New == "" {
+		writeError(w, http.StatusBadRequest, "new password is required")
+		return
+	}
+	u.password = body.New
+	s.endSessionsLocked(username)
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.issueTokenLocked(username)})
+}
+
+// handleDeleteAccount removes the user with their sessions, positions,
+// collections and data
+func (s *Server) handleDeleteAccount(w http.ResponseWriter, r *http.Request, username string) {
+	var body struct {
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.users[username].password != body.Password {
+		writeError(w, http.StatusForbidden, "password is incorrect")
+		return
+	}
+	delete(s.users, username)
+	s.endSessionsLocked(username)
+	maps.DeleteFunc(s.positions, func(key string, _ models.ReadingPosition) bool { return strings.HasPrefix(key, username+"/") })
+	maps.DeleteFunc(s.userData, func(key string, _ json.RawMessage) bool { return strings.HasPrefix(key, username+"/") })
+	maps.DeleteFunc(s.collections, func(_ string, c *collection) bool { return c.owner == username })
+	writeJSON(w, http.StatusOK, map[string]string{"message": "account deleted"})
+}
+
+// endSessionsLocked invalidates every token of username; the caller holds mu
+func (s *Server) endSessionsLocked(username string) {
+	maps.DeleteFunc(s.tokens, func(_, owner string) bool { return owner == username })
+}
+
+// Books
+
+func (s *Server) handleListBooks(w http.ResponseWriter, r *http.Request, username string) {
+	query := r.URL.Query()
+	matches := func(field, want string) bool {
+		return want == "" || strings.Contains(strings.ToLower(field), strings.ToLower(want))
+	}
+	var books []models.Book
+	for _, b := range s.Books() {
+		if matches(b.Title+" "+b.Author+" "+b.Series, query.Get("search")) &&
+			matches(b.Author, query.Get("author")) && matches(b.Series, query.Get("series")) &&
+			(query.Get("format") == "" || strings.EqualFold(b.FileFormat, query.Get("format"))) &&
+			(query.Get("type") == "" || b.ContentType == query.Get("type")) {
+			books = append(books, b)
+		}
+	}
+	sortBooks(books, query.Get("sort"), query.Get("order") == "desc")
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	page, limit = max(page, 1), max(limit, 0)
+	if limit == 0 {
+		limit = 20
+	}
+	start := min((page-1)*limit, len(books))
+	end := min(start+limit, len(books))
+	writeJSON(w, http.StatusOK, models.BooksResponse{
+		Books: append([]models.Book{}, books[start:end]...),
+		Count: end - start,
+		Total: len(books),
+		Page:  page,
+		Limit: limit,
+	})
+}
+
+// sortBooks orders books by one of the library's sort fields
+func sortBooks(books []models.Book, field string, desc bool) {
+	key := func(b models.Book) string {
+		switch field {
+		case "author":
+			return strings.ToLower(b.Author)
+		case "series":
+			return fmt.Sprintf("%s %08.2f", strings.ToLower(b.Series), b.SeriesIndex)
+		case "uploaded_at":
+			return b.UploadedAt.Format(time.RFC3339Nano)
+		}
+		return strings.ToLower(b.Title)
+	}
+	slices.SortStableFunc(books, func(a, b models.Book) int {
+		if desc {
+			a, b = b, a
+		}
+		return strings.Compare(key(a), key(b))
+	})
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request, username string) {
+	var stats models.LibraryStats
+	for _, b := range s.Books() {
+		stats.Total++
+		stats.TotalSize += b.FileSize
+		if b.IsComic() {
+			stats.Comics++
+		} else {
+			stats.Books++
+		}
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// handleGrouped answers with the books grouped by field, under the given key
+func (s *Server) handleGrouped(key string, field func(models.Book) string) func(http.ResponseWriter, *http.Request, string) {
+	return func(w http.ResponseWriter, r *http.Request, username string) {
+		groups := make(map[string][]models.Book)
+		for _, b := range s.Books() {
+			if name := field(b); name != "" {
+				groups[name] = append(groups[name], b)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]map[string][]models.Book{key: groups})
+	}
+}
+
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request, username string) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing file")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]models.Book{"book": s.addUploadedBook(header.Filename, data)})
+}
+
+// addUploadedBook adds a book for an uploaded file, titled after its name
+func (s *Server) addUploadedBook(filename string, data []byte) models.Book {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+	b := models.Book{
+		Title:      strings.TrimSuffix(filename, path.Ext(filename)),
+		Author:     "Unknown",
+		FileFormat: ext,
+	}
+	if ext == models.FileFormatCBZ || ext == models.FileFormatCBR {
+		b.ContentType = models.ContentTypeComic
+	}
+	b = s.AddBook(b)
+	s.SetFile(b.ID, data)
+	b.FileSize = int64(len(data))
+	return b
+}
+
+func (s *Server) handleGetBook(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	writeJSON(w, http.StatusOK, b.Book)
+}
+
+func (s *Server) handleUpdateBook(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	updated := b.Book
+	if !decode(w, r, &updated) {
+		return
+	}
+	// Only the metadata can be edited
+	b.Title, b.Author, b.Series, b.SeriesIndex = updated.Title, updated.Author, updated.Series, updated.SeriesIndex
+	writeJSON(w, http.StatusOK, b.Book)
+}
+
+func (s *Server) handleDeleteBook(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	s.books = slices.DeleteFunc(s.books, func(other *book) bool { return other == b })
+	for _, c := range s.collections {
+		c.bookIDs = slices.DeleteFunc(c.bookIDs, func(id string) bool { return id == b.ID })
+	}
+	s.publishLocked(api.Event{Type: api.EventBookDeleted, BookID: b.ID})
+	writeJSON(w, http.StatusOK, map[string]string{"message": "book deleted"})
+}
+
+func (s *Server) handleSetTags(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	b.Tags = body.Tags
+	writeJSON(w, http.StatusOK, b.Book)
+}
+
+func (s *Server) handleFile(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b.file)))
+	_, _ = w.Write(b.file)
+}
+
+func (s *Server) handleTOC(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	chapters := make([]models.Chapter, len(b.chapters))
+	for i := range b.chapters {
+		id := fmt.Sprintf("chapter-%d", i+1)
+		chapters[i] = models.Chapter{Index: i, ID: id, Href: id + ".xhtml", Title: fmt.Sprintf("Chapter %d", i+1)}
+	}
+	writeJSON(w, http.StatusOK, models.TOCResponse{Chapters: chapters})
+}
+
+func (s *Server) handleChapterText(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	chapter, err := strconv.Atoi(r.PathValue("chapter"))
+	if err != nil || chapter < 0 || chapter >= len(b.chapters) {
+		writeError(w, http.StatusNotFound, "chapter not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.ChapterContent{
+		BookID:      b.ID,
+		Chapter:     chapter,
+		Content:     b.chapters[chapter],
+		ContentType: "text/plain",
+	})
+}
+
+func (s *Server) handleGetPosition(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	pos, ok := s.positions[username+"/"+b.ID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no reading position")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.PositionResponse{Position: &pos})
+}
+
+func (s *Server) handleSavePosition(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	var body struct {
+		Chapter  string  `json:"chapter"`
+		Position float64 `json:"position"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	pos := models.ReadingPosition{BookID: b.ID, Chapter: body.Chapter, Position: body.Position, UpdatedAt: time.Now()}
+	s.positions[username+"/"+b.ID] = pos
+	s.publishLocked(api.Event{Type: api.EventPositionUpdated, BookID: b.ID, Chapter: pos.Chapter, Position: pos.Position})
+	writeJSON(w, http.StatusOK, models.PositionResponse{Position: &pos})
+}
+
+func (s *Server) handleGetCover(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	if len(b.cover) == 0 {
+		writeError(w, http.StatusNotFound, "no cover")
+		return
+	}
+	etag := fmt.Sprintf(`"%08x"`, crc32.ChecksumIEEE(b.cover))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(b.cover))
+	_, _ = w.Write(b.cover)
+}
+
+func (s *Server) handlePutCover(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	file, _, err := r.FormFile("cover")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing cover")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read cover")
+		return
+	}
+	b.cover = data
+	writeJSON(w, http.StatusOK, map[string]string{"message": "cover updated"})
+}
+
+func (s *Server) handleComicInfo(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	if !b.IsComic() {
+		writeError(w, http.StatusBadRequest, "not a comic")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.CBZInfoResponse{PageCount: len(b.pages), Title: b.Title, Author: b.Author, Series: b.Series})
+}
+
+func (s *Server) handleComicPage(w http.ResponseWriter, r *http.Request, username string, b *book) {
+	page, err := strconv.Atoi(r.PathValue("page"))
+	if err != nil || page < 0 || page >= len(b.pages) {
+		writeError(w, http.StatusNotFound, "page not found")
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(b.pages[page]))
+	_, _ = w.Write(b.pages[page])
+}
+
+// Collections
+
+func (s *Server) handleListCollections(w http.ResponseWriter, r *http.Request, username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	collections := []models.Collection{}
+	for _, c := range s.collections {
+		if c.owner == username {
+			collections = append(collections, c.Collection)
+		}
+	}
+	slices.SortFunc(collections, func(a, b models.Collection) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	writeJSON(w, http.StatusOK, models.CollectionsResponse{Collections: collections, Count: len(collections)})
+}
+
+func (s *Server) handleCreateCollection(w http.ResponseWriter, r *http.Request, username string) {
+	var body struct {
+		Name     string `json:"name"`
+		ParentID string `json:"parent_id"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	c := &collection{
+		Collection: models.Collection{ID: fmt.Sprintf("collection-%d", s.nextID), Name: body.Name, ParentID: body.ParentID, CreatedAt: time.Now()},
+		owner:      username,
+	}
+	s.collections[c.ID] = c
+	writeJSON(w, http.StatusCreated, map[string]models.Collection{"collection": c.Collection})
+}
+
+func (s *Server) handleDeleteCollection(w http.ResponseWriter, r *http.Request, c *collection) {
+	delete(s.collections, c.ID)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "collection deleted"})
+}
+
+func (s *Server) handleCollectionBooks(w http.ResponseWriter, r *http.Request, c *collection) {
+	books := []models.Book{}
+	for _, id := range c.bookIDs {
+		if b := s.findLocked(id); b != nil {
+			books = append(books, b.Book)
+		}
+	}
+	writeJSON(w, http.StatusOK, models.BooksResponse{Books: books, Count: len(books), Total: len(books), Page: 1, Limit: len(books)})
+}
+
+func (s *Server) handleCollectionAdd(w http.ResponseWriter, r *http.Request, c *collection) {
+	id := r.PathValue("book")
+	if s.findLocked(id) == nil {
+		writeError(w, http.StatusNotFound, "book not found")
+		return
+	}
+	if !slices.Contains(c.bookIDs, id) {
+		c.bookIDs = append(c.bookIDs, id)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "book added"})
+}
+
+func (s *Server) handleCollectionRemove(w http.ResponseWriter, r *http.Request, c *collection) {
+	id := r.PathValue("book")
+	c.bookIDs = slices.DeleteFunc(c.bookIDs, func(other string) bool { return other == id })
+	writeJSON(w, http.StatusOK, map[string]string{"message": "book removed"})
+}
+
+// User data
+
+func (s *Server) handleGetUserData(w http.ResponseWriter, r *http.Request, username string) {
+	s.mu.Lock()
+	data, ok := s.userData[username+"/"+r.PathValue("key")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (s *Server) handlePutUserData(w http.ResponseWriter, r *http.Request, username string) {
+	var data json.RawMessage
+	if !decode(w, r, &data) {
+		return
+	}
+	s.mu.Lock()
+	s.userData[username+"/"+r.PathValue("key")] = data
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
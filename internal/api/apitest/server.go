@@ -0,0 +1,306 @@
+// Package apitest provides a fake Webby server for exercising the API client
+// and the views end to end without a real backend. The server keeps its state
+// in memory; tests seed it with users, books and pages, and can replace any
+// endpoint to simulate failures or older servers.
+package apitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Server is a fake Webby server listening on a local port
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	mux         *http.ServeMux
+	overrides   map[string]http.Handler // By "METHOD /path" or "/path"
+	requests    []string
+	info        *api.ServerInfo // nil serves a 404, as older servers do
+	users       map[string]*user
+	tokens      map[string]string // Username by token
+	stale       map[string]string // Expired tokens that can still be renewed, by token
+	books       []*book
+	nextID      int
+	positions   map[string]models.ReadingPosition // By username and book ID
+	collections map[string]*collection
+	userData    map[string]json.RawMessage // By username and key
+	uploads     map[string]*upload         // Chunked upload sessions by ID
+	chunkSize   int64                      // Chunk size given to new uploads, 0 for the client's
+	events      []streamEvent              // Every event sent, for replay after Last-Event-ID
+	listeners   map[chan streamEvent]bool  // Open event streams
+	closing     chan struct{}              // Closed when the server shuts down
+	closeOnce   sync.Once
+}
+
+// user is an account on the fake server
+type user struct {
+	models.User
+	password string
+}
+
+// book is a book with its content
+type book struct {
+	models.Book
+	chapters []string
+	pages    [][]byte // Comic page images
+	cover    []byte
+	file     []byte
+}
+
+// collection is a collection and the IDs of its books
+type collection struct {
+	models.Collection
+	owner   string
+	bookIDs []string
+}
+
+// NewServer starts a fake server with no users or books. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		mux:         http.NewServeMux(),
+		overrides:   make(map[string]http.Handler),
+		users:       make(map[string]*user),
+		tokens:      make(map[string]string),
+		stale:       make(map[string]string),
+		positions:   make(map[string]models.ReadingPosition),
+		collections: make(map[string]*collection),
+		userData:    make(map[string]json.RawMessage),
+		uploads:     make(map[string]*upload),
+		listeners:   make(map[chan streamEvent]bool),
+		closing:     make(chan struct{}),
+	}
+	s.routes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Close ends the open event streams and shuts the server down
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.closing) })
+	s.Server.Close()
+}
+
+// Client returns an API client for the server, logged in as username if it
+// isn't empty
+func (s *Server) Client(username string) *api.Client {
+	token := ""
+	if username != "" {
+		token = s.Token(username)
+	}
+	return api.NewClient(s.URL, token)
+}
+
+// AddUser creates an account
+func (s *Server) AddUser(username, password string) models.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addUserLocked(username, "", password)
+}
+
+func (s *Server) addUserLocked(username, email, password string) models.User {
+	s.nextID++
+	u := &user{
+		User:     models.User{ID: fmt.Sprintf("user-%d", s.nextID), Username: username, Email: email, CreatedAt: time.Now()},
+		password: password,
+	}
+	s.users[username] = u
+	return u.User
+}
+
+// Token issues a session token for username, creating the account if needed
+func (s *Server) Token(username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[username]; !ok {
+		s.addUserLocked(username, "", "")
+	}
+	return s.issueTokenLocked(username)
+}
+
+func (s *Server) issueTokenLocked(username string) string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	token := hex.EncodeToString(buf)
+	s.tokens[token] = username
+	return token
+}
+
+// ExpireTokens invalidates every session, as when tokens time out
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]string)
+	s.stale = make(map[string]string)
+}
+
+// ExpireAccess makes every session's token refused by the API but still
+// renewable through /api/auth/refresh, as when a short-lived token lapses
+func (s *Server) ExpireAccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maps.Copy(s.stale, s.tokens)
+	s.tokens = make(map[string]string)
+}
+
+// AddBook adds a book with the given chapter texts and returns it with its ID,
+// upload date and content type filled in where they were empty
+func (s *Server) AddBook(b models.Book, chapters ...string) models.Book {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b.ID == "" {
+		s.nextID++
+		b.ID = fmt.Sprintf("book-%d", s.nextID)
+	}
+	if b.UploadedAt.IsZero() {
+		b.UploadedAt = time.Now()
+	}
+	if b.ContentType == "" {
+		b.ContentType = models.ContentTypeBook
+	}
+	s.books = append(s.books, &book{Book: b, chapters: chapters})
+	s.publishLocked(api.Event{Type: api.EventBookAdded, BookID: b.ID})
+	return b
+}
+
+// SetComicPages sets the page images of a comic
+func (s *Server) SetComicPages(bookID string, pages ...[]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.findLocked(bookID); b != nil {
+		b.pages = pages
+	}
+}
+
+// SetCover sets a book's cover image
+func (s *Server) SetCover(bookID string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.findLocked(bookID); b != nil {
+		b.cover = data
+	}
+}
+
+// SetFile sets the original file a book downloads as
+func (s *Server) SetFile(bookID string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.findLocked(bookID); b != nil {
+		b.file = data
+		b.FileSize = int64(len(data))
+	}
+}
+
+// SetInfo sets what /api/info reports; nil makes it a 404
+func (s *Server) SetInfo(info *api.ServerInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info = info
+}
+
+// Books returns the books on the server, in upload order
+func (s *Server) Books() []models.Book {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	books := make([]models.Book, len(s.books))
+	for i, b := range s.books {
+		books[i] = b.Book
+	}
+	return books
+}
+
+// Position returns the reading position username saved for a book
+func (s *Server) Position(username, bookID string) (models.ReadingPosition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pos, ok := s.positions[username+"/"+bookID]
+	return pos, ok
+}
+
+// Handle replaces an endpoint. pattern is a method and exact path, such as
+// "GET /api/books/stats", or a path alone for every method.
+func (s *Server) Handle(pattern string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[pattern] = handler
+}
+
+// Fail makes an endpoint answer with status and an error message, e.g. to
+// check how a view shows failures
+func (s *Server) Fail(pattern string, status int) {
+	s.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status, http.StatusText(status))
+	})
+}
+
+// Requests returns the requests served so far, as "METHOD /path?query"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// serve logs a request and passes it to its override, if any, or the fake API
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+	handler, ok := s.overrides[r.Method+" "+r.URL.Path]
+	if !ok {
+		handler, ok = s.overrides[r.URL.Path]
+	}
+	s.mu.Unlock()
+	if ok {
+		handler.ServeHTTP(w, r)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authed wraps a handler that needs a session, passing it the username
+func (s *Server) authed(handler func(w http.ResponseWriter, r *http.Request, username string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		username, known := s.tokens[token]
+		s.mu.Unlock()
+		if !ok || !known {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+		handler(w, r, username)
+	}
+}
+
+// findLocked returns the book with id, or nil; the caller holds mu
+func (s *Server) findLocked(id string) *book {
+	for _, b := range s.books {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the server's format
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, models.ErrorResponse{Error: message})
+}
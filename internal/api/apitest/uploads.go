@@ -0,0 +1,131 @@
+package apitest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// upload is a chunked upload session and the chunks received so far
+type upload struct {
+	id        string
+	owner     string
+	filename  string
+	size      int64
+	chunkSize int64
+	chunks    map[int][]byte
+}
+
+// session describes the upload as the API reports it
+func (u *upload) session() api.UploadSession {
+	received := slices.Sorted(maps.Keys(u.chunks))
+	if received == nil {
+		received = []int{}
+	}
+	return api.UploadSession{ID: u.id, ChunkSize: u.chunkSize, Received: received}
+}
+
+// SetUploadChunkSize sets the chunk size new chunked uploads are told to use,
+// or 0 to take the one the client asks for
+func (s *Server) SetUploadChunkSize(size int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunkSize = size
+}
+
+// withUpload wraps a handler for one of the user's upload sessions, answering
+// 404 for other sessions. The handler runs with mu held.
+func (s *Server) withUpload(handler func(w http.ResponseWriter, r *http.Request, u *upload)) func(http.ResponseWriter, *http.Request, string) {
+	return func(w http.ResponseWriter, r *http.Request, username string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		u, ok := s.uploads[r.PathValue("id")]
+		if !ok || u.owner != username {
+			writeError(w, http.StatusNotFound, "upload not found")
+			return
+		}
+		handler(w, r, u)
+	}
+}
+
+func (s *Server) handleStartUpload(w http.ResponseWriter, r *http.Request, username string) {
+	var body struct {
+		Filename  string `json:"filename"`
+		Size      int64  `json:"size"`
+		ChunkSize int64  `json:"chunk_size"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Filename == "" || body.Size <= 0 {
+		writeError(w, http.StatusBadRequest, "filename and size are required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chunkSize := body.ChunkSize
+	if s.chunkSize > 0 {
+		chunkSize = s.chunkSize
+	} else if chunkSize <= 0 {
+		chunkSize = api.DefaultUploadChunkSize
+	}
+	s.nextID++
+	u := &upload{
+		id:        fmt.Sprintf("upload-%d", s.nextID),
+		owner:     username,
+		filename:  body.Filename,
+		size:      body.Size,
+		chunkSize: chunkSize,
+		chunks:    make(map[int][]byte),
+	}
+	s.uploads[u.id] = u
+	writeJSON(w, http.StatusCreated, u.session())
+}
+
+func (s *Server) handleGetUpload(w http.ResponseWriter, r *http.Request, u *upload) {
+	writeJSON(w, http.StatusOK, u.session())
+}
+
+func (s *Server) handlePutChunk(w http.ResponseWriter, r *http.Request, u *upload) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || index < 0 || int64(index)*u.chunkSize >= u.size {
+		writeError(w, http.StatusBadRequest, "invalid chunk index")
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil || int64(len(data)) > u.chunkSize {
+		writeError(w, http.StatusBadRequest, "invalid chunk")
+		return
+	}
+	u.chunks[index] = data
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleCompleteUpload(w http.ResponseWriter, r *http.Request, username string) {
+	s.mu.Lock()
+	u, ok := s.uploads[r.PathValue("id")]
+	if !ok || u.owner != username {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+	var file bytes.Buffer
+	for index := 0; int64(index)*u.chunkSize < u.size; index++ {
+		file.Write(u.chunks[index])
+	}
+	if int64(file.Len()) != u.size {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, "upload is missing chunks")
+		return
+	}
+	delete(s.uploads, u.id)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, map[string]models.Book{"book": s.addUploadedBook(u.filename, file.Bytes())})
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrSessionExpired is returned when the token was refused and couldn't be renewed
+var ErrSessionExpired = errors.New("session expired, please log in again")
+
+// AuthEvent reports a change the client made to its session on its own: a
+// renewed token, or an empty Token when the session expired and the user has
+// to log in again
+type AuthEvent struct {
+	Token string
+}
+
+// AuthEvents returns the channel AuthEvents are sent on. Events are dropped
+// while nobody is receiving.
+func (c *Client) AuthEvents() <-chan AuthEvent {
+	return c.authEvents
+}
+
+// currentToken returns the token requests are sent with
+func (c *Client) currentToken() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.token
+}
+
+// send sends a request, allowing each try timeout (0 = no limit). When the
+// server refuses the token, it is renewed once and the request sent again.
+func (c *Client) send(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	token, err := c.session(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendAs(req, timeout, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || token == "" || isAuthRequest(req) {
+		return resp, err
+	}
+
+	renewed, err := c.renewToken(token)
+	if err != nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil // The caller reports the refusal
+	}
+	if _, err := c.session(req); err != nil {
+		resp.Body.Close()
+		return nil, err // The renewed token is another server's
+	}
+	retry := req.Clone(req.Context())
+	// Validators came from the refused token's cache entry; the renewed
+	// token's lookup adds its own
+	retry.Header.Del("If-None-Match")
+	retry.Header.Del("If-Modified-Since")
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+	return c.sendAs(retry, timeout, renewed)
+}
+
+// isAuthRequest reports whether req logs in or renews a session, where a
+// refused token means bad credentials rather than an expired session
+func isAuthRequest(req *http.Request) bool {
+	switch req.URL.Path {
+	case "/api/auth/login", "/api/auth/register", "/api/auth/refresh":
+		return true
+	}
+	return false
+}
+
+// renewToken exchanges a refused token for a new one. Requests refused at the
+// same time share one renewal; a token the server wouldn't renew isn't tried
+// again.
+func (c *Client) renewToken(refused string) (string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.authMu.Lock()
+	current, expired := c.token, c.expiredToken
+	c.authMu.Unlock()
+	if current != refused {
+		return current, nil // Renewed (or replaced by a login) since the request went out
+	}
+	if refused == expired {
+		return "", ErrSessionExpired
+	}
+
+	token, err := c.RefreshToken()
+	if err != nil || token == "" {
+		c.authMu.Lock()
+		c.expiredToken = refused
+		c.authMu.Unlock()
+		c.notifyAuth(AuthEvent{})
+		return "", ErrSessionExpired
+	}
+	c.authMu.Lock()
+	c.token = token
+	c.authMu.Unlock()
+	c.notifyAuth(AuthEvent{Token: token})
+	return token, nil
+}
+
+// notifyAuth sends an AuthEvent without waiting for a receiver
+func (c *Client) notifyAuth(event AuthEvent) {
+	select {
+	case c.authEvents <- event:
+	default:
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// A GET revalidated under a token that has since expired is sent again under
+// the renewed token without the old cache entry's validators, so the server
+// answers with the full body rather than a 304 the client can't serve
+func TestRenewedRequestDropsOldValidators(t *testing.T) {
+	var mu sync.Mutex
+	valid := "old"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/auth/refresh" {
+			valid = "new"
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": valid})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "1", "title": "Dune"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "old")
+	if _, err := c.GetBook("1"); err != nil {
+		t.Fatalf("first GetBook: %v", err)
+	}
+
+	mu.Lock()
+	valid = "expired" // The old token is refused from now on
+	mu.Unlock()
+	c.authMu.Lock()
+	c.token = "old"
+	c.authMu.Unlock()
+
+	book, err := c.GetBook("1")
+	if err != nil {
+		t.Fatalf("GetBook after renewal: %v", err)
+	}
+	if book.Title != "Dune" {
+		t.Fatalf("title = %q, want Dune", book.Title)
+	}
+	if got := c.currentToken(); got != "new" {
+		t.Fatalf("token = %q, want the renewed one", got)
+	}
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Response cache limits. Larger bodies, such as book downloads, pass through
+// without being kept.
+const (
+	responseCacheBytes = 32 << 20
+	maxCachedResponse  = 4 << 20
+)
+
+// responseCache keeps GET responses that carry an ETag or Last-Modified date.
+// Repeat requests ask the server whether they changed, and a 304 Not Modified
+// is answered from the cache, so revisiting a view doesn't download the same
+// JSON again. Entries are always revalidated, so they can't go stale.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element // Of *cachedResponse, most recently used first
+	order   *list.List
+	size    int64
+}
+
+// cachedResponse is a stored response body and the validators to check it with
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// cacheKey identifies a request's response. The token is part of it so one
+// user's responses are never served to another.
+func cacheKey(req *http.Request) string {
+	if req.Method != "GET" || req.Header.Get("Range") != "" || req.Context().Value(skipResponseCache{}) != nil {
+		return ""
+	}
+	return req.Header.Get("Authorization") + " " + req.Header.Get("Accept") + " " + req.URL.String()
+}
+
+// lookup returns the cached response for key and adds its validators to req
+func (c *responseCache) lookup(key string, req *http.Request) *cachedResponse {
+	if c == nil || key == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*cachedResponse)
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	return entry
+}
+
+// peek returns the cached response for key without revalidating it, for use
+// while the server can't be reached
+func (c *responseCache) peek(key string) *cachedResponse {
+	if c == nil || key == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		return elem.Value.(*cachedResponse)
+	}
+	return nil
+}
+
+// complete answers a 304 from the cache, and records cacheable responses as
+// their bodies are read
+func (c *responseCache) complete(key string, cached *cachedResponse, resp *http.Response) *http.Response {
+	if c == nil || key == "" {
+		return resp
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		if Debug {
+			debugf("%s not modified, using cached response", resp.Request.URL)
+		}
+		return cached.response(resp)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp
+	}
+
+	entry := &cachedResponse{
+		key:          key,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	if (entry.etag == "" && entry.lastModified == "") || resp.ContentLength > maxCachedResponse {
+		c.remove(key)
+		return resp
+	}
+	entry.header = resp.Header.Clone()
+	entry.header.Del(RequestIDHeader)
+	resp.Body = &cacheRecorder{ReadCloser: resp.Body, cache: c, entry: entry}
+	return resp
+}
+
+// response rebuilds the cached response, keeping the live response's request ID
+func (e *cachedResponse) response(live *http.Response) *http.Response {
+	header := e.header.Clone()
+	if id := live.Header.Get(RequestIDHeader); id != "" {
+		header.Set(RequestIDHeader, id)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         live.Proto,
+		ProtoMajor:    live.ProtoMajor,
+		ProtoMinor:    live.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       live.Request,
+	}
+}
+
+// store adds an entry, evicting the least recently used ones to stay in size
+func (c *responseCache) store(entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(entry.key)
+	c.entries[entry.key] = c.order.PushFront(entry)
+	c.size += int64(len(entry.body))
+	for c.size > responseCacheBytes {
+		c.removeLocked(c.order.Back().Value.(*cachedResponse).key)
+	}
+}
+
+// remove drops the entry for key, if any
+func (c *responseCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+}
+
+func (c *responseCache) removeLocked(key string) {
+	if elem, ok := c.entries[key]; ok {
+		c.size -= int64(len(elem.Value.(*cachedResponse).body))
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// clear drops every entry
+func (c *responseCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.size = 0
+}
+
+// cacheRecorder copies a response body as it is read, storing it once read to
+// the end unless it grows too large to keep
+type cacheRecorder struct {
+	io.ReadCloser
+	cache *responseCache
+	entry *cachedResponse
+	buf   bytes.Buffer
+	done  bool
+}
+
+func (r *cacheRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if r.done {
+		return n, err
+	}
+	if r.buf.Len()+n > maxCachedResponse {
+		r.done = true
+		r.buf = bytes.Buffer{}
+		return n, err
+	}
+	r.buf.Write(p[:n])
+	if err == io.EOF {
+		r.done = true
+		r.entry.body = r.buf.Bytes()
+		r.cache.store(r.entry)
+	}
+	return n, err
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Optional server features, as named in the server info
+const (
+	FeatureSharing     = "sharing"
+	FeatureComics      = "comics"
+	FeatureCollections = "collections"
+)
+
+// ServerInfo describes the server and the optional features it offers
+type ServerInfo struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Features []string `json:"features"` // nil if the server doesn't list them
+}
+
+// FeatureError is returned when the server doesn't offer a feature. It matches
+// ErrNotSupported with errors.Is.
+type FeatureError struct {
+	Feature string
+}
+
+func (e *FeatureError) Error() string {
+	return fmt.Sprintf("this server doesn't support %s", e.Feature)
+}
+
+// Is reports whether target is ErrNotSupported
+func (e *FeatureError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// LoadServerInfo asks the server for its version and features, and from then on
+// requests for features it lacks fail with a FeatureError instead of reaching
+// the server. Servers without an info endpoint are assumed to offer everything.
+func (c *Client) LoadServerInfo() (*ServerInfo, error) {
+	resp, err := c.request("GET", "/api/info", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info *ServerInfo
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		info = &ServerInfo{}
+	default:
+		if info, err = parseResponse[*ServerInfo](resp); err != nil {
+			return nil, err
+		}
+	}
+	if Debug {
+		debugf("server %q version %q, features %v", info.Name, info.Version, info.Features)
+	}
+	c.infoMu.Lock()
+	c.info = info
+	c.infoMu.Unlock()
+	return info, nil
+}
+
+// ServerInfo returns what LoadServerInfo found, or nil before it has run
+func (c *Client) ServerInfo() *ServerInfo {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.info
+}
+
+// Supports reports whether the server offers feature, assuming it does until
+// the server says otherwise
+func (c *Client) Supports(feature string) bool {
+	info := c.ServerInfo()
+	return info == nil || info.Features == nil || slices.Contains(info.Features, feature)
+}
+
+// require returns a FeatureError if the server doesn't offer feature
+func (c *Client) require(feature string) error {
+	if !c.Supports(feature) {
+		return &FeatureError{Feature: feature}
+	}
+	return nil
+}
+
+// missingEndpoint reports whether resp says a feature's endpoint doesn't exist,
+// closing it and returning a FeatureError if so. Only for endpoints that can't
+// 404 otherwise, such as listings.
+func missingEndpoint(resp *http.Response, feature string) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return &FeatureError{Feature: feature}
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -10,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/justyntemme/webby-t/pkg/models"
@@ -17,25 +20,89 @@ import (
 
 // Client is the HTTP client for the webby API
 type Client struct {
-	baseURL    string
-	token      string
-	httpClient *http.Client
+	baseURL     string
+	token       string
+	httpClient  *http.Client
+	maxAttempts int // Tries per idempotent request, see SetMaxAttempts
+
+	// Sent with every request, see SetUserAgent and SetHeaders
+	userAgent string
+	headers   http.Header
+
+	// Time limits per try (0 = none), see SetTimeouts
+	requestTimeout  time.Duration
+	transferTimeout time.Duration
+
+	// Responses to revalidate with the server instead of downloading again
+	cache  *responseCache
+	covers *coverCache
+
+	// What the server offers, see LoadServerInfo
+	infoMu sync.Mutex
+	info   *ServerInfo
+
+	// Reachability and writes waiting for the server, see offline.go
+	conn connectivity
+
+	// Throttling notices, see BusyEvents
+	busyEvents chan ServerBusyEvent
+
+	// Paces requests (nil = no limit), see SetRateLimit
+	limiter *rateLimiter
+
+	// Session renewal, see auth.go
+	authMu       sync.Mutex // Guards baseURL, token, expiredToken, profile and switched
+	refreshMu    sync.Mutex // Lets one request at a time renew the token
+	expiredToken string     // Token the server wouldn't renew
+	authEvents   chan AuthEvent
+
+	// Server switching, see profile.go
+	profile  string        // Name of the profile in use, if any
+	switched chan struct{} // Closed on switching servers
 }
 
+// ErrNotSupported is returned when the server doesn't implement an optional endpoint
+var ErrNotSupported = errors.New("not supported by the server")
+
 // NewClient creates a new API client
 func NewClient(baseURL, token string) *Client {
 	return &Client{
-		baseURL: baseURL,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:         baseURL,
+		token:           token,
+		httpClient:      &http.Client{Transport: newTransport()}, // Time limits are set per request
+		maxAttempts:     DefaultMaxAttempts,
+		userAgent:       DefaultUserAgent,
+		requestTimeout:  DefaultRequestTimeout,
+		transferTimeout: DefaultTransferTimeout,
+		cache:           newResponseCache(),
+		covers:          newCoverCache(),
+		limiter:         newRateLimiter(DefaultRateLimit, 2*DefaultRateLimit),
+		authEvents:      make(chan AuthEvent, 1),
+		switched:        make(chan struct{}),
+		conn:            connectivity{events: make(chan ConnectivityEvent, 1)},
+		busyEvents:      make(chan ServerBusyEvent, 1),
 	}
 }
 
 // SetToken updates the authentication token
 func (c *Client) SetToken(token string) {
+	c.authMu.Lock()
 	c.token = token
+	c.authMu.Unlock()
+	c.cache.clear()
+	c.covers.clear()
+}
+
+// newTransport returns a copy of the default transport that also honors ALL_PROXY
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFromEnvironment
+	return transport
+}
+
+// transport returns the client's transport, for its TLS and proxy settings
+func (c *Client) transport() *http.Transport {
+	return c.httpClient.Transport.(*http.Transport)
 }
 
 // Debug enables debug logging for API requests
@@ -43,6 +110,11 @@ var Debug bool
 
 // request makes an HTTP request to the API
 func (c *Client) request(method, path string, body interface{}) (*http.Response, error) {
+	return c.requestContext(context.Background(), method, path, body)
+}
+
+// requestContext makes an HTTP request to the API that is abandoned when ctx is cancelled
+func (c *Client) requestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -52,22 +124,103 @@ func (c *Client) request(method, path string, body interface{}) (*http.Response,
 		bodyReader = bytes.NewReader(data)
 	}
 
-	fullURL := c.baseURL + path
-	if Debug {
-		fmt.Fprintf(os.Stderr, "[API] %s %s\n", method, fullURL)
-	}
-
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint(path), bodyReader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	return c.do(req)
+}
+
+// do sends an API request with the auth token and a fresh request ID, limited to
+// the request timeout. Idempotent requests that fail transiently are retried with
+// backoff under the same ID.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.send(req, c.requestTimeout)
+}
+
+// doTransfer is do for book uploads and downloads, limited to the transfer
+// timeout instead
+func (c *Client) doTransfer(req *http.Request) (*http.Response, error) {
+	return c.send(req, c.transferTimeout)
+}
+
+// sendAs sends a request with token, allowing each try timeout (0 = no limit)
+func (c *Client) sendAs(req *http.Request, timeout time.Duration, token string) (*http.Response, error) {
+	id := newRequestID()
+	c.addHeaders(req)
+	req.Header.Set(RequestIDHeader, id)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if Debug {
+		debugf("%s %s (request %s)", req.Method, req.URL, id)
+		logRequestBody(id, req)
 	}
+	start := time.Now()
+	if resp, handled, err := c.offlineResponse(req); handled {
+		return resp, err
+	}
+
+	key := cacheKey(req)
+	cached := c.cache.lookup(key, req)
 
-	return c.httpClient.Do(req)
+	attempts := c.attemptsFor(req)
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, &RequestError{RequestID: id, Err: err}
+		}
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, &RequestError{RequestID: id, Err: err}
+			}
+			req.Body = body
+		}
+		try, cancel := withTimeout(req, timeout)
+		resp, err := c.httpClient.Do(try)
+		retry := attempt < attempts && shouldRetry(req.Context(), resp, err)
+		if throttled(resp) {
+			retry = c.retryThrottled(req, resp, attempt)
+		}
+		if !retry {
+			if err != nil {
+				cancel()
+				if isUnreachable(err) && req.Context().Value(probeRequest{}) == nil {
+					c.setOffline()
+				}
+				if Debug {
+					debugf("request %s failed after %v: %v", id, time.Since(start).Round(time.Millisecond), err)
+				}
+				return nil, &RequestError{RequestID: id, Err: err}
+			}
+			resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			if Debug {
+				debugf("request %s: %s in %v", id, resp.Status, time.Since(start).Round(time.Millisecond))
+				logResponseBody(id, resp)
+			}
+			return c.cache.complete(key, cached, resp), nil
+		}
+
+		delay := retryDelay(attempt, resp)
+		if throttled(resp) {
+			sendLatest(c.busyEvents, ServerBusyEvent{Wait: delay})
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+		if Debug {
+			debugf("request %s attempt %d failed (%v), retrying in %v", id, attempt, retryReason(resp, err), delay)
+		}
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, &RequestError{RequestID: id, Err: req.Context().Err()}
+		}
+	}
 }
 
 // parseResponse reads and unmarshals the response body
@@ -83,9 +236,9 @@ func parseResponse[T any](resp *http.Response) (T, error) {
 	if resp.StatusCode >= 400 {
 		var errResp models.ErrorResponse
 		if err := json.Unmarshal(body, &errResp); err != nil {
-			return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+			return result, withRequestID(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))
 		}
-		return result, fmt.Errorf("%s", errResp.Error)
+		return result, withRequestID(resp, fmt.Errorf("%s", errResp.Error))
 	}
 
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -125,7 +278,7 @@ func (c *Client) Register(username, email, password string) (*models.AuthRespons
 // RefreshToken refreshes the JWT token
 func (c *Client) RefreshToken() (string, error) {
 	resp, err := c.request("POST", "/api/auth/refresh", map[string]string{
-		"token": c.token,
+		"token": c.currentToken(),
 	})
 	if err != nil {
 		return "", err
@@ -157,6 +310,27 @@ func (c *Client) GetCurrentUser() (*models.User, error) {
 // ListBooks returns a list of books with optional filtering
 // contentType can be "book", "comic", or "" for all
 func (c *Client) ListBooks(page, limit int, sort, order, search, contentType string) (*models.BooksResponse, error) {
+	return c.ListBooksContext(context.Background(), page, limit, sort, order, search, contentType)
+}
+
+// ListBooksContext is ListBooks with a context, so a superseded search can be cancelled
+func (c *Client) ListBooksContext(ctx context.Context, page, limit int, sort, order, search, contentType string) (*models.BooksResponse, error) {
+	return c.ListBooksFiltered(ctx, page, limit, sort, order, BookFilters{Search: search, ContentType: contentType})
+}
+
+// BookFilters narrows a book listing. Search is free text; the other fields each
+// match one book field.
+type BookFilters struct {
+	Search      string
+	Author      string
+	Series      string
+	Format      string // File format: epub, pdf, cbz or cbr
+	ContentType string // "book", "comic", or "" for all
+}
+
+// ListBooksFiltered returns a page of books matching filters. Servers that don't
+// know a filter ignore it, so callers should check the results.
+func (c *Client) ListBooksFiltered(ctx context.Context, page, limit int, sort, order string, filters BookFilters) (*models.BooksResponse, error) {
 	params := url.Values{}
 	if page > 0 {
 		params.Set("page", fmt.Sprintf("%d", page))
@@ -170,11 +344,20 @@ func (c *Client) ListBooks(page, limit int, sort, order, search, contentType str
 	if order != "" {
 		params.Set("order", order)
 	}
-	if search != "" {
-		params.Set("search", search)
+	if filters.Search != "" {
+		params.Set("search", filters.Search)
 	}
-	if contentType != "" {
-		params.Set("type", contentType)
+	if filters.Author != "" {
+		params.Set("author", filters.Author)
+	}
+	if filters.Series != "" {
+		params.Set("series", filters.Series)
+	}
+	if filters.Format != "" {
+		params.Set("format", filters.Format)
+	}
+	if filters.ContentType != "" {
+		params.Set("type", filters.ContentType)
 	}
 
 	path := "/api/books"
@@ -182,7 +365,7 @@ func (c *Client) ListBooks(page, limit int, sort, order, search, contentType str
 		path += "?" + params.Encode()
 	}
 
-	resp, err := c.request("GET", path, nil)
+	resp, err := c.requestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +381,61 @@ func (c *Client) GetBook(id string) (*models.Book, error) {
 	return parseResponse[*models.Book](resp)
 }
 
+// BookUpdate holds the metadata fields to change on a book; nil fields are left
+// as they are
+type BookUpdate struct {
+	Title       *string  `json:"title,omitempty"`
+	Author      *string  `json:"author,omitempty"`
+	Series      *string  `json:"series,omitempty"`
+	SeriesIndex *float64 `json:"series_index,omitempty"`
+}
+
+// apply sets the changed fields on book
+func (u BookUpdate) apply(book *models.Book) {
+	if u.Title != nil {
+		book.Title = *u.Title
+	}
+	if u.Author != nil {
+		book.Author = *u.Author
+	}
+	if u.Series != nil {
+		book.Series = *u.Series
+	}
+	if u.SeriesIndex != nil {
+		book.SeriesIndex = *u.SeriesIndex
+	}
+}
+
+// UpdateBook changes a book's metadata and returns the updated book. It sends a
+// PATCH with just the changed fields; servers without PATCH get a PUT of the
+// whole book. Returns ErrNotSupported if the server can't edit books.
+func (c *Client) UpdateBook(id string, fields BookUpdate) (*models.Book, error) {
+	resp, err := c.request("PATCH", "/api/books/"+id, fields)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		resp.Body.Close()
+		book, err := c.GetBook(id)
+		if err != nil {
+			return nil, err
+		}
+		fields.apply(book)
+		if resp, err = c.request("PUT", "/api/books/"+id, book); err != nil {
+			return nil, err
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, ErrNotSupported
+	case http.StatusNoContent:
+		resp.Body.Close()
+		return c.GetBook(id)
+	}
+	return parseResponse[*models.Book](resp)
+}
+
 // DeleteBook deletes a book by ID
 func (c *Client) DeleteBook(id string) error {
 	resp, err := c.request("DELETE", "/api/books/"+id, nil)
@@ -208,11 +446,81 @@ func (c *Client) DeleteBook(id string) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete book: %s", string(body))
+		return withRequestID(resp, fmt.Errorf("failed to delete book: %s", string(body)))
 	}
+	c.covers.remove(id)
 	return nil
 }
 
+// SetBookTags replaces a book's tags, returning ErrNotSupported if the server doesn't store tags
+func (c *Client) SetBookTags(bookID string, tags []string) error {
+	resp, err := c.request("PUT", "/api/books/"+bookID+"/tags", map[string][]string{"tags": tags})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed ||
+		resp.StatusCode == http.StatusNotImplemented:
+		return ErrNotSupported
+	case resp.StatusCode >= 400:
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to set tags: %s", string(body)))
+	}
+	return nil
+}
+
+// DownloadBook streams the original book file to w
+func (c *Client) DownloadBook(id string, w io.Writer) error {
+	return c.DownloadBookProgress(id, w, nil)
+}
+
+// DownloadProgress is called as a download is written, with the bytes so far and
+// the file size (0 if the server didn't say)
+type DownloadProgress func(written, total int64)
+
+// DownloadBookProgress streams the original book file to w, reporting progress
+// after each write if progress is not nil
+func (c *Client) DownloadBookProgress(id string, w io.Writer, progress DownloadProgress) error {
+	req, err := http.NewRequest("GET", c.endpoint("/api/books/"+id+"/file"), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doTransfer(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to download book: %s", string(body)))
+	}
+
+	if progress != nil {
+		w = &progressWriter{w: w, total: max(0, resp.ContentLength), report: progress}
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// progressWriter reports the bytes written through it
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	report  DownloadProgress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.report(p.written, p.total)
+	return n, err
+}
+
 // UploadBook uploads an epub file to the server
 func (c *Client) UploadBook(filePath string) (*models.Book, error) {
 	// Open the file
@@ -243,23 +551,23 @@ func (c *Client) UploadBook(filePath string) (*models.Book, error) {
 	}
 
 	// Create the request
-	req, err := http.NewRequest("POST", c.baseURL+"/api/books", &buf)
+	req, err := http.NewRequest("POST", c.endpoint("/api/books"), &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
 
 	// Send the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doTransfer(req)
 	if err != nil {
 		return nil, err
 	}
+	return parseUploadedBook(resp)
+}
 
-	// Parse response
+// parseUploadedBook reads the book the server created from an upload
+func parseUploadedBook(resp *http.Response) (*models.Book, error) {
 	result, err := parseResponse[map[string]interface{}](resp)
 	if err != nil {
 		return nil, err
@@ -290,6 +598,39 @@ func (c *Client) UploadBook(filePath string) (*models.Book, error) {
 	return book, nil
 }
 
+// GetLibraryStats returns the book counts and total size of the library. Servers
+// without a stats endpoint have the book list summed page by page instead.
+func (c *Client) GetLibraryStats() (*models.LibraryStats, error) {
+	resp, err := c.request("GET", "/api/books/stats", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return c.sumLibraryStats()
+	}
+	return parseResponse[*models.LibraryStats](resp)
+}
+
+// sumLibraryStats counts every book in the library
+func (c *Client) sumLibraryStats() (*models.LibraryStats, error) {
+	stats := &models.LibraryStats{}
+	for book, err := range c.AllBooks(context.Background(), "", "", BookFilters{}) {
+		if err != nil {
+			return nil, err
+		}
+		stats.Total++
+		stats.TotalSize += book.FileSize
+		if book.IsComic() {
+			stats.Comics++
+		} else {
+			stats.Books++
+		}
+	}
+	return stats, nil
+}
+
 // GetBooksByAuthor returns books grouped by author
 func (c *Client) GetBooksByAuthor() (map[string][]models.Book, error) {
 	resp, err := c.request("GET", "/api/books/by-author", nil)
@@ -353,19 +694,20 @@ func (c *Client) GetPosition(bookID string) (*models.ReadingPosition, error) {
 }
 
 // SavePosition saves the current reading position
+// While the server can't be reached, the position is queued and sent on reconnecting.
 func (c *Client) SavePosition(bookID, chapter string, position float64) error {
-	resp, err := c.request("POST", "/api/books/"+bookID+"/position", map[string]interface{}{
+	resp, queued, err := c.requestOrQueue("POST", "/api/books/"+bookID+"/position", map[string]interface{}{
 		"chapter":  chapter,
 		"position": position,
 	})
-	if err != nil {
+	if err != nil || queued {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to save position: %s", string(body))
+		return withRequestID(resp, fmt.Errorf("failed to save position: %s", string(body)))
 	}
 	return nil
 }
@@ -374,18 +716,31 @@ func (c *Client) SavePosition(bookID, chapter string, position float64) error {
 
 // ListCollections returns all collections
 func (c *Client) ListCollections() (*models.CollectionsResponse, error) {
+	if err := c.require(FeatureCollections); err != nil {
+		return nil, err
+	}
 	resp, err := c.request("GET", "/api/collections", nil)
 	if err != nil {
 		return nil, err
 	}
+	if err := missingEndpoint(resp, FeatureCollections); err != nil {
+		return nil, err
+	}
 	return parseResponse[*models.CollectionsResponse](resp)
 }
 
-// CreateCollection creates a new collection
-func (c *Client) CreateCollection(name string) (*models.Collection, error) {
-	resp, err := c.request("POST", "/api/collections", map[string]string{
+// CreateCollection creates a new collection, nested inside parentID unless it is empty
+func (c *Client) CreateCollection(name, parentID string) (*models.Collection, error) {
+	if err := c.require(FeatureCollections); err != nil {
+		return nil, err
+	}
+	body := map[string]string{
 		"name": name,
-	})
+	}
+	if parentID != "" {
+		body["parent_id"] = parentID
+	}
+	resp, err := c.request("POST", "/api/collections", body)
 	if err != nil {
 		return nil, err
 	}
@@ -399,6 +754,9 @@ func (c *Client) CreateCollection(name string) (*models.Collection, error) {
 
 // DeleteCollection deletes a collection
 func (c *Client) DeleteCollection(id string) error {
+	if err := c.require(FeatureCollections); err != nil {
+		return err
+	}
 	resp, err := c.request("DELETE", "/api/collections/"+id, nil)
 	if err != nil {
 		return err
@@ -407,7 +765,101 @@ func (c *Client) DeleteCollection(id string) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete collection: %s", string(body))
+		return withRequestID(resp, fmt.Errorf("failed to delete collection: %s", string(body)))
+	}
+	return nil
+}
+
+// AddBookToCollection adds a book to a collection
+func (c *Client) AddBookToCollection(collectionID, bookID string) error {
+	if err := c.require(FeatureCollections); err != nil {
+		return err
+	}
+	resp, err := c.request("POST", "/api/collections/"+collectionID+"/books/"+bookID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to add book to collection: %s", string(body)))
+	}
+	return nil
+}
+
+// GetCollectionBooks returns the books in a collection
+func (c *Client) GetCollectionBooks(collectionID string) (*models.BooksResponse, error) {
+	if err := c.require(FeatureCollections); err != nil {
+		return nil, err
+	}
+	resp, err := c.request("GET", "/api/collections/"+collectionID+"/books", nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[*models.BooksResponse](resp)
+}
+
+// RemoveBookFromCollection removes a book from a collection
+func (c *Client) RemoveBookFromCollection(collectionID, bookID string) error {
+	if err := c.require(FeatureCollections); err != nil {
+		return err
+	}
+	resp, err := c.request("DELETE", "/api/collections/"+collectionID+"/books/"+bookID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to remove book from collection: %s", string(body)))
+	}
+	return nil
+}
+
+// User data methods: a per-user key-value store for settings that follow the user
+
+// GetUserData decodes the value stored under key into v, reporting false if nothing
+// is stored yet. Returns ErrNotSupported if the server has no user data store.
+func (c *Client) GetUserData(key string, v interface{}) (bool, error) {
+	resp, err := c.request("GET", "/api/user/data/"+url.PathEscape(key), nil)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusNoContent:
+		resp.Body.Close()
+		return false, nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return false, ErrNotSupported
+	}
+	data, err := parseResponse[json.RawMessage](resp)
+	if err != nil {
+		return false, err
+	}
+	if string(data) == "null" {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// PutUserData stores v under key. Returns ErrNotSupported if the server has no user data store.
+func (c *Client) PutUserData(key string, v interface{}) error {
+	resp, err := c.request("PUT", "/api/user/data/"+url.PathEscape(key), v)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed ||
+		resp.StatusCode == http.StatusNotImplemented:
+		return ErrNotSupported
+	case resp.StatusCode >= 400:
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to save %s: %s", key, string(body)))
 	}
 	return nil
 }
@@ -416,15 +868,24 @@ func (c *Client) DeleteCollection(id string) error {
 
 // GetSharedBooks returns books shared with the current user
 func (c *Client) GetSharedBooks() (*models.BooksResponse, error) {
+	if err := c.require(FeatureSharing); err != nil {
+		return nil, err
+	}
 	resp, err := c.request("GET", "/api/books/shared", nil)
 	if err != nil {
 		return nil, err
 	}
+	if err := missingEndpoint(resp, FeatureSharing); err != nil {
+		return nil, err
+	}
 	return parseResponse[*models.BooksResponse](resp)
 }
 
 // ShareBook shares a book with another user
 func (c *Client) ShareBook(bookID, userID string) error {
+	if err := c.require(FeatureSharing); err != nil {
+		return err
+	}
 	resp, err := c.request("POST", "/api/books/"+bookID+"/share/"+userID, nil)
 	if err != nil {
 		return err
@@ -433,13 +894,16 @@ func (c *Client) ShareBook(bookID, userID string) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to share book: %s", string(body))
+		return withRequestID(resp, fmt.Errorf("failed to share book: %s", string(body)))
 	}
 	return nil
 }
 
 // UnshareBook removes sharing for a book
 func (c *Client) UnshareBook(bookID, userID string) error {
+	if err := c.require(FeatureSharing); err != nil {
+		return err
+	}
 	resp, err := c.request("DELETE", "/api/books/"+bookID+"/share/"+userID, nil)
 	if err != nil {
 		return err
@@ -448,7 +912,7 @@ func (c *Client) UnshareBook(bookID, userID string) error {
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to unshare book: %s", string(body))
+		return withRequestID(resp, fmt.Errorf("failed to unshare book: %s", string(body)))
 	}
 	return nil
 }
@@ -492,42 +956,52 @@ func (c *Client) Health() error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("server unhealthy: status %d", resp.StatusCode)
+		return withRequestID(resp, fmt.Errorf("server unhealthy: status %d", resp.StatusCode))
 	}
 	return nil
 }
 
 // Comic methods
 
-// GetBookCover retrieves the cover image for a book
-func (c *Client) GetBookCover(bookID string) ([]byte, string, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/books/"+bookID+"/cover", nil)
+// UploadBookCover replaces a book's cover with the image at filePath
+func (c *Client) UploadBookCover(bookID, filePath string) error {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, "", err
+		return fmt.Errorf("failed to open file: %w", err)
 	}
+	defer file.Close()
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("cover", filepath.Base(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	req, err := http.NewRequest("PUT", c.endpoint("/api/books/"+bookID+"/cover"), &buf)
 	if err != nil {
-		return nil, "", err
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.doTransfer(req)
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("failed to get cover: %s", string(body))
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, "", err
+		return withRequestID(resp, fmt.Errorf("failed to upload cover: %s", string(body)))
 	}
-
-	contentType := resp.Header.Get("Content-Type")
-	return data, contentType, nil
+	c.covers.remove(bookID)
+	return nil
 }
 
 // CBZInfoResponse represents the CBZ info response from the API
@@ -540,6 +1014,9 @@ type CBZInfoResponse struct {
 
 // GetComicPages returns the page count for a comic (CBZ)
 func (c *Client) GetComicPages(bookID string) (*CBZInfoResponse, error) {
+	if err := c.require(FeatureComics); err != nil {
+		return nil, err
+	}
 	resp, err := c.request("GET", "/api/books/"+bookID+"/cbz/info", nil)
 	if err != nil {
 		return nil, err
@@ -547,29 +1024,50 @@ func (c *Client) GetComicPages(bookID string) (*CBZInfoResponse, error) {
 	return parseResponse[*CBZInfoResponse](resp)
 }
 
-// GetComicPage retrieves a specific page image from a comic (0-indexed)
+// GetComicPage retrieves a specific page image from a comic (0-indexed). A
+// large page whose connection drops partway through resumes where it stopped.
 func (c *Client) GetComicPage(bookID string, page int) ([]byte, string, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/books/%s/cbz/page/%d", c.baseURL, bookID, page), nil)
-	if err != nil {
-		return nil, "", err
-	}
+	return c.getComicPage(context.Background(), bookID, page)
+}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+// ComicPage is one page fetched by GetComicPageSet
+type ComicPage struct {
+	Index       int // 0-indexed
+	Data        []byte
+	ContentType string
+	Err         error
+}
+
+// GetComicPageSet fetches several comic pages (0-indexed), a few at a time over
+// the client's kept-alive connections rather than one round trip after another.
+// Results are in the order asked for; a page that fails carries its error
+// without stopping the others.
+func (c *Client) GetComicPageSet(ctx context.Context, bookID string, pages []int) []ComicPage {
+	results := make([]ComicPage, len(pages))
+	_ = ForEach(ctx, len(pages), DefaultParallelism, func(ctx context.Context, i int) error {
+		data, contentType, err := c.getComicPage(ctx, bookID, pages[i])
+		results[i] = ComicPage{Index: pages[i], Data: data, ContentType: contentType, Err: err}
+		return nil
+	})
+	for i := range results {
+		if results[i].Data == nil && results[i].Err == nil {
+			results[i] = ComicPage{Index: pages[i], Err: ctx.Err()} // Not started before ctx ended
+		}
 	}
+	return results
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+func (c *Client) getComicPage(ctx context.Context, bookID string, page int) ([]byte, string, error) {
+	if err := c.require(FeatureComics); err != nil {
 		return nil, "", err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("failed to get page: %s", string(body))
+	resp, body, err := c.openStream(ctx, fmt.Sprintf("/api/books/%s/cbz/page/%d", bookID, page), "", c.requestTimeout)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get page: %w", err)
 	}
+	defer body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	data, err := io.ReadAll(body)
 	if err != nil {
 		return nil, "", err
 	}
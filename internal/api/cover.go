@@ -0,0 +1,174 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cover cache limits. Covers younger than coverFreshFor are served without
+// asking the server; older ones are revalidated with their ETag.
+const (
+	coverCacheBytes = 16 << 20
+	coverFreshFor   = 5 * time.Minute
+)
+
+// Cover is a book's cover image
+type Cover struct {
+	Data        []byte
+	ContentType string // Image type, detected from the data if the server didn't say
+	ETag        string
+
+	bookID  string
+	fetched time.Time
+}
+
+// skipResponseCache marks requests whose responses are cached elsewhere
+type skipResponseCache struct{}
+
+// GetBookCover retrieves the cover image for a book and its content type
+func (c *Client) GetBookCover(bookID string) ([]byte, string, error) {
+	cover, err := c.BookCover(context.Background(), bookID)
+	if err != nil {
+		return nil, "", err
+	}
+	return cover.Data, cover.ContentType, nil
+}
+
+// BookCover retrieves the cover image for a book. Covers are kept in memory and
+// shared by every view using the client, so a cover shown in the library isn't
+// downloaded again for another view, and is only revalidated once it has aged.
+func (c *Client) BookCover(ctx context.Context, bookID string) (*Cover, error) {
+	cached := c.covers.get(bookID)
+	if cached != nil && time.Since(cached.fetched) < coverFreshFor {
+		return cached, nil
+	}
+
+	ctx = context.WithValue(ctx, skipResponseCache{}, true)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint("/api/books/"+bookID+"/cover"), nil)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := c.do(req)
+	if errors.Is(err, ErrOffline) && cached != nil {
+		return cached, nil // Stale is better than none while offline
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached = c.covers.touch(cached)
+		return cached, nil
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(resp, fmt.Errorf("failed to get cover: %s", string(body)))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	cover := &Cover{
+		Data:        data,
+		ContentType: imageType(resp.Header.Get("Content-Type"), data),
+		ETag:        resp.Header.Get("ETag"),
+		bookID:      bookID,
+		fetched:     time.Now(),
+	}
+	if len(data) > 0 {
+		c.covers.store(cover)
+	}
+	return cover, nil
+}
+
+// imageType returns the declared image type, or sniffs the data when the server
+// sent none or a generic one
+func imageType(declared string, data []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(declared); err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+	return http.DetectContentType(data)
+}
+
+// coverCache keeps recently used covers by book ID, within coverCacheBytes
+type coverCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element // Of *Cover, most recently used first
+	order   *list.List
+	size    int64
+}
+
+func newCoverCache() *coverCache {
+	return &coverCache{entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// get returns the cached cover for bookID, or nil
+func (c *coverCache) get(bookID string) *Cover {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[bookID]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*Cover)
+}
+
+// touch stores a copy of cover marked as just revalidated. Covers already
+// handed out are never changed, since other views may be reading them.
+func (c *coverCache) touch(cover *Cover) *Cover {
+	renewed := *cover
+	renewed.fetched = time.Now()
+	c.store(&renewed)
+	return &renewed
+}
+
+// store adds a cover, evicting the least recently used ones to stay in size
+func (c *coverCache) store(cover *Cover) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(cover.bookID)
+	c.entries[cover.bookID] = c.order.PushFront(cover)
+	c.size += int64(len(cover.Data))
+	for c.size > coverCacheBytes && c.order.Len() > 1 {
+		c.removeLocked(c.order.Back().Value.(*Cover).bookID)
+	}
+}
+
+// remove drops the cover for bookID, e.g. after it is replaced
+func (c *coverCache) remove(bookID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(bookID)
+}
+
+func (c *coverCache) removeLocked(bookID string) {
+	if elem, ok := c.entries[bookID]; ok {
+		c.size -= int64(len(elem.Value.(*Cover).Data))
+		c.order.Remove(elem)
+		delete(c.entries, bookID)
+	}
+}
+
+// clear drops every cover
+func (c *coverCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.size = 0
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLoggedBody is how much of a request or response body is logged
+const maxLoggedBody = 4 << 10
+
+var (
+	debugMu     sync.Mutex
+	debugOutput io.Writer = os.Stderr
+	debugBodies bool
+)
+
+// SetDebugLog turns on debug logging to w instead of stderr, e.g. a file while
+// the TUI owns the terminal. With bodies set, textual request and response
+// bodies are logged too, with passwords and tokens masked.
+func SetDebugLog(w io.Writer, bodies bool) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	Debug = true
+	debugOutput = w
+	debugBodies = bodies
+}
+
+// debugf writes a timestamped line to the debug log
+func debugf(format string, args ...interface{}) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	fmt.Fprintf(debugOutput, "%s [API] %s\n", time.Now().Format("15:04:05.000"), fmt.Sprintf(format, args...))
+}
+
+// logBodies reports whether bodies should be logged
+func logBodies() bool {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	return Debug && debugBodies
+}
+
+// secretField matches JSON string fields that must never reach a log: any key
+// naming a password, token or secret, e.g. new_password or refresh_token
+var secretField = regexp.MustCompile(`"([^"]*(?i:password|token|secret)[^"]*)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// loggableBody returns body for the log: truncated, with secrets masked, or a
+// note of its size when it isn't text
+func loggableBody(contentType string, body []byte, truncated bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "" && !strings.HasPrefix(mediaType, "text/") && !strings.Contains(mediaType, "json") && !strings.Contains(mediaType, "xml") {
+		return fmt.Sprintf("(%s body not logged)", mediaType)
+	}
+	text := secretField.ReplaceAllString(string(body), `"$1":"***"`)
+	if truncated {
+		text += "... (truncated)"
+	}
+	return text
+}
+
+// logRequestBody logs a request's body if it can be read again
+func logRequestBody(id string, req *http.Request) {
+	if req.GetBody == nil || !logBodies() {
+		return
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	truncated := len(data) > maxLoggedBody
+	if truncated {
+		data = data[:maxLoggedBody]
+	}
+	debugf("request %s body: %s", id, loggableBody(req.Header.Get("Content-Type"), data, truncated))
+}
+
+// logResponseBody wraps a response body to log its start once it has been
+// read or closed
+func logResponseBody(id string, resp *http.Response) {
+	if !logBodies() {
+		return
+	}
+	resp.Body = &debugBody{ReadCloser: resp.Body, id: id, contentType: resp.Header.Get("Content-Type")}
+}
+
+// debugBody keeps the first maxLoggedBody bytes read from a response body
+type debugBody struct {
+	io.ReadCloser
+	id          string
+	contentType string
+	buf         bytes.Buffer
+	size        int64
+	logged      bool
+}
+
+func (b *debugBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	if keep := min(n, maxLoggedBody-b.buf.Len()); keep > 0 {
+		b.buf.Write(p[:keep])
+	}
+	if err == io.EOF {
+		b.log()
+	}
+	return n, err
+}
+
+func (b *debugBody) Close() error {
+	b.log()
+	return b.ReadCloser.Close()
+}
+
+// log writes the body to the debug log, once
+func (b *debugBody) log() {
+	if b.logged {
+		return
+	}
+	b.logged = true
+	debugf("response %s body (%d bytes read): %s", b.id, b.size, loggableBody(b.contentType, b.buf.Bytes(), b.size > maxLoggedBody))
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLoggableBodyMasksSecrets(t *testing.T) {
+	// The body ChangePassword sends
+	body, err := json.Marshal(map[string]string{
+		"current_password": `old "quoted" pass`,
+		"new_password":     "n3w-pass",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := loggableBody("application/json", body, false)
+	for _, secret := range []string{"old", "quoted", "n3w-pass"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("logged body %s holds %q", logged, secret)
+		}
+	}
+	if !strings.Contains(logged, `"new_password":"***"`) {
+		t.Errorf("logged body %s doesn't show the masked field", logged)
+	}
+
+	logged = loggableBody("application/json", []byte(`{"refreshToken":"abc","client_secret":"def","Password":"ghi","title":"Dune"}`), false)
+	for _, secret := range []string{"abc", "def", "ghi"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("logged body %s holds %q", logged, secret)
+		}
+	}
+	if !strings.Contains(logged, `"title":"Dune"`) {
+		t.Errorf("logged body %s lost an ordinary field", logged)
+	}
+}
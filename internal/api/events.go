@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Server event types
+const (
+	EventBookAdded       = "book.added"
+	EventBookDeleted     = "book.deleted"
+	EventPositionUpdated = "position.updated"
+)
+
+// Reconnect delays for the event stream, doubling from the first to the last
+const (
+	eventRetryMin = 2 * time.Second
+	eventRetryMax = 2 * time.Minute
+)
+
+// Event is a change pushed by the server, e.g. a book uploaded or a reading
+// position saved from another device
+type Event struct {
+	Type     string  `json:"-"`
+	BookID   string  `json:"book_id"`
+	Chapter  string  `json:"chapter,omitempty"`
+	Position float64 `json:"position,omitempty"`
+}
+
+// SubscribeEvents follows the server's event stream (server-sent events) until
+// ctx is cancelled, reconnecting when the connection drops. The channel is
+// closed when ctx ends, or at once if the server has no event stream, so
+// callers can fall back to polling.
+func (c *Client) SubscribeEvents(ctx context.Context) <-chan Event {
+	events := make(chan Event, 16)
+	go func() {
+		defer close(events)
+		lastID := ""
+		delay := eventRetryMin
+		for ctx.Err() == nil {
+			switched := c.profileSwitched()
+			if c.currentToken() != "" {
+				received, err := c.readEventsUntil(ctx, switched, &lastID, events)
+				if errors.Is(err, ErrNotSupported) {
+					return
+				}
+				if received {
+					delay = eventRetryMin
+				}
+				if Debug && err != nil && ctx.Err() == nil {
+					debugf("event stream: %v, reconnecting in %s", err, delay)
+				}
+			}
+			select {
+			case <-time.After(delay):
+				delay = min(eventRetryMax, delay*2)
+			case <-switched:
+				// Follow the new server's events from the start
+				lastID, delay = "", eventRetryMin
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return events
+}
+
+// readEventsUntil is readEvents, hanging up when the client switches servers
+func (c *Client) readEventsUntil(ctx context.Context, switched <-chan struct{}, lastID *string, events chan<- Event) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-switched:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return c.readEvents(ctx, lastID, events)
+}
+
+// readEvents reads one connection's events into events, reporting whether any
+// arrived. lastID is sent on reconnecting so missed events can be replayed.
+func (c *Client) readEvents(ctx context.Context, lastID *string, events chan<- Event) (bool, error) {
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, skipResponseCache{}, true), "GET", c.endpoint("/api/events"), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+	resp, err := c.send(req, 0) // The stream stays open
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed ||
+		resp.StatusCode == http.StatusNotImplemented:
+		return false, ErrNotSupported
+	case resp.StatusCode >= 400:
+		return false, withRequestID(resp, fmt.Errorf("event stream: %s", resp.Status))
+	}
+
+	received := false
+	var eventType, id string
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line ends an event
+			if id != "" {
+				*lastID = id
+			}
+			if event, ok := parseEvent(eventType, data); ok {
+				received = true
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return received, ctx.Err()
+				}
+			}
+			eventType, id, data = "", "", nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			id = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return received, err
+	}
+	return received, fmt.Errorf("event stream closed")
+}
+
+// parseEvent decodes an event's JSON data. The type comes from the event field,
+// or a "type" in the data for servers that send only data lines.
+func parseEvent(eventType string, data []string) (Event, bool) {
+	if len(data) == 0 {
+		return Event{}, false // Keep-alive or comment
+	}
+	var event Event
+	var typed struct {
+		Type string `json:"type"`
+	}
+	payload := []byte(strings.Join(data, "\n"))
+	if json.Unmarshal(payload, &event) != nil {
+		return Event{}, false
+	}
+	_ = json.Unmarshal(payload, &typed)
+	event.Type = eventType
+	if event.Type == "" || event.Type == "message" {
+		event.Type = typed.Type
+	}
+	return event, event.Type != ""
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+)
+
+// DefaultUserAgent identifies the client until SetUserAgent adds its version
+const DefaultUserAgent = "webby-t"
+
+// reservedHeaders are set by the client itself and can't be replaced
+var reservedHeaders = []string{"Authorization", "Host", "Content-Length", "Content-Type", RequestIDHeader}
+
+// SetUserAgent sets the User-Agent sent with every request, e.g. "webby-t/v1.2.3"
+func (c *Client) SetUserAgent(userAgent string) {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	c.userAgent = userAgent
+}
+
+// SetHeaders sets extra headers sent with every request, such as the service
+// token a Cloudflare Access or oauth2-proxy gateway asks for. Authorization
+// can't be set: it carries the session token.
+func (c *Client) SetHeaders(headers map[string]string) error {
+	extra := make(http.Header, len(headers))
+	for name, value := range headers {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		for _, reserved := range reservedHeaders {
+			if canonical == textproto.CanonicalMIMEHeaderKey(reserved) {
+				return fmt.Errorf("header %s is set by webby-t and can't be configured", canonical)
+			}
+		}
+		extra.Set(canonical, value)
+	}
+	c.headers = extra
+	return nil
+}
+
+// addHeaders adds the User-Agent and configured headers to req
+func (c *Client) addHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", c.userAgent)
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrOffline is returned while the server can't be reached, for requests that
+// can't be answered from the cache
+var ErrOffline = errors.New("offline: the server can't be reached")
+
+// healthCheckInterval is how often an offline client checks for the server
+const healthCheckInterval = 5 * time.Second
+
+// maxFlushAttempts is how many reconnects a queued write that keeps failing is
+// sent on before it is dropped
+const maxFlushAttempts = 3
+
+// ConnectivityEvent reports the client going offline or coming back online
+type ConnectivityEvent struct {
+	Online  bool
+	Flushed int // Queued writes sent on reconnecting
+	Dropped int // Queued writes given up on: refused by the server, or failing too often
+}
+
+// connectivity tracks whether the server is reachable, and the writes waiting
+// for it to be
+type connectivity struct {
+	mu      sync.Mutex
+	offline bool
+	queue   []queuedWrite
+	events  chan ConnectivityEvent
+}
+
+// queuedWrite is a request saved while offline. Later writes to the same path
+// replace earlier ones, e.g. only the last reading position is sent.
+type queuedWrite struct {
+	method   string
+	path     string
+	body     interface{}
+	attempts int // Times it was sent and failed
+}
+
+// probeRequest marks health checks, which go out even while offline
+type probeRequest struct{}
+
+// ConnectivityEvents returns the channel connectivity changes are sent on.
+// Events are dropped while nobody is receiving.
+func (c *Client) ConnectivityEvents() <-chan ConnectivityEvent {
+	return c.conn.events
+}
+
+// Online reports whether the server was reachable at the last request
+func (c *Client) Online() bool {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	return !c.conn.offline
+}
+
+// isUnreachable reports whether err means no connection could be made, as
+// opposed to a slow or failed request
+func isUnreachable(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+// offlineResponse answers req while offline: from the cache if it can, or
+// with ErrOffline. Health checks go through.
+func (c *Client) offlineResponse(req *http.Request) (*http.Response, bool, error) {
+	if req.Context().Value(probeRequest{}) != nil || c.Online() {
+		return nil, false, nil
+	}
+	if cached := c.cache.peek(cacheKey(req)); cached != nil {
+		return cached.response(&http.Response{Proto: "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1, Header: http.Header{}, Request: req}), true, nil
+	}
+	return nil, true, ErrOffline
+}
+
+// setOffline marks the server unreachable, and starts checking for its return
+func (c *Client) setOffline() {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	if c.conn.offline {
+		return
+	}
+	c.conn.offline = true
+	if Debug {
+		debugf("server unreachable, working offline")
+	}
+	c.conn.notify(ConnectivityEvent{Online: false})
+	go c.waitForServer()
+}
+
+// waitForServer checks the server's health until it answers, then sends the
+// queued writes and marks the client online again
+func (c *Client) waitForServer() {
+	for {
+		time.Sleep(healthCheckInterval)
+		ctx := context.WithValue(context.Background(), probeRequest{}, true)
+		req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint("/health"), nil)
+		if err != nil {
+			return
+		}
+		resp, err := c.send(req, c.requestTimeout)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		if Debug {
+			debugf("server reachable again, sending queued writes")
+		}
+		flushed, dropped, online := c.flushWrites()
+		if !online {
+			continue
+		}
+		c.conn.mu.Lock()
+		c.conn.notify(ConnectivityEvent{Online: true, Flushed: flushed, Dropped: dropped})
+		c.conn.mu.Unlock()
+		return
+	}
+}
+
+// flushWrites sends the queued writes in order, then marks the client online.
+// Until then writes are still queued, behind the ones being sent, so an older
+// write can't land after a newer one to the same path. It returns how many were
+// sent and how many were dropped: writes the server refuses (4xx), and writes
+// that failed otherwise on maxFlushAttempts reconnects, which are kept for the
+// next one until then. If the server is lost again, the unsent writes stay
+// queued and it reports false.
+func (c *Client) flushWrites() (flushed, dropped int, online bool) {
+	ctx := context.WithValue(context.Background(), probeRequest{}, true)
+	var failed []queuedWrite
+	for {
+		c.conn.mu.Lock()
+		if len(c.conn.queue) == 0 {
+			c.conn.queue = failed
+			c.conn.offline = false
+			c.conn.mu.Unlock()
+			return flushed, dropped, true
+		}
+		write := c.conn.queue[0]
+		c.conn.queue = c.conn.queue[1:]
+		c.conn.mu.Unlock()
+
+		resp, err := c.requestContext(ctx, write.method, write.path, write.body)
+		if err != nil && isUnreachable(err) {
+			c.conn.mu.Lock()
+			rest := append(failed, write)
+			for _, queued := range c.conn.queue {
+				rest = replaceWrite(rest, queued)
+			}
+			c.conn.queue = rest
+			c.conn.mu.Unlock()
+			return flushed, dropped, false
+		}
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+			resp.Body.Close()
+		}
+		switch {
+		case err == nil && status < 400:
+			flushed++
+			failed = removeWrite(failed, write.method, write.path)
+		case err == nil && status < 500:
+			dropped++
+			if Debug {
+				debugf("queued %s %s refused (%d), dropped", write.method, write.path, status)
+			}
+		default:
+			write.attempts++
+			if write.attempts >= maxFlushAttempts {
+				dropped++
+				if Debug {
+					debugf("queued %s %s failed %d times, dropped", write.method, write.path, write.attempts)
+				}
+				continue
+			}
+			failed = replaceWrite(failed, write)
+		}
+	}
+}
+
+// queueWrite saves a write to send once the server is back, replacing any
+// queued write to the same path
+func (c *Client) queueWrite(queued queuedWrite) {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	c.conn.queue = replaceWrite(c.conn.queue, queued)
+}
+
+// replaceWrite appends write to queue, removing any write to the same path
+func replaceWrite(queue []queuedWrite, write queuedWrite) []queuedWrite {
+	return append(removeWrite(queue, write.method, write.path), write)
+}
+
+// removeWrite removes the write to method and path from queue, if there is one
+func removeWrite(queue []queuedWrite, method, path string) []queuedWrite {
+	return slices.DeleteFunc(queue, func(write queuedWrite) bool {
+		return write.method == method && write.path == path
+	})
+}
+
+// requestOrQueue is request for writes that can wait: while the server can't be
+// reached, the write is queued and reported as done. A write that goes through
+// replaces any queued one to the same path, which would otherwise be sent over
+// it on reconnecting.
+func (c *Client) requestOrQueue(method, path string, body interface{}) (*http.Response, bool, error) {
+	resp, err := c.request(method, path, body)
+	if err != nil && (errors.Is(err, ErrOffline) || isUnreachable(err)) {
+		c.queueWrite(queuedWrite{method: method, path: path, body: body})
+		return nil, true, nil
+	}
+	if err == nil && resp.StatusCode < 400 {
+		c.conn.mu.Lock()
+		c.conn.queue = removeWrite(c.conn.queue, method, path)
+		c.conn.mu.Unlock()
+	}
+	return resp, false, err
+}
+
+// notify sends an event without blocking; the caller holds mu
+func (s *connectivity) notify(event ConnectivityEvent) {
+	sendLatest(s.events, event)
+}
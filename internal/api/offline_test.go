@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestFlushWritesDropsRefusedAndRequeuesFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/ok":
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/gone":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "token")
+
+	c.conn.offline = true
+	c.conn.queue = []queuedWrite{
+		{method: "POST", path: "/api/ok", body: map[string]int{"n": 1}},
+		{method: "POST", path: "/api/gone", body: map[string]int{"n": 2}},
+		{method: "POST", path: "/api/broken", body: map[string]int{"n": 3}},
+	}
+	flushed, dropped, online := c.flushWrites()
+	if flushed != 1 || dropped != 1 || !online || !c.Online() {
+		t.Fatalf("flushed %d, dropped %d, online %v; want 1, 1 and online", flushed, dropped, online)
+	}
+	if len(c.conn.queue) != 1 || c.conn.queue[0].path != "/api/broken" || c.conn.queue[0].attempts != 1 {
+		t.Fatalf("queue after flush = %+v, want /api/broken with 1 attempt", c.conn.queue)
+	}
+
+	// A write that keeps failing is given up on after maxFlushAttempts
+	for i := 1; i < maxFlushAttempts; i++ {
+		c.conn.offline = true
+		flushed, dropped, _ = c.flushWrites()
+	}
+	if flushed != 0 || dropped != 1 || len(c.conn.queue) != 0 {
+		t.Fatalf("flushed %d, dropped %d, queue %+v; want the failing write dropped", flushed, dropped, c.conn.queue)
+	}
+}
+
+// A position left queued after a failed flush, or saved while the flush runs,
+// never lands over a newer one
+func TestQueuedPositionDoesNotOverwriteNewer(t *testing.T) {
+	var mu sync.Mutex
+	var saved []string
+	failNext := true
+	var during func()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Chapter string `json:"chapter"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		fail, hook := failNext, during
+		failNext, during = false, nil
+		if !fail {
+			saved = append(saved, body.Chapter)
+		}
+		mu.Unlock()
+		if hook != nil {
+			hook()
+		}
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "token")
+	lastSaved := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(saved) == 0 {
+			return ""
+		}
+		return saved[len(saved)-1]
+	}
+
+	// Queued old position, failing on reconnect, then a direct new one
+	c.conn.offline = true
+	if err := c.SavePosition("1", "old", 0); err != nil {
+		t.Fatal(err)
+	}
+	c.flushWrites()
+	if len(c.conn.queue) != 1 {
+		t.Fatalf("queue = %+v, want the failed write kept", c.conn.queue)
+	}
+	if err := c.SavePosition("1", "new", 0); err != nil {
+		t.Fatal(err)
+	}
+	c.conn.offline = true
+	c.flushWrites()
+	if got := lastSaved(); got != "new" {
+		t.Fatalf("position after reconnecting = %q, want new", got)
+	}
+
+	// A position saved while the queue is being sent goes after it
+	c.conn.offline = true
+	if err := c.SavePosition("1", "old", 0); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	during = func() {
+		if err := c.SavePosition("1", "newer", 0); err != nil {
+			t.Error(err)
+		}
+	}
+	mu.Unlock()
+	c.flushWrites()
+	if got := lastSaved(); got != "newer" {
+		t.Fatalf("position after reconnecting = %q, want newer", got)
+	}
+}
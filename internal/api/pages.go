@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"iter"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// allBooksPageSize is how many books each request for the whole library asks for
+const allBooksPageSize = 500
+
+// AllBooks walks every page of the library, yielding each book once. It stops
+// at the first error, which is yielded with an empty book. Servers that ignore
+// the page number are detected when a page brings no new books.
+func (c *Client) AllBooks(ctx context.Context, sort, order string, filters BookFilters) iter.Seq2[models.Book, error] {
+	return func(yield func(models.Book, error) bool) {
+		seen := make(map[string]bool)
+		for page := 1; ; page++ {
+			resp, err := c.ListBooksFiltered(ctx, page, allBooksPageSize, sort, order, filters)
+			if err != nil {
+				yield(models.Book{}, err)
+				return
+			}
+			added := 0
+			for _, book := range resp.Books {
+				if seen[book.ID] {
+					continue
+				}
+				seen[book.ID] = true
+				added++
+				if !yield(book, nil) {
+					return
+				}
+			}
+			if added == 0 || len(resp.Books) < allBooksPageSize || (resp.Total > 0 && len(seen) >= resp.Total) {
+				return
+			}
+		}
+	}
+}
+
+// ListAllBooks returns the whole library, for features that need more than a page
+func (c *Client) ListAllBooks(ctx context.Context, sort, order string, filters BookFilters) ([]models.Book, error) {
+	var books []models.Book
+	for book, err := range c.AllBooks(ctx, sort, order, filters) {
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	return books, nil
+}
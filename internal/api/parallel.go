@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultParallelism is how many requests a fan-out keeps in flight at once,
+// enough to hide latency without crowding the server
+const DefaultParallelism = 4
+
+// ForEach calls fn for each index below n, running at most limit calls at a
+// time (DefaultParallelism if limit is 0 or less). The first error cancels the
+// context passed to the other calls, and no more are started; it is returned
+// once the running calls finish.
+func ForEach(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	if limit <= 0 {
+		limit = DefaultParallelism
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	slots := make(chan struct{}, limit)
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if err := fn(ctx, i); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
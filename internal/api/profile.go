@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrServerChanged is returned for a request made for a server the client has
+// since switched away from
+var ErrServerChanged = errors.New("switched to another server")
+
+// Profile is a named server and the token for it
+type Profile struct {
+	Name  string
+	URL   string
+	Token string // Empty until logged in
+}
+
+// NewClientFromProfile creates an API client for a profile
+func NewClientFromProfile(profile Profile) *Client {
+	c := NewClient(profile.URL, profile.Token)
+	c.profile = profile.Name
+	return c
+}
+
+// UseProfile switches the client to another server without losing its
+// settings. Cached responses and the server info belong to the old server and
+// are dropped; requests already made for it fail with ErrServerChanged rather
+// than carrying the new token there.
+func (c *Client) UseProfile(profile Profile) {
+	c.authMu.Lock()
+	c.baseURL = profile.URL
+	c.token = profile.Token
+	c.profile = profile.Name
+	c.expiredToken = ""
+	close(c.switched)
+	c.switched = make(chan struct{})
+	c.authMu.Unlock()
+
+	c.cache.clear()
+	c.covers.clear()
+	c.conn.mu.Lock()
+	c.conn.queue = nil // Writes for the old server
+	c.conn.mu.Unlock()
+	c.infoMu.Lock()
+	c.info = nil
+	c.infoMu.Unlock()
+}
+
+// Profile returns the server the client is using
+func (c *Client) Profile() Profile {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return Profile{Name: c.profile, URL: c.baseURL, Token: c.token}
+}
+
+// endpoint returns the URL of path on the current server
+func (c *Client) endpoint(path string) string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.baseURL + path
+}
+
+// session returns the token to send req with, or ErrServerChanged if req was
+// made for an earlier server
+func (c *Client) session(req *http.Request) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if !onServer(req.URL, c.baseURL) {
+		return "", ErrServerChanged
+	}
+	return c.token, nil
+}
+
+// onServer reports whether u is on the server at baseURL: the same scheme and
+// host, and a path at or below the base path
+func onServer(u *url.URL, baseURL string) bool {
+	base, err := url.Parse(baseURL)
+	if err != nil || !strings.EqualFold(u.Scheme, base.Scheme) || !strings.EqualFold(u.Host, base.Host) {
+		return false
+	}
+	prefix := strings.TrimSuffix(base.Path, "/")
+	return prefix == "" || u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/")
+}
+
+// profileSwitched returns a channel that is closed when the client next
+// switches servers
+func (c *Client) profileSwitched() <-chan struct{} {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.switched
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOnServer(t *testing.T) {
+	tests := []struct {
+		requested, base string
+		want            bool
+	}{
+		{"https://host/webby/api/books", "https://host/webby", true},
+		{"https://host/webby/api/books", "https://host/webby/", true},
+		{"https://HOST/api/books", "https://host", true},
+		{"https://host/webby2/api/books", "https://host/webby", false},
+		{"https://host/webby", "https://host/webby2", false},
+		{"http://nas:8080/api/books", "http://nas:80", false},
+		{"http://nas:80/api/books", "http://nas:8080", false},
+		{"http://host/api/books", "https://host", false},
+		{"https://host.evil/api/books", "https://host", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.requested)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := onServer(u, tt.base); got != tt.want {
+			t.Errorf("onServer(%q, %q) = %v, want %v", tt.requested, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestSwitchedProfileTokenStaysOnItsServer(t *testing.T) {
+	c := NewClient("https://host/webby2", "old-token")
+	req, err := http.NewRequest("GET", c.endpoint("/api/books"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.UseProfile(Profile{Name: "other", URL: "https://host/webby", Token: "new-token"})
+	if token, err := c.session(req); err != ErrServerChanged {
+		t.Fatalf("session for the old server = %q, %v; want ErrServerChanged", token, err)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// SetProxy sends requests through proxyURL, an http://, https://, socks5:// or
+// socks5h:// URL. An empty URL uses the environment: HTTPS_PROXY or HTTP_PROXY
+// by scheme, then ALL_PROXY, skipping hosts listed in NO_PROXY.
+func (c *Client) SetProxy(proxyURL string) error {
+	if proxyURL == "" {
+		c.transport().Proxy = proxyFromEnvironment
+		return nil
+	}
+	proxy, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q (use http, https, socks5 or socks5h)", proxy.Scheme)
+	}
+	c.transport().Proxy = http.ProxyURL(proxy)
+	return nil
+}
+
+// proxyFromEnvironment is http.ProxyFromEnvironment plus ALL_PROXY, which curl
+// and SSH SOCKS tunnel setups commonly use. Like the standard variables it
+// never applies to this machine.
+func proxyFromEnvironment(req *http.Request) (*url.URL, error) {
+	proxy, err := http.ProxyFromEnvironment(req)
+	if proxy != nil || err != nil {
+		return proxy, err
+	}
+	all := getenvEither("ALL_PROXY", "all_proxy")
+	host := req.URL.Hostname()
+	if ip := net.ParseIP(host); all == "" || host == "localhost" || (ip != nil && ip.IsLoopback()) || noProxy(host) {
+		return nil, nil
+	}
+	return url.Parse(all)
+}
+
+// noProxy reports whether NO_PROXY exempts host: "*", the host itself, or a
+// domain it is under
+func noProxy(host string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range strings.Split(getenvEither("NO_PROXY", "no_proxy"), ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "*" {
+			return true
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(strings.TrimPrefix(entry, "*"), ".")
+		if entry != "" && (host == entry || strings.HasSuffix(host, "."+entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getenvEither returns the first of two environment variables that is set
+func getenvEither(upper, lower string) string {
+	if value := os.Getenv(upper); value != "" {
+		return value
+	}
+	return os.Getenv(lower)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is how many requests per second the client sends on
+// average; bursts of up to twice that go out at once
+const DefaultRateLimit = 10
+
+// SetRateLimit caps the client at perSecond requests on average (0 = no
+// limit), so fan-outs like loading every chapter or a page of covers don't
+// trip a small server's own rate limits. Retries count against it too.
+func (c *Client) SetRateLimit(perSecond float64) {
+	if perSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(perSecond, max(1, 2*perSecond))
+}
+
+// rateLimiter is a token bucket: each request takes a token, and tokens refill
+// at rate per second up to burst
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64 // Below zero when requests are queued for tokens
+	last   time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.refund()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, returning how long until it is actually available.
+// Callers queue in the order they reserve.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// refund returns a token reserved by a request that gave up waiting
+func (l *rateLimiter) refund() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
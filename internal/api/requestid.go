@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader carries the per-call ID used to correlate client errors with server logs
+const RequestIDHeader = "X-Request-ID"
+
+// RequestError is an API error tagged with the ID of the request that failed
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RequestID returns the request ID attached to err, or "" if there is none
+func RequestID(err error) string {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.RequestID
+	}
+	return ""
+}
+
+// newRequestID generates a random 16 hex digit request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "0000000000000000"
+	}
+	return hex.EncodeToString(b)
+}
+
+// withRequestID tags err with the ID of the request that produced resp.
+// A server-echoed ID takes precedence since that is what its logs contain.
+func withRequestID(resp *http.Response, err error) error {
+	if err == nil || resp == nil {
+		return err
+	}
+	id := resp.Header.Get(RequestIDHeader)
+	if id == "" && resp.Request != nil {
+		id = resp.Request.Header.Get(RequestIDHeader)
+	}
+	if id == "" {
+		return err
+	}
+	if Debug {
+		debugf("request %s failed: %v", id, err)
+	}
+	return &RequestError{RequestID: id, Err: err}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry defaults: idempotent requests are tried up to DefaultMaxAttempts times,
+// waiting retryBaseDelay before the first retry and doubling up to retryMaxDelay.
+// A busy server's Retry-After is waited out if it is at most retryAfterMax.
+const (
+	DefaultMaxAttempts = 3
+	retryBaseDelay     = 250 * time.Millisecond
+	retryMaxDelay      = 4 * time.Second
+	retryAfterMax      = 30 * time.Second
+)
+
+// ServerBusyEvent reports that the server asked the client to slow down, and
+// a request will be retried after Wait
+type ServerBusyEvent struct {
+	Wait time.Duration
+}
+
+// BusyEvents returns the channel ServerBusyEvents are sent on. Events are
+// dropped while nobody is receiving.
+func (c *Client) BusyEvents() <-chan ServerBusyEvent {
+	return c.busyEvents
+}
+
+// SetMaxAttempts sets how many times a GET is tried before its error is returned
+// (1 disables retries)
+func (c *Client) SetMaxAttempts(attempts int) {
+	c.maxAttempts = max(1, attempts)
+}
+
+// attemptsFor returns how many tries a request gets. Only requests without a
+// body that are safe to repeat are retried.
+func (c *Client) attemptsFor(req *http.Request) int {
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || req.Body != nil {
+		return 1
+	}
+	return max(1, c.maxAttempts)
+}
+
+// shouldRetry reports whether a failed try looks transient: a network error
+// (but not a cancelled request) or an overloaded or unreachable upstream
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// throttled reports whether the server turned a request away for being busy.
+// It didn't act on the request, so even one with a body can be sent again.
+func throttled(resp *http.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable)
+}
+
+// retryThrottled reports whether a throttled request may be tried again: its
+// body can be replayed, tries remain and the server doesn't ask for too long
+// a wait
+func (c *Client) retryThrottled(req *http.Request, resp *http.Response, attempt int) bool {
+	if !throttled(resp) || attempt >= max(1, c.maxAttempts) || (req.Body != nil && req.GetBody == nil) {
+		return false
+	}
+	wait, ok := retryAfter(resp)
+	return !ok || wait <= retryAfterMax
+}
+
+// retryDelay returns the wait before retry number attempt (from 1): the server's
+// Retry-After if it gave one, otherwise exponential backoff with full jitter
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(retryAfterMax, wait)
+	}
+	backoff := min(retryMaxDelay, retryBaseDelay<<(attempt-1))
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retryAfter returns the wait a response's Retry-After asks for, given either
+// in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(date)), true
+	}
+	return 0, false
+}
+
+// sendLatest sends event without blocking, replacing an unread one so the
+// receiver sees the newest state
+func sendLatest[T any](ch chan T, event T) {
+	select {
+	case ch <- event:
+		return
+	default:
+	}
+	select {
+	case <-ch:
+	default:
+	}
+	select {
+	case ch <- event:
+	default:
+	}
+}
+
+// retryReason describes a failed try for debug output
+func retryReason(resp *http.Response, err error) any {
+	if err != nil {
+		return err
+	}
+	return resp.Status
+}
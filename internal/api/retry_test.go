@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelayBacksOff(t *testing.T) {
+	for attempt := 1; attempt <= 8; attempt++ {
+		backoff := min(retryMaxDelay, retryBaseDelay<<(attempt-1))
+		for range 50 {
+			if got := retryDelay(attempt, nil); got < backoff/2 || got > backoff {
+				t.Fatalf("retryDelay(%d) = %v, want between %v and %v", attempt, got, backoff/2, backoff)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+		if tt.value != "" {
+			resp.Header.Set("Retry-After", tt.value)
+		}
+		got, ok := retryAfter(resp)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// A date is counted from now
+	resp := &http.Response{Header: http.Header{"Retry-After": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}}
+	if got, ok := retryAfter(resp); !ok || got < 58*time.Second || got > time.Minute {
+		t.Errorf("retryAfter(in a minute) = %v, %v", got, ok)
+	}
+	// The server's wait replaces the backoff, up to retryAfterMax
+	resp.Header.Set("Retry-After", "3600")
+	if got := retryDelay(1, resp); got != retryAfterMax {
+		t.Errorf("retryDelay with Retry-After: 3600 = %v, want %v", got, retryAfterMax)
+	}
+	resp.Header.Set("Retry-After", "2")
+	if got := retryDelay(5, resp); got != 2*time.Second {
+		t.Errorf("retryDelay with Retry-After: 2 = %v, want 2s", got)
+	}
+}
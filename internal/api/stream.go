@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// chapterAccept asks for a chapter as bare text or HTML, which can be shown as
+// it arrives; servers that only speak JSON send the usual wrapped content
+const chapterAccept = "text/plain, text/html;q=0.9, application/json;q=0.5"
+
+// ChapterStream reads a chapter's text as it arrives
+type ChapterStream struct {
+	ContentType string // Chapter content type, e.g. text/plain or text/html
+
+	body    io.ReadCloser
+	wrapped bool   // The body is a JSON ChapterContent, read in one piece
+	partial []byte // Bytes of a character split across reads
+}
+
+// OpenChapterText starts reading a chapter's text. Call Next until it returns
+// io.EOF, then Close.
+func (c *Client) OpenChapterText(ctx context.Context, bookID string, chapter int) (*ChapterStream, error) {
+	resp, body, err := c.openStream(ctx, fmt.Sprintf("/api/books/%s/text/%d", bookID, chapter), chapterAccept, c.transferTimeout)
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return &ChapterStream{
+		ContentType: mediaType,
+		body:        body,
+		wrapped:     mediaType == "application/json" || mediaType == "",
+	}, nil
+}
+
+// Next returns up to limit more bytes of text, never splitting a character, or
+// io.EOF once the chapter is complete
+func (s *ChapterStream) Next(limit int) (string, error) {
+	if s.wrapped {
+		if s.body == nil {
+			return "", io.EOF
+		}
+		data, err := io.ReadAll(s.body)
+		s.Close()
+		if err != nil {
+			return "", err
+		}
+		var content models.ChapterContent
+		if err := json.Unmarshal(data, &content); err != nil {
+			return "", fmt.Errorf("failed to decode response: %w", err)
+		}
+		s.ContentType = content.ContentType
+		return content.Content, nil
+	}
+
+	buf := make([]byte, max(limit, utf8.UTFMax))
+	copy(buf, s.partial)
+	n, err := io.ReadFull(s.body, buf[len(s.partial):])
+	n += len(s.partial)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if n == 0 && err == io.EOF {
+		return "", io.EOF
+	}
+
+	// Hold back a trailing character that hasn't fully arrived
+	cut := n
+	if err == nil {
+		for i := n - 1; i >= max(0, n-utf8.UTFMax); i-- {
+			if utf8.RuneStart(buf[i]) {
+				if !utf8.FullRune(buf[i:n]) {
+					cut = i
+				}
+				break
+			}
+		}
+	}
+	s.partial = append(s.partial[:0], buf[cut:n]...)
+	return string(buf[:cut]), nil
+}
+
+// Close stops reading the chapter
+func (s *ChapterStream) Close() error {
+	if s.body == nil {
+		return nil
+	}
+	err := s.body.Close()
+	s.body = nil
+	return err
+}
+
+// openStream GETs path for reading as the body arrives, allowing each try
+// timeout. If the connection drops partway through, the rest is requested with
+// a Range header when the server allows it.
+func (c *Client) openStream(ctx context.Context, path, accept string, timeout time.Duration) (*http.Response, io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint(path), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.send(req, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, nil, withRequestID(resp, fmt.Errorf("request failed: %s", string(body)))
+	}
+
+	reader := &rangeReader{client: c, req: req, timeout: timeout, body: resp.Body, size: resp.ContentLength}
+	if resp.Header.Get("Accept-Ranges") == "bytes" && resp.Header.Get("Content-Encoding") == "" {
+		// The validator makes sure a resumed read continues the same content
+		reader.validator = resp.Header.Get("ETag")
+		if reader.validator == "" {
+			reader.validator = resp.Header.Get("Last-Modified")
+		}
+		reader.ranges = true
+	}
+	return resp, reader, nil
+}
+
+// rangeReader reads a response body, re-requesting the remainder with a Range
+// header when the connection fails partway through
+type rangeReader struct {
+	client    *Client
+	req       *http.Request
+	timeout   time.Duration
+	body      io.ReadCloser
+	offset    int64 // Bytes read so far
+	size      int64 // Full length, or -1 if unknown
+	ranges    bool  // The server accepts byte ranges
+	validator string
+	resumes   int
+}
+
+// Read implements io.Reader
+func (r *rangeReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == nil || err == io.EOF || r.req.Context().Err() != nil {
+		return n, err
+	}
+	if resumeErr := r.resume(); resumeErr != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// resume replaces the failed body with a ranged request for the rest, allowing
+// as many resumes as the client's retries
+func (r *rangeReader) resume() error {
+	if !r.ranges || r.resumes+1 >= max(1, r.client.maxAttempts) || (r.size >= 0 && r.offset >= r.size) {
+		return fmt.Errorf("cannot resume")
+	}
+	r.resumes++
+
+	req := r.req.Clone(r.req.Context())
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(r.offset, 10)+"-")
+	if r.validator != "" {
+		req.Header.Set("If-Range", r.validator)
+	}
+	resp, err := r.client.send(req, r.timeout)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		// A full response means the content changed; splicing it in would corrupt it
+		resp.Body.Close()
+		return fmt.Errorf("server did not resume (status %d)", resp.StatusCode)
+	}
+	r.body.Close()
+	r.body = resp.Body
+	return nil
+}
+
+// Close implements io.Closer
+func (r *rangeReader) Close() error {
+	return r.body.Close()
+}
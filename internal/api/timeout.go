@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Default time limits per try. Book uploads and downloads have none, since
+// large files on slow links can take many minutes; cancel their context instead.
+const (
+	DefaultRequestTimeout  = 30 * time.Second
+	DefaultTransferTimeout = time.Duration(0)
+)
+
+// SetTimeouts sets the time limit for API calls and for book uploads and
+// downloads (0 = no limit)
+func (c *Client) SetTimeouts(request, transfer time.Duration) {
+	c.requestTimeout = max(0, request)
+	c.transferTimeout = max(0, transfer)
+}
+
+// withTimeout returns req bounded by timeout (0 = no limit). The cancel func
+// must be called once the response body has been read.
+func withTimeout(req *http.Request, timeout time.Duration) (*http.Request, context.CancelFunc) {
+	if timeout <= 0 {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
+
+// cancelOnClose releases a request's timeout when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
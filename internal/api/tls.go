@@ -0,0 +1,60 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions configures how the client checks the server's certificate, and
+// the certificate it presents to servers behind mutual TLS
+type TLSOptions struct {
+	CAFile   string // PEM bundle trusted in addition to the system roots, e.g. a self-signed CA
+	Insecure bool   // Accept any certificate; only for testing against a server you control
+
+	// Client certificate and its private key, both PEM files
+	CertFile string
+	KeyFile  string
+}
+
+// SetTLS applies TLS options to the client's connections
+func (c *Client) SetTLS(opts TLSOptions) error {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.CAFile != "" {
+		pool, err := loadCAFile(opts.CAFile)
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+	}
+	config.InsecureSkipVerify = opts.Insecure
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		if opts.CertFile == "" || opts.KeyFile == "" {
+			return fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	c.transport().TLSClientConfig = config
+	return nil
+}
+
+// loadCAFile returns the system roots plus the certificates in a PEM file
+func loadCAFile(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Chunked uploads: files of at least ChunkedUploadThreshold bytes are sent in
+// chunks, so a dropped connection only loses the chunk in flight
+const (
+	ChunkedUploadThreshold = 32 << 20
+	DefaultUploadChunkSize = 8 << 20
+)
+
+// UploadSession is a chunked upload in progress on the server
+type UploadSession struct {
+	ID        string `json:"upload_id"`
+	ChunkSize int64  `json:"chunk_size"`
+	Received  []int  `json:"received_chunks"` // Indexes of the chunks the server has
+}
+
+// UploadStore remembers unfinished upload sessions so they can resume after a restart
+type UploadStore interface {
+	PendingUpload(key string) string
+	SetPendingUpload(key, uploadID string) error
+}
+
+// UploadBookResumable uploads a book file. Large files go up in chunks, asking the
+// server which chunks it already has, so an interrupted upload picks up where it
+// stopped; the session is kept in store between runs. Small files, and servers
+// without chunked uploads, use a single request.
+func (c *Client) UploadBookResumable(ctx context.Context, filePath string, store UploadStore) (*models.Book, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	if info.Size() < ChunkedUploadThreshold {
+		return c.UploadBook(filePath)
+	}
+
+	key := uploadKey(filePath, info)
+	session, err := c.resumeOrStartUpload(store.PendingUpload(key), filepath.Base(filePath), info.Size())
+	if errors.Is(err, ErrNotSupported) {
+		return c.UploadBook(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = store.SetPendingUpload(key, session.ID)
+
+	if err := c.sendChunks(ctx, filePath, info.Size(), session); err != nil {
+		return nil, err
+	}
+	book, err := c.CompleteUpload(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	_ = store.SetPendingUpload(key, "")
+	return book, nil
+}
+
+// uploadKey identifies a file's contents well enough to resume its upload
+func uploadKey(filePath string, info os.FileInfo) string {
+	if abs, err := filepath.Abs(filePath); err == nil {
+		filePath = abs
+	}
+	return fmt.Sprintf("%s|%d|%d", filePath, info.Size(), info.ModTime().Unix())
+}
+
+// resumeOrStartUpload continues a stored session if the server still has it,
+// otherwise starts a new one
+func (c *Client) resumeOrStartUpload(uploadID, filename string, size int64) (*UploadSession, error) {
+	if uploadID != "" {
+		if session, err := c.GetUpload(uploadID); err == nil {
+			return session, nil
+		}
+	}
+	return c.StartUpload(filename, size)
+}
+
+// sendChunks sends every chunk the server doesn't have yet, retrying each on
+// failure before giving up
+func (c *Client) sendChunks(ctx context.Context, filePath string, size int64, session *UploadSession) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	chunkSize := session.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	have := make(map[int]bool, len(session.Received))
+	for _, index := range session.Received {
+		have[index] = true
+	}
+
+	buf := make([]byte, chunkSize)
+	for index := 0; int64(index)*chunkSize < size; index++ {
+		if have[index] {
+			continue
+		}
+		n, err := file.ReadAt(buf, int64(index)*chunkSize)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		if err := c.putChunkWithRetry(ctx, session.ID, index, buf[:n]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putChunkWithRetry sends one chunk, retrying with backoff like idempotent reads
+func (c *Client) putChunkWithRetry(ctx context.Context, uploadID string, index int, data []byte) error {
+	for attempt := 1; ; attempt++ {
+		err := c.PutUploadChunk(ctx, uploadID, index, data)
+		if err == nil || attempt >= max(1, c.maxAttempts) || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-time.After(retryDelay(attempt, nil)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// StartUpload opens a chunked upload session. Returns ErrNotSupported if the
+// server doesn't take chunked uploads.
+func (c *Client) StartUpload(filename string, size int64) (*UploadSession, error) {
+	body := map[string]interface{}{"filename": filename, "size": size, "chunk_size": DefaultUploadChunkSize}
+	resp, err := c.request("POST", "/api/uploads", body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, ErrNotSupported
+	}
+	return parseResponse[*UploadSession](resp)
+}
+
+// GetUpload returns an upload session with the chunks the server has received
+func (c *Client) GetUpload(uploadID string) (*UploadSession, error) {
+	resp, err := c.request("GET", "/api/uploads/"+url.PathEscape(uploadID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseResponse[*UploadSession](resp)
+}
+
+// PutUploadChunk stores one chunk of an upload; sending a chunk twice is harmless
+func (c *Client) PutUploadChunk(ctx context.Context, uploadID string, index int, data []byte) error {
+	path := "/api/uploads/" + url.PathEscape(uploadID) + "/chunks/" + strconv.Itoa(index)
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint(path), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.doTransfer(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(resp, fmt.Errorf("failed to upload chunk %d: %s", index, string(body)))
+	}
+	return nil
+}
+
+// CompleteUpload assembles the uploaded chunks into a book
+func (c *Client) CompleteUpload(uploadID string) (*models.Book, error) {
+	req, err := http.NewRequest("POST", c.endpoint("/api/uploads/"+url.PathEscape(uploadID)+"/complete"), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doTransfer(req) // The server may take a while to process a large file
+	if err != nil {
+		return nil, err
+	}
+	return parseUploadedBook(resp)
+}
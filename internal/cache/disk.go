@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const cacheDirName = "webby-t"
+
+// DiskCache stores blobs as files under a directory, evicting the least
+// recently used entries once the total size exceeds maxBytes
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64 // Total bytes on disk (valid once scanned)
+	scanned bool
+}
+
+// NewDiskCache creates a cache rooted at dir with the given size cap
+func NewDiskCache(dir string, maxBytes int64) *DiskCache {
+	return &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}
+}
+
+// DefaultDir returns the base cache directory (XDG cache dir on Linux)
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheDir, cacheDirName), nil
+}
+
+// PageDir returns the directory used for the comic page cache
+func PageDir() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pages"), nil
+}
+
+// DocumentPath returns where a downloaded book file (e.g. a PDF) is kept
+func DocumentPath(bookID, format string) (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "books", bookSegment(bookID)+"."+format), nil
+}
+
+// PageKey returns the cache key for a comic page
+func PageKey(bookID string, page int) string {
+	return bookSegment(bookID) + "/" + fmt.Sprintf("%d", page)
+}
+
+// maxSegmentLen is the longest book ID used as a file name as is
+const maxSegmentLen = 64
+
+// bookSegment turns a server-supplied book ID into a file name that can't
+// leave the cache directory: plain IDs are kept, anything else is hashed
+func bookSegment(bookID string) string {
+	plain := bookID != "" && len(bookID) <= maxSegmentLen && bookID[0] != '.'
+	for i := 0; plain && i < len(bookID); i++ {
+		c := bookID[i]
+		plain = c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+	}
+	if plain {
+		return bookID
+	}
+	sum := sha256.Sum256([]byte(bookID))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached data for key, if present
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	// Touch the entry so eviction treats it as recently used
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return data, true
+}
+
+// Put stores data under key and evicts old entries if over the size cap
+func (c *DiskCache) Put(key string, data []byte) error {
+	if c == nil || c.maxBytes <= 0 || int64(len(data)) > c.maxBytes {
+		return nil
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.scanned {
+		c.size = c.scan()
+		c.scanned = true
+	}
+
+	var oldSize int64
+	if info, err := os.Stat(path); err == nil {
+		oldSize = info.Size()
+	}
+
+	// Write to a temp file and rename so readers never see partial pages
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	c.size += int64(len(data)) - oldSize
+	if c.size > c.maxBytes {
+		c.evict()
+	}
+	return nil
+}
+
+// Size returns the total bytes currently cached
+func (c *DiskCache) Size() int64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.scanned {
+		c.size = c.scan()
+		c.scanned = true
+	}
+	return c.size
+}
+
+// Clear removes every cached entry
+func (c *DiskCache) Clear() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.size = 0
+	c.scanned = true
+	return os.RemoveAll(c.dir)
+}
+
+// path maps a key to its file path
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, filepath.FromSlash(key))
+}
+
+// cacheEntry is a file found while scanning the cache directory
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// entries lists all cached files
+func (c *DiskCache) entries() []cacheEntry {
+	var entries []cacheEntry
+	_ = filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return entries
+}
+
+// scan returns the total size of all cached files
+func (c *DiskCache) scan() int64 {
+	var total int64
+	for _, e := range c.entries() {
+		total += e.size
+	}
+	return total
+}
+
+// evict removes least recently used files until the cache fits in maxBytes
+func (c *DiskCache) evict() {
+	entries := c.entries()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	for _, e := range entries {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(e.path); err == nil {
+			total -= e.size
+		}
+	}
+	c.size = total
+}
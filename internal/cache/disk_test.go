@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBookSegment(t *testing.T) {
+	plain := []string{"42", "3f2b9c1e-7d4a-4f7e-9a55-0c1d2e3f4a5b", "book_1.v2"}
+	for _, id := range plain {
+		if got := bookSegment(id); got != id {
+			t.Errorf("bookSegment(%q) = %q, want it unchanged", id, got)
+		}
+	}
+
+	unsafe := []string{"", ".", "..", "../../etc/passwd", `..\..\x`, "a/b", "C:evil", ".hidden", strings.Repeat("x", maxSegmentLen+1)}
+	for _, id := range unsafe {
+		got := bookSegment(id)
+		if len(got) != 64 || strings.ContainsAny(got, `./\:`) {
+			t.Errorf("bookSegment(%q) = %q, want a hash", id, got)
+		}
+	}
+}
+
+func TestPageKeyStaysInCache(t *testing.T) {
+	c := NewDiskCache(t.TempDir(), 0)
+	for _, id := range []string{"..", "../..", "../../outside"} {
+		path := c.path(PageKey(id, 1))
+		rel, err := filepath.Rel(c.dir, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			t.Errorf("PageKey(%q) maps to %s, outside %s", id, path, c.dir)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// backupVersion is the version of the backup file written by Export
+const backupVersion = 1
+
+// backupFile is an exported copy of the settings and local state, for moving
+// them to another machine or keeping a backup
+type backupFile struct {
+	Version    int       `json:"webby_t_backup"`
+	ExportedAt time.Time `json:"exported_at"`
+	Config     *Config   `json:"config"`
+}
+
+// ImportCounts is what an import added to the local state
+type ImportCounts struct {
+	Bookmarks int
+	Favorites int
+	Queue     int
+	Books     int // Books given progress, ratings, notes, tags or settings
+}
+
+// Export writes the settings, bookmarks, favorites, reading queue and the rest
+// of the local state to w, in the format of path's extension (JSON unless it is
+// .toml, .yaml or .yml). The login, extra request headers (which may hold
+// credentials) and this machine's upload and sync bookkeeping are left out.
+func (c *Config) Export(w io.Writer, path string) error {
+	exported := c.withoutTokens()
+	exported.TokenServer, exported.Username = "", ""
+	exported.Headers = nil
+	exported.PendingUploads = nil
+	exported.LastSync = nil
+	exported.LastUpdateCheck = time.Time{}
+
+	data, err := encodeFile(path, backupFile{
+		Version:    backupVersion,
+		ExportedAt: time.Now(),
+		Config:     exported,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Import reads a backup written by Export, in the format of path's extension,
+// and saves it. Its settings replace the config's, apart from this machine's
+// login and request headers; its bookmarks, favorites, queue, history, smart
+// collections and per-book data are merged into the local state, keeping the
+// local entry where both have one.
+func (c *Config) Import(r io.Reader, path string) (ImportCounts, error) {
+	var counts ImportCounts
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return counts, err
+	}
+	var file backupFile
+	if err := decodeFile(path, data, &file); err != nil {
+		return counts, fmt.Errorf("invalid backup: %w", err)
+	}
+	if file.Version < 1 || file.Config == nil {
+		return counts, fmt.Errorf("invalid backup: not a webby-t export")
+	}
+	if file.Version > backupVersion {
+		return counts, fmt.Errorf("backup version %d is newer than this webby-t supports (%d)", file.Version, backupVersion)
+	}
+	imported := file.Config
+
+	merged := *imported
+	merged.path = c.path
+	merged.keyringTokens = c.keyringTokens
+	merged.keyringFailed = c.keyringFailed
+	merged.state = c.state
+	merged.stateErr = c.stateErr
+	merged.TokenKeyring = c.TokenKeyring
+	merged.PendingUploads = c.PendingUploads
+	merged.LastSync = c.LastSync
+	merged.LastUpdateCheck = c.LastUpdateCheck
+	merged.Headers = c.Headers
+	merged.ServerURL, merged.Profile = c.ServerURL, c.Profile
+	merged.Token, merged.TokenServer, merged.Username = c.Token, c.TokenServer, c.Username
+	if !c.IsAuthenticated() && imported.ServerURL != "" {
+		// Not logged in here yet, so take the backup's server
+		merged.ServerURL, merged.Profile = imported.ServerURL, imported.Profile
+	}
+	merged.Profiles = mergeProfiles(c.Profiles, imported.Profiles)
+
+	merged.Favorites, counts.Favorites = appendMissing(c.Favorites, imported.Favorites)
+	merged.ReadingQueue, counts.Queue = appendMissing(c.ReadingQueue, imported.ReadingQueue)
+	merged.Archived, _ = appendMissing(c.Archived, imported.Archived)
+	merged.RecentlyRead = mergeRecentlyRead(c.RecentlyRead, imported.RecentlyRead)
+	merged.History = mergeHistory(c.History, imported.History)
+	merged.SmartCollections = mergeSmartCollections(c.SmartCollections, imported.SmartCollections)
+	merged.LibraryState = c.LibraryState
+	merged.Bookmarks = c.Bookmarks
+	counts.Bookmarks = len(merged.mergeBookmarks(imported.Bookmarks))
+
+	books := make(map[string]bool)
+	merged.BookSettings = addMissing(c.BookSettings, imported.BookSettings, books)
+	merged.Comics = addMissing(c.Comics, imported.Comics, books)
+	merged.Progress = addMissing(c.Progress, imported.Progress, books)
+	merged.StatusOverrides = addMissing(c.StatusOverrides, imported.StatusOverrides, books)
+	merged.Ratings = addMissing(c.Ratings, imported.Ratings, books)
+	merged.Notes = addMissing(c.Notes, imported.Notes, books)
+	merged.BookTags = addMissing(c.BookTags, imported.BookTags, books)
+	counts.Books = len(books)
+
+	*c = merged
+	if err := c.saveState(c.writeState); err != nil {
+		return counts, err
+	}
+	return counts, c.Save()
+}
+
+// appendMissing adds the ids not already in list to its end, returning the
+// list and how many were added
+func appendMissing(list, ids []string) ([]string, int) {
+	seen := make(map[string]bool, len(list))
+	for _, id := range list {
+		seen[id] = true
+	}
+	added := 0
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		list = append(list, id)
+		added++
+	}
+	return list, added
+}
+
+// addMissing returns local with the imported entries for books it has none
+// for, adding those books to added
+func addMissing[V any](local, imported map[string]V, added map[string]bool) map[string]V {
+	merged := make(map[string]V, len(local)+len(imported))
+	for bookID, value := range local {
+		merged[bookID] = value
+	}
+	for bookID, value := range imported {
+		if _, ok := merged[bookID]; ok || bookID == "" {
+			continue
+		}
+		merged[bookID] = value
+		added[bookID] = true
+	}
+	return merged
+}
+
+// mergeHistory combines two reading histories without repeating sessions,
+// oldest first and capped at MaxHistory
+func mergeHistory(history, imported []ReadingSession) []ReadingSession {
+	merged := append([]ReadingSession(nil), history...)
+	seen := make(map[string]bool, len(history))
+	for _, session := range history {
+		seen[session.key()] = true
+	}
+	for _, session := range imported {
+		if !seen[session.key()] {
+			seen[session.key()] = true
+			merged = append(merged, session)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].StartedAt.Before(merged[j].StartedAt)
+	})
+	if len(merged) > MaxHistory {
+		merged = merged[len(merged)-MaxHistory:]
+	}
+	return merged
+}
+
+// mergeSmartCollections adds the imported smart collections whose names are new
+func mergeSmartCollections(collections, imported []SmartCollection) []SmartCollection {
+	merged := append([]SmartCollection(nil), collections...)
+	names := make(map[string]bool, len(collections))
+	for _, collection := range collections {
+		names[collection.Name] = true
+	}
+	for _, collection := range imported {
+		if names[collection.Name] {
+			continue
+		}
+		names[collection.Name] = true
+		merged = append(merged, collection)
+	}
+	return merged
+}
+
+// mergeProfiles adds the imported profiles whose names are new, without
+// their logins
+func mergeProfiles(profiles, imported []Profile) []Profile {
+	merged := append([]Profile(nil), profiles...)
+	names := make(map[string]bool, len(profiles))
+	for _, profile := range profiles {
+		names[profile.Name] = true
+	}
+	for _, profile := range imported {
+		if profile.Name == "" || names[profile.Name] {
+			continue
+		}
+		names[profile.Name] = true
+		profile.Token, profile.Username = "", ""
+		merged = append(merged, profile)
+	}
+	return merged
+}
+
+// mergeRecentlyRead combines two recently read lists, keeping the latest
+// opening of each book, newest first and capped at MaxRecentlyRead
+func mergeRecentlyRead(entries, imported []RecentlyReadEntry) []RecentlyReadEntry {
+	latest := make(map[string]RecentlyReadEntry)
+	for _, entry := range append(append([]RecentlyReadEntry(nil), entries...), imported...) {
+		if current, ok := latest[entry.BookID]; !ok || entry.OpenedAt.After(current.OpenedAt) {
+			latest[entry.BookID] = entry
+		}
+	}
+	merged := make([]RecentlyReadEntry, 0, len(latest))
+	for _, entry := range latest {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].OpenedAt.After(merged[j].OpenedAt)
+	})
+	if len(merged) > MaxRecentlyRead {
+		merged = merged[:MaxRecentlyRead]
+	}
+	return merged
+}
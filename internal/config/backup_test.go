@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportMergesLocalState(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	source := newTestConfig(t, t.TempDir())
+	source.Theme = "light"
+	source.Headers = map[string]string{"Authorization": "Bearer secret"}
+	for i, err := range []error{
+		source.ToggleFavorite("shared"),
+		source.ToggleFavorite("new"),
+		source.SetProgress("shared", 0.9),
+		source.SetProgress("new", 0.1),
+		source.SetRating("shared", 1),
+		source.SetNote("new", "from backup"),
+		source.AddReadingSession(ReadingSession{BookID: "new", StartedAt: started}),
+		source.SaveSmartCollection(SmartCollection{Name: "backup"}),
+	} {
+		if err != nil {
+			t.Fatalf("source step %d: %v", i, err)
+		}
+	}
+	var backup bytes.Buffer
+	if err := source.Export(&backup, "backup.json"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(backup.String(), "secret") {
+		t.Errorf("export holds request headers:\n%s", backup.String())
+	}
+
+	local := newTestConfig(t, t.TempDir())
+	local.Headers = map[string]string{"X-Local": "1"}
+	for i, err := range []error{
+		local.ToggleFavorite("shared"),
+		local.SetProgress("shared", 0.3),
+		local.SetRating("shared", 5),
+		local.AddReadingSession(ReadingSession{BookID: "shared", StartedAt: started.Add(time.Hour)}),
+		local.SaveSmartCollection(SmartCollection{Name: "local"}),
+	} {
+		if err != nil {
+			t.Fatalf("local step %d: %v", i, err)
+		}
+	}
+	counts, err := local.Import(&backup, "backup.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if counts.Favorites != 1 || counts.Books != 1 {
+		t.Errorf("counts = %+v, want 1 favorite and 1 book", counts)
+	}
+	if local.Theme != "light" {
+		t.Errorf("theme = %q, want the backup's", local.Theme)
+	}
+	if !reflect.DeepEqual(local.Headers, map[string]string{"X-Local": "1"}) {
+		t.Errorf("headers = %v, want the local ones", local.Headers)
+	}
+	if want := map[string]float64{"shared": 0.3, "new": 0.1}; !reflect.DeepEqual(local.Progress, want) {
+		t.Errorf("progress = %v, want %v", local.Progress, want)
+	}
+	if local.GetRating("shared") != 5 || local.GetNote("new") != "from backup" {
+		t.Errorf("rating, note = %d, %q", local.GetRating("shared"), local.GetNote("new"))
+	}
+	if len(local.History) != 2 || local.History[0].BookID != "new" {
+		t.Errorf("history = %+v", local.History)
+	}
+	if len(local.SmartCollections) != 2 {
+		t.Errorf("smart collections = %+v", local.SmartCollections)
+	}
+
+	// The merge is saved to the state database
+	reloaded := newTestConfig(t, filepath.Dir(local.path))
+	if !reflect.DeepEqual(reloaded.Progress, local.Progress) || len(reloaded.History) != 2 {
+		t.Errorf("reloaded progress = %v, history = %+v", reloaded.Progress, reloaded.History)
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import bolt "go.etcd.io/bbolt"
+
+// Comic page fit modes: the whole page, or its width or height filling the screen
+const (
+	FitPage   = "page"
+	FitWidth  = "width"
+	FitHeight = "height"
+)
+
+// FitModes lists the fit modes in the order they are cycled through
+var FitModes = []string{FitPage, FitWidth, FitHeight}
+
+// BookSettings holds one book's reading preferences. Unset fields fall back to
+// the global defaults.
+type BookSettings struct {
+	TextScale   float64 `json:"text_scale,omitempty"`    // Reader text scale, 0 for text_scale
+	Continuous  *bool   `json:"continuous,omitempty"`    // Reader continuous scroll, unset for continuous_scroll
+	RightToLeft *bool   `json:"right_to_left,omitempty"` // Comic manga page order, unset for comic_right_to_left
+	FitMode     string  `json:"fit_mode,omitempty"`      // Comic page fit, "" for comic_fit_mode
+}
+
+// GetBookSettings returns the preferences saved for a book (unset if none)
+func (c *Config) GetBookSettings(bookID string) BookSettings {
+	return c.BookSettings[bookID]
+}
+
+// SetBookSettings saves the preferences for a book, dropping entries with
+// nothing set
+func (c *Config) SetBookSettings(bookID string, settings BookSettings) error {
+	if settings == (BookSettings{}) {
+		delete(c.BookSettings, bookID)
+	} else {
+		if c.BookSettings == nil {
+			c.BookSettings = make(map[string]BookSettings)
+		}
+		c.BookSettings[bookID] = settings
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		if settings == (BookSettings{}) {
+			return deleteKeys(tx.Bucket(bookSettingsBucket), bookID)
+		}
+		return putJSON(tx.Bucket(bookSettingsBucket), bookID, settings)
+	})
+}
+
+// BookTextScale returns the reader text scale for a book
+func (c *Config) BookTextScale(bookID string) float64 {
+	scale := c.GetBookSettings(bookID).TextScale
+	if scale < MinTextScale || scale > MaxTextScale {
+		return c.GetTextScale()
+	}
+	return scale
+}
+
+// SetBookTextScale sets a book's text scale within bounds and saves. The
+// global scale clears it.
+func (c *Config) SetBookTextScale(bookID string, scale float64) error {
+	scale = max(MinTextScale, min(MaxTextScale, scale))
+	settings := c.GetBookSettings(bookID)
+	settings.TextScale = scale
+	if scale == c.GetTextScale() {
+		settings.TextScale = 0
+	}
+	return c.SetBookSettings(bookID, settings)
+}
+
+// BookContinuous reports whether a book opens in continuous scroll mode
+func (c *Config) BookContinuous(bookID string) bool {
+	if continuous := c.GetBookSettings(bookID).Continuous; continuous != nil {
+		return *continuous
+	}
+	return c.ContinuousScroll
+}
+
+// SetBookContinuous remembers a book's scroll mode
+func (c *Config) SetBookContinuous(bookID string, continuous bool) error {
+	settings := c.GetBookSettings(bookID)
+	settings.Continuous = &continuous
+	if continuous == c.ContinuousScroll {
+		settings.Continuous = nil
+	}
+	return c.SetBookSettings(bookID, settings)
+}
+
+// BookRightToLeft reports whether a comic's pages read right to left. Manga
+// mode saved with the comic viewer settings counts when it isn't set here.
+func (c *Config) BookRightToLeft(bookID string) bool {
+	if rightToLeft := c.GetBookSettings(bookID).RightToLeft; rightToLeft != nil {
+		return *rightToLeft
+	}
+	return c.ComicRightToLeft || c.GetComicSettings(bookID).RightToLeft
+}
+
+// SetBookRightToLeft remembers a comic's page order
+func (c *Config) SetBookRightToLeft(bookID string, rightToLeft bool) error {
+	if comic := c.GetComicSettings(bookID); comic.RightToLeft {
+		// Moved here from the comic viewer settings
+		comic.RightToLeft = false
+		if err := c.SetComicSettings(bookID, comic); err != nil {
+			return err
+		}
+	}
+	settings := c.GetBookSettings(bookID)
+	settings.RightToLeft = &rightToLeft
+	if rightToLeft == c.ComicRightToLeft {
+		settings.RightToLeft = nil
+	}
+	return c.SetBookSettings(bookID, settings)
+}
+
+// BookFitMode returns how a comic's pages fit the screen: FitPage, FitWidth
+// or FitHeight
+func (c *Config) BookFitMode(bookID string) string {
+	if mode := c.GetBookSettings(bookID).FitMode; validFitMode(mode) {
+		return mode
+	}
+	return c.defaultFitMode()
+}
+
+// defaultFitMode returns the global fit mode, defaulting to FitPage
+func (c *Config) defaultFitMode() string {
+	if validFitMode(c.ComicFitMode) {
+		return c.ComicFitMode
+	}
+	return FitPage
+}
+
+// SetBookFitMode remembers how a comic's pages fit the screen
+func (c *Config) SetBookFitMode(bookID, mode string) error {
+	settings := c.GetBookSettings(bookID)
+	settings.FitMode = mode
+	if !validFitMode(mode) || mode == c.defaultFitMode() {
+		settings.FitMode = ""
+	}
+	return c.SetBookSettings(bookID, settings)
+}
+
+// validFitMode reports whether mode is one of FitModes
+func validFitMode(mode string) bool {
+	for _, m := range FitModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
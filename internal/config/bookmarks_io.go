@@ -0,0 +1,196 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bookmark file formats
+const (
+	BookmarkFormatJSON = "json"
+	BookmarkFormatCSV  = "csv"
+)
+
+// bookmarkCSVHeader is the column order used for CSV import/export
+var bookmarkCSVHeader = []string{
+	"id", "book_id", "book_title", "chapter", "chapter_title", "position", "name", "note", "created_at",
+}
+
+// BookmarkFormatForPath picks a bookmark file format from a file extension, defaulting to JSON
+func BookmarkFormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return BookmarkFormatCSV
+	}
+	return BookmarkFormatJSON
+}
+
+// ExportBookmarks writes all bookmarks to w in the given format
+func (c *Config) ExportBookmarks(w io.Writer, format string) error {
+	switch format {
+	case BookmarkFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		bookmarks := c.Bookmarks
+		if bookmarks == nil {
+			bookmarks = []Bookmark{}
+		}
+		return enc.Encode(bookmarks)
+	case BookmarkFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(bookmarkCSVHeader); err != nil {
+			return err
+		}
+		for _, b := range c.Bookmarks {
+			record := []string{
+				b.ID,
+				b.BookID,
+				b.BookTitle,
+				strconv.Itoa(b.Chapter),
+				b.ChapterTitle,
+				strconv.FormatFloat(b.Position, 'f', -1, 64),
+				b.Name,
+				b.Note,
+				b.CreatedAt.Format(time.RFC3339),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown bookmark format %q", format)
+	}
+}
+
+// ImportBookmarks reads bookmarks from r and merges them into the config.
+// Bookmarks whose ID or location already exists are skipped. Returns the number added.
+func (c *Config) ImportBookmarks(r io.Reader, format string) (int, error) {
+	var imported []Bookmark
+	switch format {
+	case BookmarkFormatJSON:
+		if err := json.NewDecoder(r).Decode(&imported); err != nil {
+			return 0, fmt.Errorf("invalid bookmark JSON: %w", err)
+		}
+	case BookmarkFormatCSV:
+		var err error
+		if imported, err = readBookmarkCSV(r); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unknown bookmark format %q", format)
+	}
+
+	added := c.mergeBookmarks(imported)
+	if len(added) == 0 {
+		return 0, nil
+	}
+	return len(added), c.saveState(func(tx *bolt.Tx) error {
+		for _, b := range added {
+			if err := putJSON(tx.Bucket(bookmarksBucket), b.ID, b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// mergeBookmarks adds the imported bookmarks whose ID and location are new,
+// returning those added
+func (c *Config) mergeBookmarks(imported []Bookmark) []Bookmark {
+	existing := make(map[string]bool, len(c.Bookmarks))
+	locations := make(map[string]bool, len(c.Bookmarks))
+	for _, b := range c.Bookmarks {
+		existing[b.ID] = true
+		locations[bookmarkLocation(b)] = true
+	}
+
+	var added []Bookmark
+	for i, b := range imported {
+		if b.BookID == "" {
+			continue
+		}
+		if b.ID == "" {
+			b.ID = fmt.Sprintf("%s-%d", generateBookmarkID(), i)
+		}
+		if existing[b.ID] || locations[bookmarkLocation(b)] {
+			continue
+		}
+		if b.CreatedAt.IsZero() {
+			b.CreatedAt = time.Now()
+		}
+		existing[b.ID] = true
+		locations[bookmarkLocation(b)] = true
+		c.Bookmarks = append(c.Bookmarks, b)
+		added = append(added, b)
+	}
+	return added
+}
+
+// bookmarkLocation identifies the spot a bookmark points at, for de-duplication
+func bookmarkLocation(b Bookmark) string {
+	return fmt.Sprintf("%s/%d/%g", b.BookID, b.Chapter, b.Position)
+}
+
+// readBookmarkCSV parses bookmarks from CSV with a header row naming the columns
+func readBookmarkCSV(r io.Reader) ([]Bookmark, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid bookmark CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Map column names so files with reordered or missing columns still import
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	if _, ok := columns["book_id"]; !ok {
+		return nil, fmt.Errorf("invalid bookmark CSV: missing book_id column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var bookmarks []Bookmark
+	for line, record := range records[1:] {
+		b := Bookmark{
+			ID:           field(record, "id"),
+			BookID:       field(record, "book_id"),
+			BookTitle:    field(record, "book_title"),
+			ChapterTitle: field(record, "chapter_title"),
+			Name:         field(record, "name"),
+			Note:         field(record, "note"),
+		}
+		if s := field(record, "chapter"); s != "" {
+			if b.Chapter, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("invalid chapter on line %d: %w", line+2, err)
+			}
+		}
+		if s := field(record, "position"); s != "" {
+			if b.Position, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("invalid position on line %d: %w", line+2, err)
+			}
+		}
+		if s := field(record, "created_at"); s != "" {
+			if b.CreatedAt, err = time.Parse(time.RFC3339, s); err != nil {
+				return nil, fmt.Errorf("invalid created_at on line %d: %w", line+2, err)
+			}
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/update"
+)
+
+// NewClient returns an API client for the server and token, with the retry,
+// timeout, rate limit, TLS, proxy and header settings applied. On an invalid
+// TLS, proxy or header setting the client is still returned along with the
+// first error, using the defaults for that setting.
+func (c *Config) NewClient() (*api.Client, error) {
+	client := api.NewClient(c.ServerURL, c.Token)
+	client.SetMaxAttempts(c.GetRetryAttempts())
+	client.SetTimeouts(c.GetRequestTimeout(), c.GetTransferTimeout())
+	client.SetRateLimit(c.GetRateLimit())
+	client.SetUserAgent("webby-t/" + update.Version)
+	err := client.SetTLS(api.TLSOptions{
+		CAFile:   c.GetCAFile(),
+		Insecure: c.SkipTLSVerify(),
+		CertFile: c.ClientCert,
+		KeyFile:  c.ClientKey,
+	})
+	if proxyErr := client.SetProxy(c.Proxy); proxyErr != nil && err == nil {
+		err = proxyErr
+	}
+	if headersErr := client.SetHeaders(c.GetHeaders()); headersErr != nil && err == nil {
+		err = headersErr
+	}
+	return client, err
+}
@@ -1,17 +1,21 @@
 package config
 
 import (
-	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	bolt "go.etcd.io/bbolt"
 )
 
 const (
-	DefaultServerURL   = "http://localhost:8080"
-	configFileName     = "config.json"
-	configDirName      = "webby-t"
-	MaxRecentlyRead    = 10 // Maximum number of recently read books to track
+	DefaultServerURL = "http://localhost:8080"
+	configFileName   = "config.json"
+	configDirName    = "webby-t"
+	MaxRecentlyRead  = 10  // Maximum number of recently read books to track
+	MaxHistory       = 500 // Maximum number of reading sessions to keep
 )
 
 // RecentlyReadEntry represents a recently read book
@@ -21,33 +25,161 @@ type RecentlyReadEntry struct {
 	OpenedAt  time.Time `json:"opened_at"`
 }
 
+// ReadingSession is one stretch of reading a book, from opening it to leaving the reader
+type ReadingSession struct {
+	BookID       string    `json:"book_id"`
+	Title        string    `json:"title"`
+	StartedAt    time.Time `json:"started_at"`
+	EndedAt      time.Time `json:"ended_at"`
+	StartChapter int       `json:"start_chapter"` // Chapter index (page number for comics)
+	EndChapter   int       `json:"end_chapter"`
+	Comic        bool      `json:"comic,omitempty"`
+}
+
+// Duration returns how long the session lasted
+func (s ReadingSession) Duration() time.Duration {
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// key identifies a session when merging histories
+func (s ReadingSession) key() string {
+	return s.BookID + "@" + s.StartedAt.UTC().Format(time.RFC3339Nano)
+}
+
 // Bookmark represents a saved position in a book
 type Bookmark struct {
-	ID        string    `json:"id"`
-	BookID    string    `json:"book_id"`
-	BookTitle string    `json:"book_title"`
-	Chapter   int       `json:"chapter"`
-	ChapterTitle string `json:"chapter_title"`
-	Position  float64   `json:"position"` // 0-1 within chapter
-	Note      string    `json:"note,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           string    `json:"id"`
+	BookID       string    `json:"book_id"`
+	BookTitle    string    `json:"book_title"`
+	Chapter      int       `json:"chapter"`
+	ChapterTitle string    `json:"chapter_title"`
+	Position     float64   `json:"position"`       // 0-1 within chapter
+	Name         string    `json:"name,omitempty"` // User-assigned label
+	Note         string    `json:"note,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
+// ComicSettings holds per-book comic viewer preferences
+type ComicSettings struct {
+	RightToLeft bool    `json:"right_to_left,omitempty"` // Manga page order, as saved before book_settings
+	Webtoon     bool    `json:"webtoon,omitempty"`       // Pages stitched into a vertical strip
+	Rotation    int     `json:"rotation,omitempty"`      // Clockwise quarter turns (0-3)
+	Brightness  int     `json:"brightness,omitempty"`    // -100 to 100 percent
+	Contrast    int     `json:"contrast,omitempty"`      // -100 to 100 percent
+	Gamma       float64 `json:"gamma,omitempty"`         // 0 means 1.0 (unchanged)
+}
+
+// SmartCollection is a saved combination of library search, sort and filters
+type SmartCollection struct {
+	Name         string `json:"name"`
+	Search       string `json:"search,omitempty"`
+	Sort         string `json:"sort,omitempty"` // title, author, series or uploaded_at
+	Descending   bool   `json:"descending,omitempty"`
+	ContentType  string `json:"content_type,omitempty"` // "", "book" or "comic"
+	Author       string `json:"author,omitempty"`
+	Series       string `json:"series,omitempty"`
+	Tag          string `json:"tag,omitempty"`
+	Status       string `json:"status,omitempty"` // Read status: unread, reading or finished
+	Favorites    bool   `json:"favorites,omitempty"`
+	Queue        bool   `json:"queue,omitempty"`
+	RecentlyRead bool   `json:"recently_read,omitempty"`
+	NewestFirst  bool   `json:"newest_first,omitempty"` // Recently added: newest uploads first, whatever Sort says
+	MinRating    int    `json:"min_rating,omitempty"`   // Only books rated at least this, best first
+	Shared       bool   `json:"shared,omitempty"`       // Books other users shared, instead of my own
+	Archived     bool   `json:"archived,omitempty"`     // Only archived books, which are otherwise hidden
+}
+
+// LibraryState is where the library was left, restored on the next start
+type LibraryState struct {
+	Filters SmartCollection `json:"filters"` // Search, sort and filters (unnamed)
+	Page    int             `json:"page,omitempty"`
+	Cursor  int             `json:"cursor,omitempty"`
+}
+
+// KeyRemaps maps keys pressed in a view to the key or action each acts as
+type KeyRemaps map[string]string
+
 // Config holds the application configuration
 type Config struct {
-	ServerURL    string              `json:"server_url"`
-	Token        string              `json:"token,omitempty"`
-	TokenServer  string              `json:"token_server,omitempty"` // Server URL the token was obtained from
-	Username     string              `json:"username,omitempty"`
-	RecentlyRead []RecentlyReadEntry `json:"recently_read,omitempty"`
-	TextScale    float64             `json:"text_scale,omitempty"`    // 0.5-2.0, default 1.0
-	Favorites    []string            `json:"favorites,omitempty"`     // List of favorited book IDs
-	ReadingQueue []string            `json:"reading_queue,omitempty"` // Ordered list of books to read
-	Bookmarks    []Bookmark          `json:"bookmarks,omitempty"`     // Saved bookmarks
-	Theme        string              `json:"theme,omitempty"`         // Color theme name (dark, light, etc.)
+	ServerURL          string                   `json:"server_url"`
+	Token              string                   `json:"token,omitempty"`
+	TokenKeyring       bool                     `json:"token_keyring,omitempty"` // Keep the token in the OS keyring, falling back to this file without one
+	TokenServer        string                   `json:"token_server,omitempty"`  // Server URL the token was obtained from
+	Username           string                   `json:"username,omitempty"`
+	Profile            string                   `json:"profile,omitempty"`  // Name of the active entry in profiles, if any
+	Profiles           []Profile                `json:"profiles,omitempty"` // Named servers, each with its own login, to switch between
+	RecentlyRead       []RecentlyReadEntry      `json:"recently_read,omitempty"`
+	TextScale          float64                  `json:"text_scale,omitempty"`           // 0.5-2.0, default 1.0
+	Favorites          []string                 `json:"favorites,omitempty"`            // List of favorited book IDs
+	ReadingQueue       []string                 `json:"reading_queue,omitempty"`        // Ordered list of books to read
+	Archived           []string                 `json:"archived,omitempty"`             // Books hidden from the library on this machine (not deleted)
+	Bookmarks          []Bookmark               `json:"bookmarks,omitempty"`            // Saved bookmarks
+	Comics             map[string]ComicSettings `json:"comics,omitempty"`               // Per-book comic viewer settings
+	BookSettings       map[string]BookSettings  `json:"book_settings,omitempty"`        // Per-book reading preferences, ahead of the defaults below
+	History            []ReadingSession         `json:"history,omitempty"`              // Reading session log, oldest first
+	Theme              string                   `json:"theme,omitempty"`                // Color theme name (dark, light, etc.)
+	Themes             []CustomTheme            `json:"themes,omitempty"`               // User-defined themes, alongside those in themes.d
+	PageCacheMB        int                      `json:"page_cache_mb,omitempty"`        // Comic page disk cache cap in MB (-1 disables)
+	AutoTheme          bool                     `json:"auto_theme,omitempty"`           // Switch between day and night themes by local time
+	DayTheme           string                   `json:"day_theme,omitempty"`            // Theme used during the day when auto_theme is on
+	NightTheme         string                   `json:"night_theme,omitempty"`          // Theme used at night when auto_theme is on
+	SunriseHour        int                      `json:"sunrise_hour,omitempty"`         // Hour (1-23) the day theme starts, default 7
+	SunsetHour         int                      `json:"sunset_hour,omitempty"`          // Hour (1-23) the night theme starts, default 19
+	SearchHighlight    string                   `json:"search_highlight,omitempty"`     // "block" (default) or "underline"
+	SearchMatchColor   string                   `json:"search_match_color,omitempty"`   // Override for other matches (e.g. "#374151")
+	SearchCurrentColor string                   `json:"search_current_color,omitempty"` // Override for the current match
+	LowPower           bool                     `json:"low_power,omitempty"`            // Fewer redraws and background refreshes (battery saving)
+	CheckUpdates       bool                     `json:"check_updates,omitempty"`        // Look for a newer release on startup (opt-in)
+	LastUpdateCheck    time.Time                `json:"last_update_check,omitempty"`    // When the startup update check last ran
+	SixelPalette       string                   `json:"sixel_palette,omitempty"`        // "median-cut" (default) or "plan9"
+	SixelColors        int                      `json:"sixel_colors,omitempty"`         // Median-cut palette size (2-256, default 256)
+	SixelDither        string                   `json:"sixel_dither,omitempty"`         // "floyd-steinberg" (default) or "none"
+	SlideshowSeconds   int                      `json:"slideshow_seconds,omitempty"`    // Comic slideshow time per page (default 8)
+	PanStepPercent     int                      `json:"pan_step_percent,omitempty"`     // Share of the visible area a zoomed comic pans per step (default 10)
+	ComicZoomLock      bool                     `json:"comic_zoom_lock,omitempty"`      // Keep comic zoom and vertical pan when turning pages
+	ComicRightToLeft   bool                     `json:"comic_right_to_left,omitempty"`  // Read comics right to left (manga) unless set per book
+	ComicFitMode       string                   `json:"comic_fit_mode,omitempty"`       // Comic page fit: "page" (default), "width" or "height"
+	ContinuousScroll   bool                     `json:"continuous_scroll,omitempty"`    // Open books in continuous scroll mode unless set per book
+	EInk               bool                     `json:"eink,omitempty"`                 // Grayscale, high-contrast comics and fewer redraws for e-ink displays
+	DownloadDir        string                   `json:"download_dir,omitempty"`         // Where downloaded book files are saved (default ~/Downloads)
+	BookTags           map[string][]string      `json:"book_tags,omitempty"`            // Local tags by book ID, for servers that don't store tags
+	SmartCollections   []SmartCollection        `json:"smart_collections,omitempty"`    // Saved library filters, shown in the collections view
+	DisableSync        bool                     `json:"disable_sync,omitempty"`         // Keep favorites and the queue on this machine only
+	LastSync           *SyncedLists             `json:"last_sync,omitempty"`            // Favorites and queue as last agreed with the server
+	Progress           map[string]float64       `json:"progress,omitempty"`             // Share of each book read (0-1) by book ID, as of leaving the reader
+	StatusOverrides    map[string]string        `json:"read_status,omitempty"`          // Read status set by hand (unread, reading, finished) by book ID
+	Ratings            map[string]int           `json:"ratings,omitempty"`              // Personal star ratings (1-5) by book ID
+	Notes              map[string]string        `json:"notes,omitempty"`                // Personal notes by book ID
+	PageSize           int                      `json:"page_size,omitempty"`            // Books per library page (default 50)
+	InfiniteScroll     bool                     `json:"infinite_scroll,omitempty"`      // Append the next page as the library cursor nears the end
+	LibraryState       *LibraryState            `json:"library_state,omitempty"`        // Library sort, filters and position as last left
+	StartInLibrary     bool                     `json:"start_in_library,omitempty"`     // Skip the home dashboard after login
+	RefreshSeconds     int                      `json:"refresh_seconds,omitempty"`      // How often the library checks the server for new books (default 60, -1 disables)
+	RetryAttempts      int                      `json:"retry_attempts,omitempty"`       // Tries per read request on network errors (default 3, 1 disables retries)
+	RequestTimeout     int                      `json:"request_timeout,omitempty"`      // Seconds an API call may take (default 30)
+	TransferTimeout    int                      `json:"transfer_timeout,omitempty"`     // Seconds a book upload or download may take (default 0, no limit)
+	RateLimit          int                      `json:"rate_limit,omitempty"`           // API requests per second on average, in bursts of up to twice that (default 10, -1 disables)
+	CAFile             string                   `json:"ca_file,omitempty"`              // PEM bundle of extra trusted certificates, for self-signed servers
+	InsecureSkipVerify bool                     `json:"insecure_skip_verify,omitempty"` // Accept any server certificate (testing only)
+	ClientCert         string                   `json:"client_cert,omitempty"`          // PEM client certificate for servers behind mutual TLS
+	ClientKey          string                   `json:"client_key,omitempty"`           // PEM private key for client_cert
+	Proxy              string                   `json:"proxy,omitempty"`                // HTTP(S) or SOCKS5 proxy URL (default from HTTPS_PROXY, HTTP_PROXY or ALL_PROXY)
+	Headers            map[string]string        `json:"headers,omitempty"`              // Extra request headers, e.g. for an auth gateway; $VAR in values is read from the environment
+	PendingUploads     map[string]string        `json:"pending_uploads,omitempty"`      // Unfinished chunked upload sessions by file, resumed on the next upload
+	KeyPreset          string                   `json:"key_preset,omitempty"`           // Ready-made key set: vim (default), arrows or emacs
+	Keys               map[string]KeyRemaps     `json:"keys,omitempty"`                 // Remapped keys by view ("global" for all): key pressed to the key or action it acts as, "" to unbind
 
 	// Path to config file (not persisted)
 	path string `json:"-"`
+
+	keyringTokens map[string]string // Tokens as last stored in the keyring, by entry
+	keyringFailed bool              // The keyring couldn't be used this run
+	state         *stateStore       // Where the lists live, nil to keep them in the file
+	stateErr      error             // Why the state database exists but couldn't be used
+	runCAFile     string            // CA bundle given for this run only, ahead of ca_file
+	runInsecure   bool              // Certificate checks turned off for this run only
+	runLowPower   bool              // Low-power mode turned on for this run only
+	runEInk       bool              // E-ink mode turned on for this run only
 }
 
 const (
@@ -57,7 +189,57 @@ const (
 	TextScaleStep    = 0.1
 )
 
-// Load loads configuration from the config file
+// DefaultPageCacheMB is the default size cap for the on-disk comic page cache
+const DefaultPageCacheMB = 512
+
+// DefaultPanStepPercent is how much of the visible area a zoomed comic page pans per key press
+const DefaultPanStepPercent = 10
+
+// Books per library page
+const (
+	DefaultPageSize = 50
+	MinPageSize     = 10
+	MaxPageSize     = 500
+)
+
+// DefaultRefreshSeconds is how often the library checks the server for changes
+// unless refresh_seconds says otherwise
+const DefaultRefreshSeconds = 60
+
+// DefaultRequestTimeoutSeconds is how long an API call may take unless
+// request_timeout says otherwise
+const DefaultRequestTimeoutSeconds = 30
+
+// DefaultRateLimit is how many API requests per second are sent on average
+// unless rate_limit says otherwise
+const DefaultRateLimit = 10
+
+// Tries per read request; retries back off up to a few seconds each
+const (
+	DefaultRetryAttempts = 3
+	MaxRetryAttempts     = 10
+)
+
+// Comic slideshow time per page
+const (
+	DefaultSlideshowSeconds = 8
+	MinSlideshowSeconds     = 1
+	MaxSlideshowSeconds     = 120
+)
+
+// Defaults for automatic day/night theme switching
+const (
+	DefaultDayTheme    = "light"
+	DefaultNightTheme  = "dark"
+	DefaultSunriseHour = 7
+	DefaultSunsetHour  = 19
+)
+
+// UpdateCheckInterval is the minimum time between startup update checks
+const UpdateCheckInterval = 24 * time.Hour
+
+// Load loads configuration from the config file, which may be JSON, TOML or
+// YAML
 func Load() (*Config, error) {
 	configPath, err := getConfigPath()
 	if err != nil {
@@ -72,13 +254,14 @@ func Load() (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if os.IsNotExist(err) {
 		// Config doesn't exist, return defaults
+		cfg.loadState()
 		return cfg, nil
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(data, cfg); err != nil {
+	if err := decodeFile(configPath, data, cfg); err != nil {
 		return nil, err
 	}
 
@@ -87,6 +270,14 @@ func Load() (*Config, error) {
 		cfg.ServerURL = DefaultServerURL
 	}
 
+	// A hand-edited url in the active profile wins over the one last used
+	if profile, ok := cfg.GetProfile(cfg.Profile); ok && profile.URL != "" {
+		cfg.ServerURL = profile.URL
+	}
+
+	cfg.loadKeyringTokens()
+	cfg.loadState()
+
 	// Invalidate token if it was obtained from a different server
 	// Also clear if TokenServer is empty (legacy config) to force re-login
 	if cfg.Token != "" && (cfg.TokenServer == "" || cfg.TokenServer != cfg.ServerURL) {
@@ -102,20 +293,45 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// Save persists the configuration to disk
+// Save persists the configuration to disk. The file is replaced atomically
+// while holding a lock shared with other running instances.
 func (c *Config) Save() error {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+
 	// Ensure directory exists
 	dir := filepath.Dir(c.path)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	c.storeProfile()
+	saved := c
+	if c.state != nil || c.stateErr != nil {
+		// The lists live in the state database; keep them out of the file
+		saved = saved.withoutState()
+	}
+	if c.TokenKeyring && c.storeKeyringTokens() {
+		// The tokens live in the keyring; keep them out of the file
+		saved = saved.withoutTokens()
+	}
+
+	data, err := encodeFile(c.path, saved)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(c.path, data, 0600)
+	unlock, err := lockConfig(c.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeFileAtomic(c.path, data, 0600)
+}
+
+// Path returns the config file's path
+func (c *Config) Path() string {
+	return c.path
 }
 
 // SetToken updates the token and saves, also recording which server it's for
@@ -157,11 +373,21 @@ func (c *Config) AddRecentlyRead(bookID, title string) error {
 	c.RecentlyRead = append([]RecentlyReadEntry{entry}, newList...)
 
 	// Trim to max size
+	var dropped []string
 	if len(c.RecentlyRead) > MaxRecentlyRead {
+		for _, old := range c.RecentlyRead[MaxRecentlyRead:] {
+			dropped = append(dropped, old.BookID)
+		}
 		c.RecentlyRead = c.RecentlyRead[:MaxRecentlyRead]
 	}
 
-	return c.Save()
+	return c.saveState(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recentlyReadBucket)
+		if err := putJSON(bucket, bookID, entry); err != nil {
+			return err
+		}
+		return deleteKeys(bucket, dropped...)
+	})
 }
 
 // GetRecentlyReadIDs returns the list of recently read book IDs
@@ -173,6 +399,30 @@ func (c *Config) GetRecentlyReadIDs() []string {
 	return ids
 }
 
+// AddReadingSession appends a session to the reading history and saves
+func (c *Config) AddReadingSession(session ReadingSession) error {
+	c.History = append(c.History, session)
+	if len(c.History) > MaxHistory {
+		c.History = c.History[len(c.History)-MaxHistory:]
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historyBucket)
+		if err := appendHistory(bucket, session); err != nil {
+			return err
+		}
+		return trimHistory(bucket)
+	})
+}
+
+// GetHistory returns reading sessions, newest first
+func (c *Config) GetHistory() []ReadingSession {
+	history := make([]ReadingSession, len(c.History))
+	for i, s := range c.History {
+		history[len(c.History)-1-i] = s
+	}
+	return history
+}
+
 // IsFavorite returns true if the book is favorited
 func (c *Config) IsFavorite(bookID string) bool {
 	for _, id := range c.Favorites {
@@ -198,7 +448,10 @@ func (c *Config) ToggleFavorite(bookID string) error {
 		// Add to favorites
 		c.Favorites = append(c.Favorites, bookID)
 	}
-	return c.Save()
+	favorite := c.IsFavorite(bookID)
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(favoritesBucket), []string{bookID}, favorite)
+	})
 }
 
 // GetFavoriteIDs returns the list of favorited book IDs
@@ -206,6 +459,44 @@ func (c *Config) GetFavoriteIDs() []string {
 	return c.Favorites
 }
 
+// SetFavorites adds or removes several books from favorites with a single save
+func (c *Config) SetFavorites(bookIDs []string, favorite bool) error {
+	for _, id := range bookIDs {
+		if favorite && !c.IsFavorite(id) {
+			c.Favorites = append(c.Favorites, id)
+		} else if !favorite {
+			c.Favorites = removeString(c.Favorites, id)
+		}
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(favoritesBucket), bookIDs, favorite)
+	})
+}
+
+// IsArchived returns true if the book is hidden from the library
+func (c *Config) IsArchived(bookID string) bool {
+	for _, id := range c.Archived {
+		if id == bookID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetArchived archives (hides) or restores books and saves
+func (c *Config) SetArchived(bookIDs []string, archived bool) error {
+	for _, id := range bookIDs {
+		if archived && !c.IsArchived(id) {
+			c.Archived = append(c.Archived, id)
+		} else if !archived {
+			c.Archived = removeString(c.Archived, id)
+		}
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(archivedBucket), bookIDs, archived)
+	})
+}
+
 // IsInQueue returns true if the book is in the reading queue
 func (c *Config) IsInQueue(bookID string) bool {
 	for _, id := range c.ReadingQueue {
@@ -239,7 +530,9 @@ func (c *Config) AddToQueue(bookID string) error {
 	if !c.IsInQueue(bookID) {
 		c.ReadingQueue = append(c.ReadingQueue, bookID)
 	}
-	return c.Save()
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(queueBucket), []string{bookID}, true)
+	})
 }
 
 // RemoveFromQueue removes a book from the reading queue
@@ -251,7 +544,34 @@ func (c *Config) RemoveFromQueue(bookID string) error {
 		}
 	}
 	c.ReadingQueue = newQueue
-	return c.Save()
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(queueBucket), []string{bookID}, false)
+	})
+}
+
+// SetQueued adds several books to the end of the reading queue, or removes them, with a single save
+func (c *Config) SetQueued(bookIDs []string, queued bool) error {
+	for _, id := range bookIDs {
+		if queued && !c.IsInQueue(id) {
+			c.ReadingQueue = append(c.ReadingQueue, id)
+		} else if !queued {
+			c.ReadingQueue = removeString(c.ReadingQueue, id)
+		}
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		return setMembers(tx.Bucket(queueBucket), bookIDs, queued)
+	})
+}
+
+// removeString returns list without any occurrence of s
+func removeString(list []string, s string) []string {
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
 }
 
 // MoveInQueue moves a book up or down in the queue
@@ -275,7 +595,10 @@ func (c *Config) MoveInQueue(bookID string, delta int) error {
 
 	// Swap positions
 	c.ReadingQueue[idx], c.ReadingQueue[newIdx] = c.ReadingQueue[newIdx], c.ReadingQueue[idx]
-	return c.Save()
+	other := c.ReadingQueue[idx]
+	return c.saveState(func(tx *bolt.Tx) error {
+		return swapMembers(tx.Bucket(queueBucket), bookID, other)
+	})
 }
 
 // GetQueueIDs returns the ordered list of queued book IDs
@@ -283,6 +606,118 @@ func (c *Config) GetQueueIDs() []string {
 	return c.ReadingQueue
 }
 
+// GetBookTags returns the local tags for a book
+func (c *Config) GetBookTags(bookID string) []string {
+	return c.BookTags[bookID]
+}
+
+// SetBookTags replaces the local tags for a book (none removes the entry) and saves
+func (c *Config) SetBookTags(bookID string, tags []string) error {
+	if len(tags) == 0 {
+		if _, ok := c.BookTags[bookID]; !ok {
+			return nil
+		}
+		delete(c.BookTags, bookID)
+		return c.saveState(func(tx *bolt.Tx) error {
+			return deleteKeys(tx.Bucket(bookTagsBucket), bookID)
+		})
+	}
+	if c.BookTags == nil {
+		c.BookTags = make(map[string][]string)
+	}
+	c.BookTags[bookID] = tags
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(bookTagsBucket), bookID, tags)
+	})
+}
+
+// AllBookTags returns every local tag in use, sorted
+func (c *Config) AllBookTags() []string {
+	seen := make(map[string]bool)
+	var all []string
+	for _, tags := range c.BookTags {
+		for _, tag := range tags {
+			if !seen[tag] {
+				seen[tag] = true
+				all = append(all, tag)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// MaxRating is the most stars a book can be rated
+const MaxRating = 5
+
+// GetRating returns the stars a book was rated, 0 if unrated
+func (c *Config) GetRating(bookID string) int {
+	return c.Ratings[bookID]
+}
+
+// SetRating rates a book 1-MaxRating stars, or clears its rating with 0, and saves
+func (c *Config) SetRating(bookID string, stars int) error {
+	if stars <= 0 {
+		delete(c.Ratings, bookID)
+		return c.saveState(func(tx *bolt.Tx) error {
+			return deleteKeys(tx.Bucket(ratingsBucket), bookID)
+		})
+	}
+	if c.Ratings == nil {
+		c.Ratings = make(map[string]int)
+	}
+	c.Ratings[bookID] = min(stars, MaxRating)
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(ratingsBucket), bookID, c.Ratings[bookID])
+	})
+}
+
+// GetNote returns the note attached to a book
+func (c *Config) GetNote(bookID string) string {
+	return c.Notes[bookID]
+}
+
+// SetNote attaches a note to a book (empty removes it) and saves
+func (c *Config) SetNote(bookID, note string) error {
+	if note == "" {
+		delete(c.Notes, bookID)
+		return c.saveState(func(tx *bolt.Tx) error {
+			return deleteKeys(tx.Bucket(notesBucket), bookID)
+		})
+	}
+	if c.Notes == nil {
+		c.Notes = make(map[string]string)
+	}
+	c.Notes[bookID] = note
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(notesBucket), bookID, note)
+	})
+}
+
+// SaveSmartCollection adds a smart collection, replacing any with the same name, and saves
+func (c *Config) SaveSmartCollection(collection SmartCollection) error {
+	for i, existing := range c.SmartCollections {
+		if existing.Name == collection.Name {
+			c.SmartCollections[i] = collection
+			return c.Save()
+		}
+	}
+	c.SmartCollections = append(c.SmartCollections, collection)
+	return c.Save()
+}
+
+// DeleteSmartCollection removes the named smart collection and saves
+func (c *Config) DeleteSmartCollection(name string) error {
+	collections := make([]SmartCollection, 0, len(c.SmartCollections))
+	for _, collection := range c.SmartCollections {
+		if collection.Name != name {
+			collections = append(collections, collection)
+		}
+	}
+	c.SmartCollections = collections
+	return c.Save()
+}
+
 // GetTextScale returns the text scale, defaulting to 1.0
 func (c *Config) GetTextScale() float64 {
 	if c.TextScale < MinTextScale || c.TextScale > MaxTextScale {
@@ -321,7 +756,9 @@ func (c *Config) AddBookmark(bookID, bookTitle string, chapter int, chapterTitle
 		CreatedAt:    time.Now(),
 	}
 	c.Bookmarks = append(c.Bookmarks, bookmark)
-	return c.Save()
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(bookmarksBucket), bookmark.ID, bookmark)
+	})
 }
 
 // GetBookmarks returns all bookmarks
@@ -349,7 +786,38 @@ func (c *Config) DeleteBookmark(bookmarkID string) error {
 		}
 	}
 	c.Bookmarks = newBookmarks
-	return c.Save()
+	return c.saveState(func(tx *bolt.Tx) error {
+		return deleteKeys(tx.Bucket(bookmarksBucket), bookmarkID)
+	})
+}
+
+// RenameBookmark sets the display name of a bookmark and saves
+func (c *Config) RenameBookmark(bookmarkID, name string) error {
+	for i := range c.Bookmarks {
+		if c.Bookmarks[i].ID == bookmarkID {
+			c.Bookmarks[i].Name = name
+			return c.saveBookmark(c.Bookmarks[i])
+		}
+	}
+	return nil
+}
+
+// SetBookmarkNote sets the note attached to a bookmark and saves
+func (c *Config) SetBookmarkNote(bookmarkID, note string) error {
+	for i := range c.Bookmarks {
+		if c.Bookmarks[i].ID == bookmarkID {
+			c.Bookmarks[i].Note = note
+			return c.saveBookmark(c.Bookmarks[i])
+		}
+	}
+	return nil
+}
+
+// saveBookmark persists a changed bookmark
+func (c *Config) saveBookmark(bookmark Bookmark) error {
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(bookmarksBucket), bookmark.ID, bookmark)
+	})
 }
 
 // generateBookmarkID creates a unique bookmark ID
@@ -365,13 +833,303 @@ func (c *Config) GetThemeName() string {
 	return c.Theme
 }
 
-// SetTheme sets the theme name and saves
+// SetTheme sets the theme name and saves.
+// Picking a theme manually turns off automatic day/night switching.
 func (c *Config) SetTheme(themeName string) error {
 	c.Theme = themeName
+	c.AutoTheme = false
+	return c.Save()
+}
+
+// SetAutoTheme enables or disables automatic day/night theme switching and saves
+func (c *Config) SetAutoTheme(enabled bool) error {
+	c.AutoTheme = enabled
+	return c.Save()
+}
+
+// ActiveThemeName returns the theme to use at the given time.
+// Without auto_theme this is just the configured theme.
+func (c *Config) ActiveThemeName(now time.Time) string {
+	if !c.AutoTheme {
+		return c.GetThemeName()
+	}
+
+	sunrise := hourOrDefault(c.SunriseHour, DefaultSunriseHour)
+	sunset := hourOrDefault(c.SunsetHour, DefaultSunsetHour)
+	hour := now.Hour()
+	var day bool
+	if sunrise <= sunset {
+		day = hour >= sunrise && hour < sunset
+	} else {
+		// Day wraps past midnight (e.g. night shift schedules)
+		day = hour >= sunrise || hour < sunset
+	}
+
+	if day {
+		if c.DayTheme == "" {
+			return DefaultDayTheme
+		}
+		return c.DayTheme
+	}
+	if c.NightTheme == "" {
+		return DefaultNightTheme
+	}
+	return c.NightTheme
+}
+
+// hourOrDefault returns hour if it is a valid non-zero hour of the day, otherwise def
+func hourOrDefault(hour, def int) int {
+	if hour <= 0 || hour > 23 {
+		return def
+	}
+	return hour
+}
+
+// GetPageCacheBytes returns the comic page cache size cap in bytes (0 = disabled)
+func (c *Config) GetPageCacheBytes() int64 {
+	if c.PageCacheMB < 0 {
+		return 0
+	}
+	if c.PageCacheMB == 0 {
+		return DefaultPageCacheMB << 20
+	}
+	return int64(c.PageCacheMB) << 20
+}
+
+// GetPanStep returns the comic pan step as a fraction of the visible area
+func (c *Config) GetPanStep() float64 {
+	if c.PanStepPercent <= 0 {
+		return DefaultPanStepPercent / 100.0
+	}
+	return float64(min(100, c.PanStepPercent)) / 100
+}
+
+// SetLibraryState records where the library was left and saves, unless it is unchanged
+func (c *Config) SetLibraryState(state LibraryState) error {
+	if c.LibraryState != nil && *c.LibraryState == state {
+		return nil
+	}
+	c.LibraryState = &state
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(libraryBucket), libraryStateKey, state)
+	})
+}
+
+// GetPageSize returns the number of books per library page
+func (c *Config) GetPageSize() int {
+	if c.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return min(MaxPageSize, max(MinPageSize, c.PageSize))
+}
+
+// GetRefreshInterval returns how often the library checks the server for
+// changes (0 = never)
+func (c *Config) GetRefreshInterval() time.Duration {
+	if c.RefreshSeconds < 0 {
+		return 0
+	}
+	if c.RefreshSeconds == 0 {
+		return DefaultRefreshSeconds * time.Second
+	}
+	return time.Duration(max(10, c.RefreshSeconds)) * time.Second
+}
+
+// GetRetryAttempts returns how many times a read request is tried before its
+// error is shown (0 means the client default)
+func (c *Config) GetRetryAttempts() int {
+	if c.RetryAttempts <= 0 {
+		return DefaultRetryAttempts
+	}
+	return min(MaxRetryAttempts, c.RetryAttempts)
+}
+
+// GetRequestTimeout returns how long an API call may take
+func (c *Config) GetRequestTimeout() time.Duration {
+	if c.RequestTimeout <= 0 {
+		return DefaultRequestTimeoutSeconds * time.Second
+	}
+	return time.Duration(c.RequestTimeout) * time.Second
+}
+
+// GetTransferTimeout returns how long a book upload or download may take (0 = no limit)
+func (c *Config) GetTransferTimeout() time.Duration {
+	return time.Duration(max(0, c.TransferTimeout)) * time.Second
+}
+
+// GetRateLimit returns how many API requests per second may be sent on average
+// (0 = no limit)
+func (c *Config) GetRateLimit() float64 {
+	switch {
+	case c.RateLimit < 0:
+		return 0
+	case c.RateLimit == 0:
+		return DefaultRateLimit
+	}
+	return float64(c.RateLimit)
+}
+
+// GetHeaders returns the extra request headers, with environment variables in
+// their values expanded so secrets can stay out of the config file
+func (c *Config) GetHeaders() map[string]string {
+	headers := make(map[string]string, len(c.Headers))
+	for name, value := range c.Headers {
+		headers[name] = os.ExpandEnv(value)
+	}
+	return headers
+}
+
+// SetRunTLS trusts an extra CA bundle or skips certificate checks for this run
+// only, without saving either to the config file
+func (c *Config) SetRunTLS(caFile string, insecure bool) {
+	c.runCAFile, c.runInsecure = caFile, insecure
+}
+
+// GetCAFile returns the PEM bundle of extra trusted certificates, if any
+func (c *Config) GetCAFile() string {
+	if c.runCAFile != "" {
+		return c.runCAFile
+	}
+	return c.CAFile
+}
+
+// SkipTLSVerify reports whether any server certificate is accepted
+func (c *Config) SkipTLSVerify() bool {
+	return c.runInsecure || c.InsecureSkipVerify
+}
+
+// PendingUpload returns the unfinished upload session for a file, if any
+func (c *Config) PendingUpload(key string) string {
+	return c.PendingUploads[key]
+}
+
+// SetPendingUpload records an unfinished upload session for a file, or forgets
+// it when uploadID is empty, and saves
+func (c *Config) SetPendingUpload(key, uploadID string) error {
+	if uploadID == "" {
+		if _, ok := c.PendingUploads[key]; !ok {
+			return nil
+		}
+		delete(c.PendingUploads, key)
+		return c.Save()
+	}
+	if c.PendingUploads == nil {
+		c.PendingUploads = make(map[string]string)
+	}
+	if c.PendingUploads[key] == uploadID {
+		return nil
+	}
+	c.PendingUploads[key] = uploadID
+	return c.Save()
+}
+
+// GetSlideshowSeconds returns the comic slideshow time per page
+func (c *Config) GetSlideshowSeconds() int {
+	if c.SlideshowSeconds <= 0 {
+		return DefaultSlideshowSeconds
+	}
+	return min(MaxSlideshowSeconds, max(MinSlideshowSeconds, c.SlideshowSeconds))
+}
+
+// SetSlideshowSeconds sets the comic slideshow time per page (clamped) and saves
+func (c *Config) SetSlideshowSeconds(seconds int) error {
+	c.SlideshowSeconds = min(MaxSlideshowSeconds, max(MinSlideshowSeconds, seconds))
+	return c.Save()
+}
+
+// SetComicZoomLock sets whether comic zoom and vertical pan carry over between pages and saves
+func (c *Config) SetComicZoomLock(enabled bool) error {
+	c.ComicZoomLock = enabled
+	return c.Save()
+}
+
+// SetEInk enables or disables e-ink mode and saves. Disabling it also ends
+// e-ink mode turned on for this run.
+func (c *Config) SetEInk(enabled bool) error {
+	c.EInk = enabled
+	if !enabled {
+		c.runEInk = false
+	}
+	return c.Save()
+}
+
+// SetRunEInk turns e-ink mode on for this run only, without saving it
+func (c *Config) SetRunEInk() {
+	c.runEInk = true
+}
+
+// EInkMode reports whether pages are drawn for an e-ink display
+func (c *Config) EInkMode() bool {
+	return c.EInk || c.runEInk
+}
+
+// SetRunLowPower turns low-power mode on for this run only, without saving it
+func (c *Config) SetRunLowPower() {
+	c.runLowPower = true
+}
+
+// ReduceRedraws reports whether redraws should be kept to a minimum,
+// for battery saving or because the display is e-ink
+func (c *Config) ReduceRedraws() bool {
+	return c.LowPower || c.runLowPower || c.EInkMode()
+}
+
+// GetDownloadDir returns the directory downloaded books are saved to, expanding a
+// leading ~. Without a setting it is ~/Downloads, or the home directory if that is missing.
+func (c *Config) GetDownloadDir() string {
+	home, err := os.UserHomeDir()
+	if c.DownloadDir != "" {
+		if err == nil && (c.DownloadDir == "~" || strings.HasPrefix(c.DownloadDir, "~/")) {
+			return filepath.Join(home, c.DownloadDir[1:])
+		}
+		return c.DownloadDir
+	}
+	if err != nil {
+		return "."
+	}
+	downloads := filepath.Join(home, "Downloads")
+	if info, err := os.Stat(downloads); err == nil && info.IsDir() {
+		return downloads
+	}
+	return home
+}
+
+// GetComicSettings returns the viewer settings saved for a comic (defaults if none)
+func (c *Config) GetComicSettings(bookID string) ComicSettings {
+	return c.Comics[bookID]
+}
+
+// SetComicSettings saves the viewer settings for a comic, dropping entries left at defaults
+func (c *Config) SetComicSettings(bookID string, settings ComicSettings) error {
+	if settings == (ComicSettings{}) {
+		delete(c.Comics, bookID)
+	} else {
+		if c.Comics == nil {
+			c.Comics = make(map[string]ComicSettings)
+		}
+		c.Comics[bookID] = settings
+	}
+	return c.saveState(func(tx *bolt.Tx) error {
+		if settings == (ComicSettings{}) {
+			return deleteKeys(tx.Bucket(comicsBucket), bookID)
+		}
+		return putJSON(tx.Bucket(comicsBucket), bookID, settings)
+	})
+}
+
+// UpdateCheckDue reports whether the opt-in startup update check should run
+func (c *Config) UpdateCheckDue(now time.Time) bool {
+	return c.CheckUpdates && now.Sub(c.LastUpdateCheck) >= UpdateCheckInterval
+}
+
+// MarkUpdateChecked records when the update check ran and saves
+func (c *Config) MarkUpdateChecked(now time.Time) error {
+	c.LastUpdateCheck = now
 	return c.Save()
 }
 
-// getConfigPath returns the path to the config file
+// getConfigPath returns the path to the config file: config.toml, config.yaml
+// or config.yml if one exists, otherwise config.json
 func getConfigPath() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
@@ -383,5 +1141,5 @@ func getConfigPath() (string, error) {
 		configDir = filepath.Join(home, ".config")
 	}
 
-	return filepath.Join(configDir, configDirName, configFileName), nil
+	return findConfigFile(filepath.Join(configDir, configDirName)), nil
 }
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunOverridesAreNotSaved(t *testing.T) {
+	cfg := &Config{ServerURL: DefaultServerURL, path: filepath.Join(t.TempDir(), "config.json")}
+	cfg.SetRunTLS("/tmp/ca.pem", true)
+	cfg.SetRunLowPower()
+	cfg.SetRunEInk()
+	if cfg.GetCAFile() != "/tmp/ca.pem" || !cfg.SkipTLSVerify() {
+		t.Fatalf("overrides not applied: %q, %v", cfg.GetCAFile(), cfg.SkipTLSVerify())
+	}
+	if !cfg.ReduceRedraws() || !cfg.EInkMode() {
+		t.Fatal("low-power or e-ink override not applied")
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(cfg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"ca_file", "insecure_skip_verify", "low_power", "eink"} {
+		if strings.Contains(string(data), key) {
+			t.Errorf("config file holds %s:\n%s", key, data)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames are the config files looked for, in order; the first that
+// exists is used. The format follows the extension.
+var configFileNames = []string{"config.toml", "config.yaml", "config.yml", configFileName}
+
+// findConfigFile returns the config file in dir, or config.json if there is none
+func findConfigFile(dir string) string {
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, configFileName)
+}
+
+// isConfigFile reports whether path has an extension a config or theme file
+// can be written in
+func isConfigFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".toml", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// decodeFile unmarshals data into v by path's extension. TOML and YAML go
+// through JSON so the structs' json tags name their keys too.
+func decodeFile(path string, data []byte, v interface{}) error {
+	var doc map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+	default:
+		return json.Unmarshal(data, v)
+	}
+
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(converted, v)
+}
+
+// encodeFile marshals v in the format of path's extension, using the same keys
+// as JSON
+func encodeFile(path string, v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".toml" && ext != ".yaml" && ext != ".yml" {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	doc = plainValues(doc)
+
+	if ext == ".toml" {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return yaml.Marshal(doc)
+}
+
+// plainValues turns JSON numbers into ints or floats and drops nulls, which
+// TOML can't hold, so values keep their JSON types
+func plainValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = plainValues(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = plainValues(value)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil && !math.IsInf(f, 0) {
+			return f
+		}
+		return v.String()
+	}
+	return v
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// The tokens' entries in the OS keyring (Secret Service, Keychain or Windows
+// Credential Manager): the current token, and each profile's
+const (
+	keyringService       = "webby-t"
+	keyringUser          = "token"
+	keyringProfilePrefix = "profile:"
+)
+
+// keyringEntries returns the tokens kept in the keyring by entry name
+func (c *Config) keyringEntries() map[string]*string {
+	entries := map[string]*string{keyringUser: &c.Token}
+	for i := range c.Profiles {
+		entries[keyringProfilePrefix+c.Profiles[i].Name] = &c.Profiles[i].Token
+	}
+	return entries
+}
+
+// loadKeyringTokens reads the tokens from the keyring when the config keeps
+// them there. Tokens still in the file are moved into the keyring.
+func (c *Config) loadKeyringTokens() {
+	if !c.TokenKeyring {
+		return
+	}
+	c.keyringTokens = make(map[string]string)
+	inFile := false
+	for user, token := range c.keyringEntries() {
+		if *token != "" {
+			inFile = true
+			continue
+		}
+		stored, err := keyring.Get(keyringService, user)
+		if errors.Is(err, keyring.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			c.keyringFailed = true
+			return
+		}
+		*token = stored
+		c.keyringTokens[user] = stored
+	}
+	if inFile {
+		_ = c.Save()
+	}
+}
+
+// storeKeyringTokens brings the keyring entries in line with the tokens,
+// returning false if the keyring can't be used so the tokens are saved in the
+// file instead
+func (c *Config) storeKeyringTokens() bool {
+	if c.keyringFailed {
+		return false
+	}
+	if c.keyringTokens == nil {
+		c.keyringTokens = make(map[string]string)
+	}
+
+	entries := c.keyringEntries()
+	for user := range c.keyringTokens {
+		if _, ok := entries[user]; !ok {
+			// A profile removed from the config
+			empty := ""
+			entries[user] = &empty
+		}
+	}
+	for user, token := range entries {
+		if *token == c.keyringTokens[user] {
+			continue
+		}
+		var err error
+		if *token == "" {
+			err = keyring.Delete(keyringService, user)
+			if errors.Is(err, keyring.ErrNotFound) {
+				err = nil
+			}
+		} else {
+			err = keyring.Set(keyringService, user, *token)
+		}
+		if err != nil {
+			// No keyring service on this machine (e.g. a headless session)
+			c.keyringFailed = true
+			return false
+		}
+		if *token == "" {
+			delete(c.keyringTokens, user)
+		} else {
+			c.keyringTokens[user] = *token
+		}
+	}
+	return true
+}
+
+// withoutTokens returns a copy of the config to save with the tokens left out
+func (c *Config) withoutTokens() *Config {
+	saved := *c
+	saved.Token = ""
+	saved.Profiles = make([]Profile, len(c.Profiles))
+	for i, profile := range c.Profiles {
+		profile.Token = ""
+		saved.Profiles[i] = profile
+	}
+	return &saved
+}
+
+// TokenStorage describes where the token is kept: "keyring" or "config file"
+func (c *Config) TokenStorage() string {
+	if c.TokenKeyring && !c.keyringFailed {
+		return "keyring"
+	}
+	return "config file"
+}
@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package config
+
+import "os"
+
+// lockFile does nothing where there are no advisory locks; saves are still
+// atomic, but two instances saving at once can lose one's changes
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing, like lockFile
+func unlockFile(file *os.File) error {
+	return nil
+}
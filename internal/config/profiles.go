@@ -0,0 +1,62 @@
+package config
+
+import "fmt"
+
+// Profile is a named server with its own login, for switching between servers.
+// The active profile's server and login are the config's own; the profile
+// entry is kept in step when the config is saved.
+type Profile struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// GetProfile returns the profile with name
+func (c *Config) GetProfile(name string) (Profile, bool) {
+	for _, profile := range c.Profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// UseProfile switches to the server and login of the named profile, keeping
+// the current login in the profile being left, and saves
+func (c *Config) UseProfile(name string) error {
+	profile, ok := c.GetProfile(name)
+	if !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	if profile.URL == "" {
+		return fmt.Errorf("profile %q has no url", name)
+	}
+	c.storeProfile()
+
+	c.Profile = name
+	c.ServerURL = profile.URL
+	c.Token = profile.Token
+	c.TokenServer = ""
+	if profile.Token != "" {
+		c.TokenServer = profile.URL
+	}
+	c.Username = profile.Username
+	return c.Save()
+}
+
+// storeProfile copies the current server and login into the active profile
+func (c *Config) storeProfile() {
+	for i := range c.Profiles {
+		if c.Profiles[i].Name != c.Profile {
+			continue
+		}
+		c.Profiles[i].URL = c.ServerURL
+		c.Profiles[i].Token = c.Token
+		c.Profiles[i].Username = c.Username
+		if c.TokenServer != c.ServerURL {
+			c.Profiles[i].Token = ""
+		}
+		return
+	}
+}
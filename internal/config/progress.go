@@ -0,0 +1,130 @@
+package config
+
+import bolt "go.etcd.io/bbolt"
+
+// FinishedProgress is the share of a book read from which it counts as finished
+const FinishedProgress = 0.98
+
+// ReadStatus is how far the user has got with a book
+type ReadStatus int
+
+const (
+	StatusUnread ReadStatus = iota
+	StatusReading
+	StatusFinished
+)
+
+// String returns the status name used in filters and the config file
+func (s ReadStatus) String() string {
+	switch s {
+	case StatusReading:
+		return "reading"
+	case StatusFinished:
+		return "finished"
+	default:
+		return "unread"
+	}
+}
+
+// Label returns the status as shown to the user
+func (s ReadStatus) Label() string {
+	switch s {
+	case StatusReading:
+		return "In progress"
+	case StatusFinished:
+		return "Finished"
+	default:
+		return "Unread"
+	}
+}
+
+// ParseReadStatus returns the status with the given name, and whether there is one
+func ParseReadStatus(name string) (ReadStatus, bool) {
+	for s := StatusUnread; s <= StatusFinished; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return StatusUnread, false
+}
+
+// SetProgress records the share of a book read (0-1), as of leaving the reader.
+// Reading a book again drops any status set by hand.
+func (c *Config) SetProgress(bookID string, fraction float64) error {
+	if c.Progress == nil {
+		c.Progress = make(map[string]float64)
+	}
+	c.Progress[bookID] = max(0, min(1, fraction))
+	delete(c.StatusOverrides, bookID)
+	return c.saveState(func(tx *bolt.Tx) error {
+		if err := putJSON(tx.Bucket(progressBucket), bookID, c.Progress[bookID]); err != nil {
+			return err
+		}
+		return deleteKeys(tx.Bucket(readStatusBucket), bookID)
+	})
+}
+
+// GetProgress returns the share of a book read, and whether it has been opened
+func (c *Config) GetProgress(bookID string) (float64, bool) {
+	fraction, ok := c.Progress[bookID]
+	return fraction, ok
+}
+
+// GetReadStatus returns whether a book is unread, being read or finished: the
+// status set by hand if there is one, otherwise from the recorded progress
+func (c *Config) GetReadStatus(bookID string) ReadStatus {
+	if status, ok := ParseReadStatus(c.StatusOverrides[bookID]); ok {
+		return status
+	}
+	fraction, ok := c.GetProgress(bookID)
+	switch {
+	case !ok:
+		return StatusUnread
+	case fraction >= FinishedProgress:
+		return StatusFinished
+	default:
+		return StatusReading
+	}
+}
+
+// SetReadStatus overrides a book's read status until it is next read
+func (c *Config) SetReadStatus(bookID string, status ReadStatus) error {
+	if c.StatusOverrides == nil {
+		c.StatusOverrides = make(map[string]string)
+	}
+	c.StatusOverrides[bookID] = status.String()
+	return c.saveState(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(readStatusBucket), bookID, status.String())
+	})
+}
+
+// MarkFinished records a book as fully read and takes it out of the reading queue
+func (c *Config) MarkFinished(bookID string) error {
+	if c.Progress == nil {
+		c.Progress = make(map[string]float64)
+	}
+	c.Progress[bookID] = 1
+	delete(c.StatusOverrides, bookID)
+	c.ReadingQueue = removeString(c.ReadingQueue, bookID)
+	return c.saveState(func(tx *bolt.Tx) error {
+		if err := putJSON(tx.Bucket(progressBucket), bookID, 1.0); err != nil {
+			return err
+		}
+		if err := deleteKeys(tx.Bucket(readStatusBucket), bookID); err != nil {
+			return err
+		}
+		return setMembers(tx.Bucket(queueBucket), []string{bookID}, false)
+	})
+}
+
+// ResetProgress forgets how far a book has been read, making it unread again
+func (c *Config) ResetProgress(bookID string) error {
+	delete(c.Progress, bookID)
+	delete(c.StatusOverrides, bookID)
+	return c.saveState(func(tx *bolt.Tx) error {
+		if err := deleteKeys(tx.Bucket(progressBucket), bookID); err != nil {
+			return err
+		}
+		return deleteKeys(tx.Bucket(readStatusBucket), bookID)
+	})
+}
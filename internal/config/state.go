@@ -0,0 +1,568 @@
+package config
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Local state (favorites, the reading queue, recently read books, bookmarks,
+// reading progress and history, and everything else kept per book) lives in a
+// bbolt database next to the config file, so a change writes only the entries
+// it touches and the config file holds just the settings.
+
+// stateFileName is the state database's file name
+const stateFileName = "state.db"
+
+// stateLockTimeout is how long to wait for another running instance to finish
+// with the database
+const stateLockTimeout = 2 * time.Second
+
+// Buckets in the state database. Favorites, the queue and archived books map
+// book IDs to their place in the list; the history maps sequence numbers to
+// sessions; the library bucket holds the library state under libraryStateKey;
+// the rest are JSON by book ID or bookmark ID.
+var (
+	favoritesBucket    = []byte("favorites")
+	queueBucket        = []byte("queue")
+	archivedBucket     = []byte("archived")
+	recentlyReadBucket = []byte("recently_read")
+	bookmarksBucket    = []byte("bookmarks")
+	bookSettingsBucket = []byte("book_settings")
+	comicsBucket       = []byte("comics")
+	progressBucket     = []byte("progress")
+	readStatusBucket   = []byte("read_status")
+	ratingsBucket      = []byte("ratings")
+	notesBucket        = []byte("notes")
+	bookTagsBucket     = []byte("book_tags")
+	historyBucket      = []byte("history")
+	libraryBucket      = []byte("library")
+)
+
+var stateBuckets = [][]byte{
+	favoritesBucket, queueBucket, archivedBucket, recentlyReadBucket, bookmarksBucket, bookSettingsBucket,
+	comicsBucket, progressBucket, readStatusBucket, ratingsBucket, notesBucket, bookTagsBucket, historyBucket,
+	libraryBucket,
+}
+
+// libraryStateKey is the library state's key in the library bucket
+const libraryStateKey = "state"
+
+// stateStore is the local state database. It is opened for each change so
+// several running instances can share it.
+type stateStore struct {
+	path string
+}
+
+// update runs fn in a write transaction, creating the buckets as needed
+func (s *stateStore) update(fn func(tx *bolt.Tx) error) error {
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: stateLockTimeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range stateBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// loadState moves the config onto the state database. Lists still in the
+// config file (from before the database, or saved while it couldn't be
+// created) are merged into it, then everything is read from it. Without a
+// usable database the lists stay in the config file, unless the database
+// exists but is locked by another instance or unreadable: then the lists
+// aren't saved anywhere this run rather than shadowing the database's.
+func (c *Config) loadState() {
+	store := &stateStore{path: filepath.Join(filepath.Dir(c.path), stateFileName)}
+	inFile := c.hasState()
+
+	if err := os.MkdirAll(filepath.Dir(store.path), 0700); err != nil {
+		return
+	}
+	_, statErr := os.Stat(store.path)
+	err := store.update(func(tx *bolt.Tx) error {
+		if inFile {
+			if err := c.mergeState(tx); err != nil {
+				return err
+			}
+		}
+		return c.readState(tx)
+	})
+	if err != nil {
+		if statErr == nil && !inFile {
+			c.stateErr = fmt.Errorf("local state unavailable: %w", err)
+		}
+		return
+	}
+	c.state = store
+	if inFile {
+		_ = c.Save()
+	}
+}
+
+// hasState reports whether the config holds any of the lists kept in the
+// state database
+func (c *Config) hasState() bool {
+	return len(c.Favorites) > 0 || len(c.ReadingQueue) > 0 || len(c.Archived) > 0 || len(c.RecentlyRead) > 0 ||
+		len(c.Bookmarks) > 0 || len(c.BookSettings) > 0 || len(c.Comics) > 0 || len(c.Progress) > 0 ||
+		len(c.StatusOverrides) > 0 || len(c.Ratings) > 0 || len(c.Notes) > 0 || len(c.BookTags) > 0 ||
+		len(c.History) > 0 || c.LibraryState != nil
+}
+
+// mergeState adds the config's lists to the database's. Entries for the same
+// book are taken from the config, except recently read ones, where the later
+// opening wins.
+func (c *Config) mergeState(tx *bolt.Tx) error {
+	if err := setMembers(tx.Bucket(favoritesBucket), c.Favorites, true); err != nil {
+		return err
+	}
+	if err := setMembers(tx.Bucket(queueBucket), c.ReadingQueue, true); err != nil {
+		return err
+	}
+	if err := setMembers(tx.Bucket(archivedBucket), c.Archived, true); err != nil {
+		return err
+	}
+
+	recentlyRead, err := readEntries[RecentlyReadEntry](tx.Bucket(recentlyReadBucket))
+	if err != nil {
+		return err
+	}
+	for _, entry := range c.RecentlyRead {
+		if current, ok := recentlyRead[entry.BookID]; ok && !entry.OpenedAt.After(current.OpenedAt) {
+			continue
+		}
+		if err := putJSON(tx.Bucket(recentlyReadBucket), entry.BookID, entry); err != nil {
+			return err
+		}
+	}
+	for _, bookmark := range c.Bookmarks {
+		if err := putJSON(tx.Bucket(bookmarksBucket), bookmark.ID, bookmark); err != nil {
+			return err
+		}
+	}
+
+	history, err := readHistory(tx.Bucket(historyBucket))
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(history))
+	for _, session := range history {
+		seen[session.key()] = true
+	}
+	for _, session := range c.History {
+		if !seen[session.key()] {
+			history = append(history, session)
+		}
+	}
+	if err := writeHistory(tx.Bucket(historyBucket), history); err != nil {
+		return err
+	}
+
+	if c.LibraryState != nil {
+		if err := putJSON(tx.Bucket(libraryBucket), libraryStateKey, c.LibraryState); err != nil {
+			return err
+		}
+	}
+	return c.putBookEntries(tx)
+}
+
+// writeState replaces the database's lists with the config's
+func (c *Config) writeState(tx *bolt.Tx) error {
+	if err := replaceMembers(tx.Bucket(favoritesBucket), c.Favorites); err != nil {
+		return err
+	}
+	if err := replaceMembers(tx.Bucket(queueBucket), c.ReadingQueue); err != nil {
+		return err
+	}
+	if err := replaceMembers(tx.Bucket(archivedBucket), c.Archived); err != nil {
+		return err
+	}
+	for _, name := range [][]byte{
+		recentlyReadBucket, bookmarksBucket, bookSettingsBucket, comicsBucket, progressBucket,
+		readStatusBucket, ratingsBucket, notesBucket, bookTagsBucket, libraryBucket,
+	} {
+		if err := clearBucket(tx.Bucket(name)); err != nil {
+			return err
+		}
+	}
+	for _, entry := range c.RecentlyRead {
+		if err := putJSON(tx.Bucket(recentlyReadBucket), entry.BookID, entry); err != nil {
+			return err
+		}
+	}
+	for _, bookmark := range c.Bookmarks {
+		if err := putJSON(tx.Bucket(bookmarksBucket), bookmark.ID, bookmark); err != nil {
+			return err
+		}
+	}
+	if err := writeHistory(tx.Bucket(historyBucket), c.History); err != nil {
+		return err
+	}
+	if c.LibraryState != nil {
+		if err := putJSON(tx.Bucket(libraryBucket), libraryStateKey, c.LibraryState); err != nil {
+			return err
+		}
+	}
+	return c.putBookEntries(tx)
+}
+
+// putBookEntries stores the config's per-book maps in their buckets
+func (c *Config) putBookEntries(tx *bolt.Tx) error {
+	if err := putEntries(tx.Bucket(bookSettingsBucket), c.BookSettings); err != nil {
+		return err
+	}
+	if err := putEntries(tx.Bucket(comicsBucket), c.Comics); err != nil {
+		return err
+	}
+	if err := putEntries(tx.Bucket(progressBucket), c.Progress); err != nil {
+		return err
+	}
+	if err := putEntries(tx.Bucket(readStatusBucket), c.StatusOverrides); err != nil {
+		return err
+	}
+	if err := putEntries(tx.Bucket(ratingsBucket), c.Ratings); err != nil {
+		return err
+	}
+	if err := putEntries(tx.Bucket(notesBucket), c.Notes); err != nil {
+		return err
+	}
+	return putEntries(tx.Bucket(bookTagsBucket), c.BookTags)
+}
+
+// readState loads the config's lists from the database, leaving them as they
+// were if any entry can't be read
+func (c *Config) readState(tx *bolt.Tx) error {
+	recentlyReadByID, err := readEntries[RecentlyReadEntry](tx.Bucket(recentlyReadBucket))
+	if err != nil {
+		return err
+	}
+	recentlyRead := make([]RecentlyReadEntry, 0, len(recentlyReadByID))
+	for _, entry := range recentlyReadByID {
+		recentlyRead = append(recentlyRead, entry)
+	}
+	sort.SliceStable(recentlyRead, func(i, j int) bool {
+		return recentlyRead[i].OpenedAt.After(recentlyRead[j].OpenedAt)
+	})
+
+	bookmarksByID, err := readEntries[Bookmark](tx.Bucket(bookmarksBucket))
+	if err != nil {
+		return err
+	}
+	bookmarks := make([]Bookmark, 0, len(bookmarksByID))
+	for _, bookmark := range bookmarksByID {
+		bookmarks = append(bookmarks, bookmark)
+	}
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		if !bookmarks[i].CreatedAt.Equal(bookmarks[j].CreatedAt) {
+			return bookmarks[i].CreatedAt.Before(bookmarks[j].CreatedAt)
+		}
+		return bookmarks[i].ID < bookmarks[j].ID
+	})
+
+	history, err := readHistory(tx.Bucket(historyBucket))
+	if err != nil {
+		return err
+	}
+	var libraryState *LibraryState
+	if value := tx.Bucket(libraryBucket).Get([]byte(libraryStateKey)); value != nil {
+		libraryState = new(LibraryState)
+		if err := json.Unmarshal(value, libraryState); err != nil {
+			return err
+		}
+	}
+
+	bookSettings, err := readEntries[BookSettings](tx.Bucket(bookSettingsBucket))
+	if err != nil {
+		return err
+	}
+	comics, err := readEntries[ComicSettings](tx.Bucket(comicsBucket))
+	if err != nil {
+		return err
+	}
+	progress, err := readEntries[float64](tx.Bucket(progressBucket))
+	if err != nil {
+		return err
+	}
+	statusOverrides, err := readEntries[string](tx.Bucket(readStatusBucket))
+	if err != nil {
+		return err
+	}
+	ratings, err := readEntries[int](tx.Bucket(ratingsBucket))
+	if err != nil {
+		return err
+	}
+	notes, err := readEntries[string](tx.Bucket(notesBucket))
+	if err != nil {
+		return err
+	}
+	bookTags, err := readEntries[[]string](tx.Bucket(bookTagsBucket))
+	if err != nil {
+		return err
+	}
+
+	c.Favorites = readMembers(tx.Bucket(favoritesBucket))
+	c.ReadingQueue = readMembers(tx.Bucket(queueBucket))
+	c.Archived = readMembers(tx.Bucket(archivedBucket))
+	c.RecentlyRead = recentlyRead
+	c.Bookmarks = bookmarks
+	c.History = history
+	c.LibraryState = libraryState
+	c.BookSettings = bookSettings
+	c.Comics = comics
+	c.Progress = progress
+	c.StatusOverrides = statusOverrides
+	c.Ratings = ratings
+	c.Notes = notes
+	c.BookTags = bookTags
+	return nil
+}
+
+// saveState persists a change to the local state: just the entries update
+// touches when the state database is in use, otherwise the whole config. While
+// the database exists but can't be used the change is kept for this run only.
+func (c *Config) saveState(update func(tx *bolt.Tx) error) error {
+	if c.state == nil {
+		if c.stateErr != nil {
+			return c.stateErr
+		}
+		return c.Save()
+	}
+	return c.state.update(update)
+}
+
+// withoutState returns a copy of the config to save with the lists kept in the
+// state database left out
+func (c *Config) withoutState() *Config {
+	saved := *c
+	saved.Favorites = nil
+	saved.ReadingQueue = nil
+	saved.Archived = nil
+	saved.RecentlyRead = nil
+	saved.Bookmarks = nil
+	saved.History = nil
+	saved.LibraryState = nil
+	saved.BookSettings = nil
+	saved.Comics = nil
+	saved.Progress = nil
+	saved.StatusOverrides = nil
+	saved.Ratings = nil
+	saved.Notes = nil
+	saved.BookTags = nil
+	return &saved
+}
+
+// StateFile returns the state database's path, or "" while the local state is
+// kept in the config file
+func (c *Config) StateFile() string {
+	if c.state == nil {
+		return ""
+	}
+	return c.state.path
+}
+
+// setMembers adds ids to the end of a list bucket, or removes them
+func setMembers(bucket *bolt.Bucket, ids []string, member bool) error {
+	for _, id := range ids {
+		if !member {
+			if err := bucket.Delete([]byte(id)); err != nil {
+				return err
+			}
+			continue
+		}
+		if bucket.Get([]byte(id)) != nil {
+			continue
+		}
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put([]byte(id), encodeSeq(seq)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceMembers replaces a list bucket's contents with ids, in order
+func replaceMembers(bucket *bolt.Bucket, ids []string) error {
+	if err := clearBucket(bucket); err != nil {
+		return err
+	}
+	return setMembers(bucket, ids, true)
+}
+
+// swapMembers swaps the places of two ids in a list bucket
+func swapMembers(bucket *bolt.Bucket, a, b string) error {
+	seqA, seqB := bucket.Get([]byte(a)), bucket.Get([]byte(b))
+	if seqA == nil || seqB == nil {
+		return nil
+	}
+	seqA, seqB = append([]byte(nil), seqA...), append([]byte(nil), seqB...)
+	if err := bucket.Put([]byte(a), seqB); err != nil {
+		return err
+	}
+	return bucket.Put([]byte(b), seqA)
+}
+
+// readMembers returns a list bucket's ids in order
+func readMembers(bucket *bolt.Bucket) []string {
+	type member struct {
+		id  string
+		seq uint64
+	}
+	var members []member
+	_ = bucket.ForEach(func(key, value []byte) error {
+		members = append(members, member{id: string(key), seq: binary.BigEndian.Uint64(value)})
+		return nil
+	})
+	sort.Slice(members, func(i, j int) bool { return members[i].seq < members[j].seq })
+
+	var ids []string
+	for _, m := range members {
+		ids = append(ids, m.id)
+	}
+	return ids
+}
+
+// readHistory returns the reading sessions in a history bucket, oldest first
+func readHistory(bucket *bolt.Bucket) ([]ReadingSession, error) {
+	var history []ReadingSession
+	err := bucket.ForEach(func(_, value []byte) error {
+		var session ReadingSession
+		if err := json.Unmarshal(value, &session); err != nil {
+			return err
+		}
+		history = append(history, session)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].StartedAt.Before(history[j].StartedAt)
+	})
+	return history, nil
+}
+
+// writeHistory replaces a history bucket's sessions, keeping the latest
+// MaxHistory
+func writeHistory(bucket *bolt.Bucket, history []ReadingSession) error {
+	if err := clearBucket(bucket); err != nil {
+		return err
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].StartedAt.Before(history[j].StartedAt)
+	})
+	if len(history) > MaxHistory {
+		history = history[len(history)-MaxHistory:]
+	}
+	for _, session := range history {
+		if err := appendHistory(bucket, session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendHistory adds a session to the end of a history bucket
+func appendHistory(bucket *bolt.Bucket, session ReadingSession) error {
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(encodeSeq(seq), value)
+}
+
+// trimHistory drops the oldest sessions in a history bucket beyond MaxHistory
+func trimHistory(bucket *bolt.Bucket) error {
+	extra := bucket.Stats().KeyN - MaxHistory
+	var keys [][]byte
+	cursor := bucket.Cursor()
+	for key, _ := cursor.First(); key != nil && len(keys) < extra; key, _ = cursor.Next() {
+		keys = append(keys, append([]byte(nil), key...))
+	}
+	for _, key := range keys {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeSeq encodes a list place as a bucket value
+func encodeSeq(seq uint64) []byte {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, seq)
+	return value
+}
+
+// putJSON stores v as JSON under key
+func putJSON(bucket *bolt.Bucket, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(key), value)
+}
+
+// readEntries returns a bucket's JSON values by key
+func readEntries[V any](bucket *bolt.Bucket) (map[string]V, error) {
+	entries := make(map[string]V)
+	err := bucket.ForEach(func(key, value []byte) error {
+		var entry V
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return err
+		}
+		entries[string(key)] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// putEntries stores each value as JSON under its key
+func putEntries[V any](bucket *bolt.Bucket, entries map[string]V) error {
+	for key, value := range entries {
+		if err := putJSON(bucket, key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteKeys removes keys from a bucket
+func deleteKeys(bucket *bolt.Bucket, keys ...string) error {
+	for _, key := range keys {
+		if err := bucket.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearBucket removes every key from a bucket
+func clearBucket(bucket *bolt.Bucket) error {
+	var keys []string
+	_ = bucket.ForEach(func(key, _ []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	})
+	return deleteKeys(bucket, keys...)
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestConfig(t *testing.T, dir string) *Config {
+	t.Helper()
+	cfg := &Config{ServerURL: DefaultServerURL, path: filepath.Join(dir, "config.json")}
+	cfg.loadState()
+	if cfg.state == nil {
+		t.Fatalf("state database not opened: %v", cfg.stateErr)
+	}
+	return cfg
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(t, dir)
+
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	steps := []error{
+		cfg.ToggleFavorite("fav"),
+		cfg.SetArchived([]string{"old"}, true),
+		cfg.SetProgress("book", 0.5),
+		cfg.SetRating("book", 4),
+		cfg.SetNote("book", "a note"),
+		cfg.SetBookTags("book", []string{"scifi"}),
+		cfg.SetComicSettings("comic", ComicSettings{Rotation: 1}),
+		cfg.AddReadingSession(ReadingSession{BookID: "book", StartedAt: started, EndedAt: started.Add(time.Hour)}),
+		cfg.SetLibraryState(LibraryState{Page: 2, Cursor: 3}),
+		cfg.SetTheme("light"),
+	}
+	for i, err := range steps {
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(cfg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"favorites", "archived", "progress", "ratings", "notes", "book_tags", "comics", "history", "library_state"} {
+		if strings.Contains(string(data), `"`+key+`"`) {
+			t.Errorf("config file holds %s:\n%s", key, data)
+		}
+	}
+
+	loaded := newTestConfig(t, dir)
+	if !loaded.IsFavorite("fav") || !loaded.IsArchived("old") {
+		t.Errorf("lists = %v, %v", loaded.Favorites, loaded.Archived)
+	}
+	if fraction, _ := loaded.GetProgress("book"); fraction != 0.5 {
+		t.Errorf("progress = %v, want 0.5", fraction)
+	}
+	if loaded.GetRating("book") != 4 || loaded.GetNote("book") != "a note" {
+		t.Errorf("rating, note = %d, %q", loaded.GetRating("book"), loaded.GetNote("book"))
+	}
+	if !reflect.DeepEqual(loaded.GetBookTags("book"), []string{"scifi"}) {
+		t.Errorf("tags = %v", loaded.GetBookTags("book"))
+	}
+	if loaded.GetComicSettings("comic").Rotation != 1 {
+		t.Errorf("comic settings = %+v", loaded.GetComicSettings("comic"))
+	}
+	if len(loaded.History) != 1 || !loaded.History[0].StartedAt.Equal(started) {
+		t.Errorf("history = %+v", loaded.History)
+	}
+	if loaded.LibraryState == nil || loaded.LibraryState.Page != 2 {
+		t.Errorf("library state = %+v", loaded.LibraryState)
+	}
+}
+
+func TestLoadStateMergesFileLists(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(t, dir)
+	if err := cfg.ToggleFavorite("in-db"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetProgress("read", 0.25); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lists left in the file, e.g. by a version before the state database
+	fromFile := &Config{
+		ServerURL: DefaultServerURL,
+		path:      cfg.path,
+		Favorites: []string{"in-file"},
+		Progress:  map[string]float64{"other": 1},
+	}
+	fromFile.loadState()
+	if fromFile.state == nil {
+		t.Fatal("state database not opened")
+	}
+	if want := []string{"in-db", "in-file"}; !reflect.DeepEqual(fromFile.Favorites, want) {
+		t.Errorf("favorites = %v, want %v", fromFile.Favorites, want)
+	}
+	if want := map[string]float64{"read": 0.25, "other": 1}; !reflect.DeepEqual(fromFile.Progress, want) {
+		t.Errorf("progress = %v, want %v", fromFile.Progress, want)
+	}
+}
+
+func TestLockedStateIsNotSavedToFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(t, dir)
+	if err := cfg.ToggleFavorite("kept"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another instance holding the database
+	db, err := bolt.Open(cfg.state.path, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked := &Config{ServerURL: DefaultServerURL, path: cfg.path}
+	locked.loadState()
+	if locked.state != nil || locked.stateErr == nil {
+		t.Fatal("locked state database was used")
+	}
+	if err := locked.ToggleFavorite("lost"); err == nil {
+		t.Error("change saved while the state database is locked")
+	}
+	if err := locked.SetTheme("light"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(cfg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "favorites") {
+		t.Errorf("config file holds favorites:\n%s", data)
+	}
+	db.Close()
+
+	if loaded := newTestConfig(t, dir); !reflect.DeepEqual(loaded.Favorites, []string{"kept"}) {
+		t.Errorf("favorites = %v, want [kept]", loaded.Favorites)
+	}
+}
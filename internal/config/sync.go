@@ -0,0 +1,82 @@
+package config
+
+import (
+	"slices"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// SyncedLists is the favorites and reading queue as last agreed with a server.
+// It is the common base for three-way merges, so each side's additions and
+// removals since then can be told apart and combined without conflicts.
+type SyncedLists struct {
+	Server    string   `json:"server"`
+	Favorites []string `json:"favorites"`
+	Queue     []string `json:"queue"`
+}
+
+// SyncBase returns the lists last agreed with the current server, or empty lists
+// if there has been no sync with it
+func (c *Config) SyncBase() SyncedLists {
+	if c.LastSync == nil || c.LastSync.Server != c.ServerURL {
+		return SyncedLists{Server: c.ServerURL}
+	}
+	return *c.LastSync
+}
+
+// ApplySync replaces the favorites and queue with merged lists and saves
+func (c *Config) ApplySync(favorites, queue []string) error {
+	c.Favorites = favorites
+	c.ReadingQueue = queue
+	return c.saveState(func(tx *bolt.Tx) error {
+		if err := replaceMembers(tx.Bucket(favoritesBucket), favorites); err != nil {
+			return err
+		}
+		return replaceMembers(tx.Bucket(queueBucket), queue)
+	})
+}
+
+// MarkSynced records lists the server has accepted as the base for the next merge
+func (c *Config) MarkSynced(favorites, queue []string) error {
+	c.LastSync = &SyncedLists{Server: c.ServerURL, Favorites: favorites, Queue: queue}
+	return c.Save()
+}
+
+// MergeLists merges two edited copies of a list with their common base. If only one
+// side changed, including a reorder, its list wins. Otherwise the result is the remote
+// list, minus items removed locally, plus items added locally in local order, so both
+// sides' additions and removals survive.
+func MergeLists(base, local, remote []string) []string {
+	if slices.Equal(remote, base) {
+		return slices.Clone(local)
+	}
+	if slices.Equal(local, base) {
+		return slices.Clone(remote)
+	}
+
+	inBase := make(map[string]bool, len(base))
+	for _, id := range base {
+		inBase[id] = true
+	}
+	inLocal := make(map[string]bool, len(local))
+	for _, id := range local {
+		inLocal[id] = true
+	}
+
+	merged := make([]string, 0, len(remote)+len(local))
+	seen := make(map[string]bool)
+	for _, id := range remote {
+		if seen[id] || (inBase[id] && !inLocal[id]) {
+			continue // Duplicate or removed locally
+		}
+		seen[id] = true
+		merged = append(merged, id)
+	}
+	for _, id := range local {
+		if !seen[id] && !inBase[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
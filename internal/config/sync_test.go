@@ -0,0 +1,33 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeLists(t *testing.T) {
+	tests := []struct {
+		name                string
+		base, local, remote []string
+		want                []string
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, []string{"a", "b"}, []string{"a", "b"}},
+		{"local reorder wins", []string{"a", "b"}, []string{"b", "a"}, []string{"a", "b"}, []string{"b", "a"}},
+		{"remote reorder wins", []string{"a", "b"}, []string{"a", "b"}, []string{"b", "a"}, []string{"b", "a"}},
+		{"both add", []string{"a"}, []string{"a", "l"}, []string{"a", "r"}, []string{"a", "r", "l"}},
+		{"local removes, remote adds", []string{"a", "b"}, []string{"b"}, []string{"a", "b", "r"}, []string{"b", "r"}},
+		{"remote removes, local adds", []string{"a", "b"}, []string{"a", "b", "l"}, []string{"b"}, []string{"b", "l"}},
+		{"both add the same", []string{"a"}, []string{"a", "x"}, []string{"x", "a"}, []string{"x", "a"}},
+		{"first sync", nil, []string{"l", "s"}, []string{"s", "r"}, []string{"s", "r", "l"}},
+	}
+	for _, tt := range tests {
+		got := MergeLists(tt.base, tt.local, tt.remote)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: MergeLists(%v, %v, %v) = %v, want %v", tt.name, tt.base, tt.local, tt.remote, got, tt.want)
+		}
+		// Merging the other way round keeps the same members
+		if other := MergeLists(tt.base, tt.remote, tt.local); len(other) != len(got) {
+			t.Errorf("%s: swapped sides = %v, want the members of %v", tt.name, other, got)
+		}
+	}
+}
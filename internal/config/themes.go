@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// themesDirName is the directory next to the config file holding one theme per
+// JSON, TOML or YAML file
+const themesDirName = "themes.d"
+
+// CustomTheme is a user-defined color theme. Colors are hex ("#7C3AED") or
+// ANSI numbers ("241"); any left out come from the Base theme.
+type CustomTheme struct {
+	Name           string `json:"name"`
+	Description    string `json:"description,omitempty"`
+	Base           string `json:"base,omitempty"` // Theme the unset colors come from (default dark)
+	Primary        string `json:"primary,omitempty"`
+	Secondary      string `json:"secondary,omitempty"`
+	Background     string `json:"background,omitempty"`
+	Foreground     string `json:"foreground,omitempty"`
+	Success        string `json:"success,omitempty"`
+	Warning        string `json:"warning,omitempty"`
+	Error          string `json:"error,omitempty"`
+	Muted          string `json:"muted,omitempty"`
+	Border         string `json:"border,omitempty"`
+	Selection      string `json:"selection,omitempty"`
+	SelectionText  string `json:"selection_text,omitempty"`
+	BadgeBook      string `json:"badge_book,omitempty"`
+	BadgeBookText  string `json:"badge_book_text,omitempty"`
+	BadgeComic     string `json:"badge_comic,omitempty"`
+	BadgeComicText string `json:"badge_comic_text,omitempty"`
+}
+
+// colors returns the theme's colors by JSON name
+func (t CustomTheme) colors() map[string]string {
+	return map[string]string{
+		"primary":          t.Primary,
+		"secondary":        t.Secondary,
+		"background":       t.Background,
+		"foreground":       t.Foreground,
+		"success":          t.Success,
+		"warning":          t.Warning,
+		"error":            t.Error,
+		"muted":            t.Muted,
+		"border":           t.Border,
+		"selection":        t.Selection,
+		"selection_text":   t.SelectionText,
+		"badge_book":       t.BadgeBook,
+		"badge_book_text":  t.BadgeBookText,
+		"badge_comic":      t.BadgeComic,
+		"badge_comic_text": t.BadgeComicText,
+	}
+}
+
+// validate checks the theme has a name and usable colors
+func (t CustomTheme) validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("theme has no name")
+	}
+	colors := t.colors()
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if color := colors[name]; color != "" && !validColor(color) {
+			return fmt.Errorf("theme %q: %s %q is not a hex color or ANSI number (0-255)", t.Name, name, color)
+		}
+	}
+	return nil
+}
+
+// validColor reports whether color is #RGB, #RRGGBB or an ANSI color number
+func validColor(color string) bool {
+	if hex, ok := strings.CutPrefix(color, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ThemesDir returns the directory user theme files are read from
+func (c *Config) ThemesDir() string {
+	return filepath.Join(filepath.Dir(c.path), themesDirName)
+}
+
+// GetCustomThemes returns the user's themes: those in the themes directory, in
+// file name order, then those in the config, which win on a name clash. Themes
+// that can't be read or are invalid are left out and reported in the error.
+func (c *Config) GetCustomThemes() ([]CustomTheme, error) {
+	var themes []CustomTheme
+	var errs []error
+
+	files, err := filepath.Glob(filepath.Join(c.ThemesDir(), "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if !isConfigFile(file) {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var theme CustomTheme
+		if err := decodeFile(file, data, &theme); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(file), err))
+			continue
+		}
+		if theme.Name == "" {
+			theme.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		themes = append(themes, theme)
+	}
+	themes = append(themes, c.Themes...)
+
+	kept := themes[:0]
+	index := make(map[string]int)
+	for _, theme := range themes {
+		if err := theme.validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i, ok := index[theme.Name]; ok {
+			kept[i] = theme
+			continue
+		}
+		index[theme.Name] = len(kept)
+		kept = append(kept, theme)
+	}
+	return kept, errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// saveMu serializes saves within this process; lockConfig does the same across
+// running instances
+var saveMu sync.Mutex
+
+// lockConfig blocks until it holds the advisory lock on the config file shared
+// by every running instance, and returns a function that releases it
+func lockConfig(path string) (func(), error) {
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it into
+// place, so a crash or another instance reading mid-write never sees a partial
+// file. A symlinked config keeps its link; the file it points to is replaced.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Gone already once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,69 @@
+// Package pdf rasterizes PDF pages to images using poppler's command-line tools
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultDPI is the resolution pages are rendered at, comparable to a comic scan
+const DefaultDPI = 150
+
+// ErrNoRasterizer is returned when pdftoppm/pdfinfo are not installed
+var ErrNoRasterizer = errors.New("PDF viewing needs pdftoppm and pdfinfo (poppler-utils) installed")
+
+// Available reports whether the poppler tools are on PATH
+func Available() bool {
+	for _, tool := range []string{"pdftoppm", "pdfinfo"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// PageCount returns the number of pages in the PDF at path
+func PageCount(path string) (int, error) {
+	if !Available() {
+		return 0, ErrNoRasterizer
+	}
+	out, err := run("pdfinfo", path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if value, ok := strings.CutPrefix(line, "Pages:"); ok {
+			return strconv.Atoi(strings.TrimSpace(value))
+		}
+	}
+	return 0, fmt.Errorf("pdfinfo: no page count for %s", path)
+}
+
+// RenderPage renders one page (1-indexed) of the PDF at path as PNG data
+func RenderPage(path string, page, dpi int) ([]byte, error) {
+	if !Available() {
+		return nil, ErrNoRasterizer
+	}
+	n := strconv.Itoa(page)
+	// Without an output root, pdftoppm writes the single page to stdout
+	return run("pdftoppm", "-f", n, "-l", n, "-r", strconv.Itoa(dpi), "-png", "-singlefile", path)
+}
+
+// run executes a tool, including its stderr in any error
+func run(name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", name, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,267 @@
+// Package script runs batches of webby actions without the TUI, for cron jobs
+// and other tooling. Scripts are JSON or YAML; each step prints one JSON result line.
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported step actions
+const (
+	ActionLogin           = "login"
+	ActionSearch          = "search"
+	ActionOpen            = "open"
+	ActionExportBookmarks = "export_bookmarks"
+)
+
+// Step is a single scripted action
+type Step struct {
+	Action      string `json:"action"`
+	Username    string `json:"username,omitempty"`     // login
+	Password    string `json:"password,omitempty"`     // login
+	PasswordEnv string `json:"password_env,omitempty"` // login: read password from this env var
+	Query       string `json:"query,omitempty"`        // search
+	Limit       int    `json:"limit,omitempty"`        // search, default 20
+	Book        string `json:"book,omitempty"`         // open: book ID, or title from the last search
+	Chapter     *int   `json:"chapter,omitempty"`      // open: include this chapter's text
+	Format      string `json:"format,omitempty"`       // export_bookmarks: json or csv
+	Path        string `json:"path,omitempty"`         // export_bookmarks: output file (omit to inline)
+}
+
+// Result is printed as one JSON line per executed step
+type Result struct {
+	Step   int         `json:"step"`
+	Action string      `json:"action"`
+	OK     bool        `json:"ok"`
+	Error  string      `json:"error,omitempty"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// Parse reads a script, either {"steps": [...]} or a bare array of steps, in
+// JSON or YAML
+func Parse(r io.Reader) ([]Step, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '[' && data[0] != '{' {
+		// YAML: convert to JSON so steps are read with the same keys
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid script: %w", err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("invalid script: %w", err)
+		}
+	}
+
+	var steps []Step
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &steps)
+	} else {
+		var doc struct {
+			Steps []Step `json:"steps"`
+		}
+		err = json.Unmarshal(data, &doc)
+		steps = doc.Steps
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("script has no steps")
+	}
+	return steps, nil
+}
+
+// Runner executes script steps against the configured server
+type Runner struct {
+	cfg    *config.Config
+	client *api.Client
+	out    io.Writer
+
+	lastBooks []models.Book // Results of the most recent search, for opening by title
+}
+
+// NewRunner creates a runner using the config's server and saved token
+func NewRunner(cfg *config.Config, out io.Writer) (*Runner, error) {
+	client, err := cfg.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		cfg:    cfg,
+		client: client,
+		out:    out,
+	}, nil
+}
+
+// Run executes steps in order, stopping at the first failure
+func (r *Runner) Run(steps []Step) error {
+	enc := json.NewEncoder(r.out)
+	for i, step := range steps {
+		result, err := r.runStep(step)
+		line := Result{Step: i + 1, Action: step.Action, OK: err == nil, Result: result}
+		if err != nil {
+			line.Error = err.Error()
+		}
+		if encErr := enc.Encode(line); encErr != nil {
+			return encErr
+		}
+		if err != nil {
+			return fmt.Errorf("step %d (%s) failed: %w", i+1, step.Action, err)
+		}
+	}
+	return nil
+}
+
+// runStep dispatches a single step
+func (r *Runner) runStep(step Step) (interface{}, error) {
+	switch step.Action {
+	case ActionLogin:
+		return r.login(step)
+	case ActionSearch:
+		return r.search(step)
+	case ActionOpen:
+		return r.open(step)
+	case ActionExportBookmarks:
+		return r.exportBookmarks(step)
+	default:
+		return nil, fmt.Errorf("unknown action %q", step.Action)
+	}
+}
+
+// login authenticates for the rest of the script (the token is not saved to config)
+func (r *Runner) login(step Step) (interface{}, error) {
+	password := step.Password
+	if step.PasswordEnv != "" {
+		password = os.Getenv(step.PasswordEnv)
+	}
+	if step.Username == "" || password == "" {
+		return nil, fmt.Errorf("login requires username and password")
+	}
+
+	auth, err := r.client.Login(step.Username, password)
+	if err != nil {
+		return nil, err
+	}
+	r.client.SetToken(auth.Token)
+	return auth.User, nil
+}
+
+// search lists books matching a query
+func (r *Runner) search(step Step) (interface{}, error) {
+	limit := step.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	resp, err := r.client.ListBooks(1, limit, "title", "asc", step.Query, "")
+	if err != nil {
+		return nil, err
+	}
+	r.lastBooks = resp.Books
+	return resp, nil
+}
+
+// openResult describes an opened book
+type openResult struct {
+	Book     *models.Book            `json:"book"`
+	Chapters []models.Chapter        `json:"chapters,omitempty"`
+	Position *models.ReadingPosition `json:"position,omitempty"`
+	Content  *models.ChapterContent  `json:"content,omitempty"`
+}
+
+// open fetches a book's details, TOC, reading position and optionally a chapter
+func (r *Runner) open(step Step) (interface{}, error) {
+	book, err := r.resolveBook(step.Book)
+	if err != nil {
+		return nil, err
+	}
+
+	result := openResult{Book: book}
+	if book.IsCBZ() {
+		return result, nil
+	}
+
+	toc, err := r.client.GetTOC(book.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.Chapters = toc.Chapters
+
+	// A missing position just means the book hasn't been read yet
+	if pos, err := r.client.GetPosition(book.ID); err == nil {
+		result.Position = pos
+	}
+
+	if step.Chapter != nil {
+		content, err := r.client.GetChapterText(book.ID, *step.Chapter)
+		if err != nil {
+			return nil, err
+		}
+		result.Content = content
+	}
+	return result, nil
+}
+
+// resolveBook finds a book by title in the last search results, falling back to an ID lookup
+func (r *Runner) resolveBook(ref string) (*models.Book, error) {
+	if ref == "" {
+		if len(r.lastBooks) == 0 {
+			return nil, fmt.Errorf("open requires a book ID or a preceding search")
+		}
+		return &r.lastBooks[0], nil
+	}
+	for i, b := range r.lastBooks {
+		if b.ID == ref || strings.EqualFold(b.Title, ref) {
+			return &r.lastBooks[i], nil
+		}
+	}
+	return r.client.GetBook(ref)
+}
+
+// exportBookmarks writes bookmarks to a file, or inlines them in the result
+func (r *Runner) exportBookmarks(step Step) (interface{}, error) {
+	format := step.Format
+	if format == "" {
+		format = config.BookmarkFormatForPath(step.Path)
+	}
+
+	if step.Path == "" {
+		if format == config.BookmarkFormatJSON {
+			return r.cfg.GetBookmarks(), nil
+		}
+		var buf bytes.Buffer
+		if err := r.cfg.ExportBookmarks(&buf, format); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+
+	file, err := os.Create(step.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cfg.ExportBookmarks(file, format); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"path":  step.Path,
+		"count": len(r.cfg.GetBookmarks()),
+	}, nil
+}
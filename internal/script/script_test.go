@@ -0,0 +1,50 @@
+package script
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	chapter := 2
+	want := []Step{
+		{Action: ActionSearch, Query: "dune", Limit: 5},
+		{Action: ActionOpen, Book: "Dune", Chapter: &chapter},
+	}
+	scripts := map[string]string{
+		"json array": `[{"action":"search","query":"dune","limit":5},{"action":"open","book":"Dune","chapter":2}]`,
+		"json steps": `{"steps":[{"action":"search","query":"dune","limit":5},{"action":"open","book":"Dune","chapter":2}]}`,
+		"yaml list": `
+- action: search
+  query: dune
+  limit: 5
+- action: open
+  book: Dune
+  chapter: 2
+`,
+		"yaml steps": `
+steps:
+  - {action: search, query: dune, limit: 5}
+  - {action: open, book: Dune, chapter: 2}
+`,
+	}
+	for name, script := range scripts {
+		steps, err := Parse(strings.NewReader(script))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(steps, want) {
+			t.Errorf("%s: steps = %+v, want %+v", name, steps, want)
+		}
+	}
+}
+
+func TestParseRejectsEmptyScripts(t *testing.T) {
+	for _, script := range []string{"", "[]", "steps: []", "{}"} {
+		if _, err := Parse(strings.NewReader(script)); err == nil {
+			t.Errorf("Parse(%q) succeeded", script)
+		}
+	}
+}
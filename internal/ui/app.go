@@ -1,22 +1,44 @@
 package ui
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/cache"
 	"github.com/justyntemme/webby-t/internal/config"
 	"github.com/justyntemme/webby-t/internal/ui/styles"
 	"github.com/justyntemme/webby-t/internal/ui/terminal"
 	"github.com/justyntemme/webby-t/internal/ui/views"
+	"github.com/justyntemme/webby-t/internal/update"
 	"github.com/justyntemme/webby-t/pkg/models"
 )
 
+// autoThemeInterval is how often the day/night theme is re-checked
+// (lowPowerThemeInterval in low-power mode)
+const (
+	autoThemeInterval     = time.Minute
+	lowPowerThemeInterval = 10 * time.Minute
+)
+
+// autoThemeMsg triggers a check of the automatic day/night theme
+type autoThemeMsg struct{}
+
+// updateCheckedMsg carries the result of the startup update check
+type updateCheckedMsg struct {
+	release *update.Release
+	err     error
+}
+
 // App is the main application model
 type App struct {
-	config *config.Config
-	client *api.Client
-	keys   KeyMap
+	config   *config.Config
+	client   *api.Client
+	keys     KeyMap
+	bindings *Bindings // Keys remapped in the config
 
 	// Current view state
 	currentView views.ViewType
@@ -37,67 +59,192 @@ type App struct {
 	uploadView      views.View
 	comicView       views.View
 	bookDetailsView views.View
+	historyView     views.View
+	homeView        views.View
+	settingsView    views.View
+	profilesView    views.View
 
 	// Error/status message
 	err       error
 	statusMsg string
 	showHelp  bool
+
+	themeCheckInterval time.Duration // How often the auto day/night theme is re-checked
+
+	// Favorites and queue sync with the server
+	syncing         bool
+	syncUnsupported bool // The server has no user data store
+
+	// The server can't be reached, see connectivity.go
+	offline bool
+
+	// View to reopen after logging in again (ViewLogin = none), see auth.go
+	resumeView views.ViewType
+	resumeUser string // Whose session expired; another user starts afresh
 }
 
 // NewApp creates a new application instance
 func NewApp(cfg *config.Config) *App {
-	client := api.NewClient(cfg.ServerURL, cfg.Token)
+	client, connErr := cfg.NewClient()
 
-	// Apply saved theme from config
-	styles.SetCurrentTheme(cfg.GetThemeName())
+	bindings, err := NewBindings(DefaultKeyMap(), cfg.KeyPreset, cfg.Keys)
+	if err != nil && connErr == nil {
+		connErr = fmt.Errorf("key bindings: %w", err)
+	}
+
+	if err := registerThemes(cfg); err != nil && connErr == nil {
+		connErr = fmt.Errorf("themes: %w", err)
+	}
+
+	// Apply saved theme from config (or the day/night theme for the current time)
+	styles.SetCurrentTheme(cfg.ActiveThemeName(time.Now()))
+	styles.SetSearchHighlight(cfg.SearchHighlight, cfg.SearchMatchColor, cfg.SearchCurrentColor)
+	terminal.SetSixelOptions(cfg.SixelPalette, cfg.SixelColors, cfg.SixelDither)
 
 	app := &App{
-		config:      cfg,
-		client:      client,
-		keys:        DefaultKeyMap(),
-		currentView: views.ViewLogin,
-		width:       80,
-		height:      24,
+		config:             cfg,
+		client:             client,
+		keys:               DefaultKeyMap(),
+		bindings:           bindings,
+		currentView:        views.ViewLogin,
+		width:              80,
+		height:             24,
+		themeCheckInterval: autoThemeInterval,
+		err:                connErr,
+	}
+
+	// Low-power and e-ink modes: static cursors and fewer background refreshes
+	views.LowPower = cfg.ReduceRedraws()
+	if cfg.ReduceRedraws() {
+		app.themeCheckInterval = lowPowerThemeInterval
 	}
 
 	// Initialize views
 	app.loginView = views.NewLoginView(client, cfg)
 	app.libraryView = views.NewLibraryView(client, cfg)
 	app.readerView = views.NewReaderView(client, cfg)
-	app.collectionsView = views.NewCollectionsView(client)
-	app.uploadView = views.NewUploadView(client)
-	app.comicView = views.NewComicView(client)
+	app.collectionsView = views.NewCollectionsView(client, cfg)
+	app.uploadView = views.NewUploadView(client, cfg)
+	app.comicView = views.NewComicView(client, cfg, newPageCache(cfg))
 	app.bookDetailsView = views.NewBookDetailsView(client, cfg)
+	app.historyView = views.NewHistoryView(client, cfg)
+	app.homeView = views.NewHomeView(client, cfg)
+	app.settingsView = views.NewSettingsView(client, cfg)
+	app.profilesView = views.NewProfilesView(cfg)
 
-	// If already authenticated, go to library
+	// If already authenticated, go to the home dashboard (or straight to the library)
 	if cfg.IsAuthenticated() {
-		app.currentView = views.ViewLibrary
+		app.currentView = app.startView()
 	}
 
 	return app
 }
 
+// newPageCache creates the on-disk comic page cache, or nil if no cache dir is available
+func newPageCache(cfg *config.Config) *cache.DiskCache {
+	dir, err := cache.PageDir()
+	if err != nil {
+		return nil
+	}
+	return cache.NewDiskCache(dir, cfg.GetPageCacheBytes())
+}
+
 // Init implements tea.Model
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		a.getCurrentView().Init(),
 		tea.SetWindowTitle("webby-t"),
-	)
+		a.autoThemeTick(),
+	}
+	if a.config.UpdateCheckDue(time.Now()) {
+		cmds = append(cmds, checkForUpdate)
+	}
+	cmds = append(cmds, a.loadServerInfo(), a.startSync(), a.waitForAuthEvent(), a.waitForConnectivity(), a.waitForBusy(), a.subscribeEvents())
+	return tea.Batch(cmds...)
+}
+
+// checkForUpdate looks up the latest release in the background
+func checkForUpdate() tea.Msg {
+	release, err := update.Latest()
+	return updateCheckedMsg{release: release, err: err}
+}
+
+// handleUpdateChecked shows a notice when a newer release exists.
+// Failures are silent; the check is retried on the next start.
+func (a *App) handleUpdateChecked(msg updateCheckedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return a, nil
+	}
+	_ = a.config.MarkUpdateChecked(time.Now())
+	if update.IsNewer(update.Version, msg.release.TagName) {
+		a.statusMsg = fmt.Sprintf("Update available: %s (current %s) - run 'webby-t version --check' for details",
+			msg.release.TagName, update.Version)
+	}
+	return a, nil
+}
+
+// autoThemeTick schedules the next day/night theme check
+func (a *App) autoThemeTick() tea.Cmd {
+	return tea.Tick(a.themeCheckInterval, func(time.Time) tea.Msg {
+		return autoThemeMsg{}
+	})
+}
+
+// handleAutoTheme switches to the day or night theme when the hour crosses sunrise/sunset
+func (a *App) handleAutoTheme() (tea.Model, tea.Cmd) {
+	if !a.config.AutoTheme {
+		return a, a.autoThemeTick()
+	}
+	name := a.config.ActiveThemeName(time.Now())
+	if name == styles.CurrentTheme().Name {
+		return a, a.autoThemeTick()
+	}
+	styles.SetCurrentTheme(name)
+	return a, tea.Batch(a.autoThemeTick(), views.NotifyThemeChanged(name))
 }
 
 // Update implements tea.Model - dispatches to focused handlers
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		translated, bound := a.translateKey(keyMsg)
+		if !bound {
+			return a, nil
+		}
+		msg = translated
+	}
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.handleWindowSize(msg)
+		if a.currentView == views.ViewComic {
+			// The comic page is re-rendered in the background for the new size
+			return a.delegateToView(msg)
+		}
 		return a, nil
 	case tea.KeyMsg:
 		if model, cmd := a.handleKeyMsg(msg); cmd != nil || model != a {
 			return model, cmd
 		}
 	case views.LoginSuccessMsg, views.LogoutMsg, views.OpenBookMsg,
-		views.ShowBookDetailsMsg, views.SwitchViewMsg, views.ErrorMsg, views.ClearErrorMsg:
+		views.ShowBookDetailsMsg, views.ApplySmartCollectionMsg, views.SwitchViewMsg, views.SwitchProfileMsg, views.ErrorMsg, views.ClearErrorMsg:
 		return a.handleAppMsg(msg)
+	case autoThemeMsg:
+		return a.handleAutoTheme()
+	case updateCheckedMsg:
+		return a.handleUpdateChecked(msg)
+	case syncFetchedMsg:
+		return a.handleSyncFetched(msg)
+	case syncPushedMsg:
+		return a.handleSyncPushed(msg)
+	case authEventMsg:
+		return a.handleAuthEvent(msg)
+	case serverEventMsg:
+		return a.handleServerEvent(msg)
+	case connectivityMsg:
+		return a.handleConnectivity(msg)
+	case busyMsg:
+		return a.handleBusy(msg)
+	case busyClearedMsg:
+		return a.handleBusyCleared(msg)
 	}
 	return a.delegateToView(msg)
 }
@@ -113,15 +260,39 @@ func (a *App) handleWindowSize(msg tea.WindowSizeMsg) {
 	a.uploadView.SetSize(msg.Width, msg.Height)
 	a.comicView.SetSize(msg.Width, msg.Height)
 	a.bookDetailsView.SetSize(msg.Width, msg.Height)
+	a.historyView.SetSize(msg.Width, msg.Height)
+	a.homeView.SetSize(msg.Width, msg.Height)
+	a.settingsView.SetSize(msg.Width, msg.Height)
+	a.profilesView.SetSize(msg.Width, msg.Height)
+}
+
+// translateKey applies the config's key remaps, except to keys typed into the
+// login form or a prompt. Returns false for a key unbound in the current view.
+func (a *App) translateKey(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if a.currentView == views.ViewLogin || a.currentView == views.ViewRegister {
+		return msg, true
+	}
+	if capturer, ok := a.activeView().(views.KeyCapturer); ok && capturer.CapturingKeys() {
+		return msg, true
+	}
+	return a.bindings.Translate(a.currentView, msg)
 }
 
 // handleKeyMsg processes global keybindings
 func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if capturer, ok := a.activeView().(views.KeyCapturer); ok && capturer.CapturingKeys() && msg.String() != "ctrl+c" {
+		return a, nil
+	}
+	if handler, ok := a.activeView().(views.BackHandler); ok && handler.HandlingBack() && !a.showHelp &&
+		key.Matches(msg, a.keys.Escape, a.keys.Quit) && msg.String() != "ctrl+c" {
+		return a, nil
+	}
 	switch {
 	case key.Matches(msg, a.keys.Quit):
 		if a.currentView == views.ViewReader || a.currentView == views.ViewComic {
 			return a.switchView(views.ViewLibrary)
 		}
+		_ = a.libraryView.(*views.LibraryView).SaveState()
 		return a, tea.Quit
 	case key.Matches(msg, a.keys.Help):
 		a.showHelp = !a.showHelp
@@ -145,6 +316,7 @@ func (a *App) handleEscapeKey() (tea.Model, tea.Cmd) {
 		views.ViewUpload:      views.ViewLibrary,
 		views.ViewComic:       views.ViewLibrary,
 		views.ViewBookDetails: views.ViewLibrary,
+		views.ViewHistory:     views.ViewLibrary,
 	}
 	if dest, ok := backMap[a.currentView]; ok {
 		return a.switchView(dest)
@@ -158,14 +330,23 @@ func (a *App) handleAppMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case views.LoginSuccessMsg:
 		a.user = &msg.User
 		a.config.Username = msg.User.Username
-		return a.switchView(views.ViewLibrary)
+		if a.resumeView != views.ViewLogin && msg.User.Username == a.resumeUser {
+			return a.resumeSession()
+		}
+		a.resumeView = views.ViewLogin
+		return a.switchView(a.startView())
 	case views.LogoutMsg:
 		a.user = nil
+		a.resumeView = views.ViewLogin
 		a.config.ClearToken()
 		return a.switchView(views.ViewLogin)
 	case views.OpenBookMsg:
+		if err := a.unsupportedBook(msg.Book); err != nil {
+			a.err = err
+			return a, nil
+		}
 		_ = a.config.AddRecentlyRead(msg.Book.ID, msg.Book.Title)
-		if msg.Book.IsCBZ() {
+		if msg.Book.IsCBZ() || msg.Book.IsPDF() {
 			a.comicView.(*views.ComicView).SetBook(msg.Book)
 			return a.switchView(views.ViewComic)
 		}
@@ -174,6 +355,9 @@ func (a *App) handleAppMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case views.ShowBookDetailsMsg:
 		a.bookDetailsView.(*views.BookDetailsView).SetBook(msg.Book)
 		return a.switchView(views.ViewBookDetails)
+	case views.ApplySmartCollectionMsg:
+		a.libraryView.(*views.LibraryView).ApplySmartCollection(msg.Collection)
+		return a.switchView(views.ViewLibrary)
 	case views.ErrorMsg:
 		a.err = msg.Err
 		return a, nil
@@ -182,10 +366,41 @@ func (a *App) handleAppMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 	case views.SwitchViewMsg:
 		return a.switchView(msg.View)
+	case views.SwitchProfileMsg:
+		return a.switchProfile(msg.Name)
 	}
 	return a, nil
 }
 
+// activeView returns the view that receives input for the current view type
+func (a *App) activeView() views.View {
+	switch a.currentView {
+	case views.ViewLogin, views.ViewRegister:
+		return a.loginView
+	case views.ViewLibrary:
+		return a.libraryView
+	case views.ViewReader, views.ViewTOC:
+		return a.readerView
+	case views.ViewCollections:
+		return a.collectionsView
+	case views.ViewUpload:
+		return a.uploadView
+	case views.ViewComic:
+		return a.comicView
+	case views.ViewBookDetails:
+		return a.bookDetailsView
+	case views.ViewHistory:
+		return a.historyView
+	case views.ViewHome:
+		return a.homeView
+	case views.ViewSettings:
+		return a.settingsView
+	case views.ViewProfiles:
+		return a.profilesView
+	}
+	return nil
+}
+
 // delegateToView passes messages to the current view
 func (a *App) delegateToView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -204,6 +419,14 @@ func (a *App) delegateToView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.comicView, cmd = a.comicView.Update(msg)
 	case views.ViewBookDetails:
 		a.bookDetailsView, cmd = a.bookDetailsView.Update(msg)
+	case views.ViewHistory:
+		a.historyView, cmd = a.historyView.Update(msg)
+	case views.ViewHome:
+		a.homeView, cmd = a.homeView.Update(msg)
+	case views.ViewSettings:
+		a.settingsView, cmd = a.settingsView.Update(msg)
+	case views.ViewProfiles:
+		a.profilesView, cmd = a.profilesView.Update(msg)
 	}
 	return a, cmd
 }
@@ -227,14 +450,27 @@ func (a *App) View() string {
 		content = a.comicView.View()
 	case views.ViewBookDetails:
 		content = a.bookDetailsView.View()
+	case views.ViewHistory:
+		content = a.historyView.View()
+	case views.ViewHome:
+		content = a.homeView.View()
+	case views.ViewSettings:
+		content = a.settingsView.View()
+	case views.ViewProfiles:
+		content = a.profilesView.View()
 	default:
 		content = "Unknown view"
 	}
 
-	// Add error bar if there's an error
+	// Add error bar if there's an error, otherwise any notice
 	if a.err != nil {
 		errorBar := styles.ErrorStyle.Render("Error: " + a.err.Error())
 		content = lipgloss.JoinVertical(lipgloss.Left, content, errorBar)
+	} else if a.statusMsg != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, styles.SuccessStyle.Render(a.statusMsg))
+	}
+	if a.offline {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, styles.WarningStyle.Render(offlineNotice))
 	}
 
 	// Add help overlay if shown
@@ -245,11 +481,41 @@ func (a *App) View() string {
 	return content
 }
 
+// startView returns the view shown after login: the home dashboard, unless the
+// config asks to open the library directly
+func (a *App) startView() views.ViewType {
+	if a.config.StartInLibrary {
+		return views.ViewLibrary
+	}
+	return views.ViewHome
+}
+
 // switchView changes the current view and initializes it
 func (a *App) switchView(view views.ViewType) (*App, tea.Cmd) {
-	// Save position when leaving the reader
+	if err := a.unsupportedView(view); err != nil {
+		a.err = err
+		return a, nil
+	}
+
+	// Save position and log the reading session when leaving the reader or comic viewer
+	var saveErr error
 	if a.currentView == views.ViewReader || a.currentView == views.ViewTOC {
-		a.readerView.(*views.ReaderView).SavePositionOnExit()
+		readerView := a.readerView.(*views.ReaderView)
+		if err := readerView.SavePositionOnExit(); err != nil {
+			saveErr = fmt.Errorf("failed to save position: %w", err)
+		}
+		readerView.EndSession()
+		if view != views.ViewReader && view != views.ViewTOC {
+			readerView.StopLoading()
+		}
+	} else if a.currentView == views.ViewComic {
+		comicView := a.comicView.(*views.ComicView)
+		if err := comicView.SavePositionOnExit(); err != nil {
+			saveErr = fmt.Errorf("failed to save position: %w", err)
+		}
+		comicView.EndSession()
+	} else if a.currentView == views.ViewLibrary {
+		_ = a.libraryView.(*views.LibraryView).SaveState()
 	}
 
 	// Clear terminal images when leaving views that display them
@@ -266,8 +532,13 @@ func (a *App) switchView(view views.ViewType) (*App, tea.Cmd) {
 
 	a.prevView = a.currentView
 	a.currentView = view
-	a.err = nil
+	a.err = saveErr
+	a.statusMsg = ""
 
+	// Pick up favorites and queue changes from other machines when returning to the library
+	if view == views.ViewLibrary {
+		return a, tea.Batch(a.getCurrentView().Init(), a.startSync())
+	}
 	return a, a.getCurrentView().Init()
 }
 
@@ -288,56 +559,15 @@ func (a *App) getCurrentView() views.View {
 		return a.comicView
 	case views.ViewBookDetails:
 		return a.bookDetailsView
+	case views.ViewHistory:
+		return a.historyView
+	case views.ViewHome:
+		return a.homeView
+	case views.ViewSettings:
+		return a.settingsView
+	case views.ViewProfiles:
+		return a.profilesView
 	default:
 		return a.loginView
 	}
 }
-
-// renderHelp renders the help overlay
-func (a *App) renderHelp() string {
-	help := styles.Dialog.Width(60).Render(
-		styles.DialogTitle.Render("Keyboard Shortcuts") + "\n\n" +
-			styles.HelpKey.Render("Navigation") + "\n" +
-			"  j/↓     Move down\n" +
-			"  k/↑     Move up\n" +
-			"  g       Go to top\n" +
-			"  G       Go to bottom\n" +
-			"  Ctrl+d  Page down\n" +
-			"  Ctrl+u  Page up\n\n" +
-			styles.HelpKey.Render("Reader") + "\n" +
-			"  n/l     Next chapter\n" +
-			"  p/h     Previous chapter\n" +
-			"  t       Table of contents\n" +
-			"  B       Add bookmark\n" +
-			"  b       View bookmarks\n\n" +
-			styles.HelpKey.Render("Comic Viewer") + "\n" +
-			"  hjkl    Navigate pages\n" +
-			"  [/]     First/Last page\n" +
-			"  ←→↑↓    Pan/scroll image\n" +
-			"  +/-     Zoom in/out\n" +
-			"  0       Reset zoom\n\n" +
-			styles.HelpKey.Render("Library") + "\n" +
-			"  /       Search\n" +
-			"  s       Sort\n" +
-			"  v       Filter (All/Books/Comics)\n" +
-			"  b/m     Books only / Comics only\n" +
-			"  A       Filter by author\n" +
-			"  E       Filter by series\n" +
-			"  x       Clear filter\n" +
-			"  i       Book details\n" +
-			"  Enter   Open book\n\n" +
-			styles.HelpKey.Render("General") + "\n" +
-			"  q       Quit/Back\n" +
-			"  Esc     Back\n" +
-			"  ?       Toggle help\n",
-	)
-
-	// Center the help dialog
-	return lipgloss.Place(
-		a.width,
-		a.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		help,
-	)
-}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+// authEventMsg carries a session change made by the API client: a renewed
+// token, or none when the session expired
+type authEventMsg api.AuthEvent
+
+// waitForAuthEvent waits for the client's next session change
+func (a *App) waitForAuthEvent() tea.Cmd {
+	events := a.client.AuthEvents()
+	return func() tea.Msg {
+		return authEventMsg(<-events)
+	}
+}
+
+// handleAuthEvent saves a renewed token, or sends the user to the login view
+// when the session can't be renewed. The view they were on is reopened after
+// they log in again.
+func (a *App) handleAuthEvent(msg authEventMsg) (tea.Model, tea.Cmd) {
+	next := a.waitForAuthEvent()
+	if msg.Token != "" {
+		_ = a.config.SetToken(msg.Token)
+		return a, next
+	}
+	if a.currentView == views.ViewLogin || a.currentView == views.ViewRegister {
+		return a, next
+	}
+
+	a.resumeView = a.currentView
+	if a.resumeView == views.ViewTOC {
+		a.resumeView = views.ViewReader
+	}
+	a.resumeUser = a.config.Username
+	_ = a.config.ClearToken()
+	a.loginView.(*views.LoginView).PrefillUsername(a.resumeUser)
+	_, cmd := a.switchView(views.ViewLogin)
+	a.err = nil // Saving the reading position failed too; it is saved after login
+	a.statusMsg = "Your session has expired. Log in again to continue where you left off."
+	return a, tea.Batch(next, cmd)
+}
+
+// resumeSession returns to the view that was open when the session expired,
+// first saving the reading position that couldn't be saved then. Leaving for
+// the login view ended the reading session, so a new one starts.
+func (a *App) resumeSession() (*App, tea.Cmd) {
+	view := a.resumeView
+	a.resumeView = views.ViewLogin
+	switch view {
+	case views.ViewReader:
+		readerView := a.readerView.(*views.ReaderView)
+		_ = readerView.SavePositionOnExit()
+		readerView.ResumeSession()
+	case views.ViewComic:
+		comicView := a.comicView.(*views.ComicView)
+		_ = comicView.SavePositionOnExit()
+		comicView.ResumeSession()
+	}
+	return a.switchView(view)
+}
@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+// Key remapping. The config's "keys" maps a view name (or "global", which
+// applies everywhere) to the keys to remap there: each pressed key acts as
+// another key, or as a named action such as "next_chapter". An empty value
+// unbinds the key. "key_preset" starts from a ready-made set.
+
+// keyScopes are the views whose keys can be remapped, by config name. Keys
+// typed into the login and account forms, and into prompts, are never remapped.
+var keyScopes = map[string][]views.ViewType{
+	"home":        {views.ViewHome},
+	"library":     {views.ViewLibrary},
+	"reader":      {views.ViewReader, views.ViewTOC},
+	"comic":       {views.ViewComic},
+	"collections": {views.ViewCollections},
+	"upload":      {views.ViewUpload},
+	"details":     {views.ViewBookDetails},
+	"history":     {views.ViewHistory},
+}
+
+// globalScope names remaps that apply in every view
+const globalScope = "global"
+
+// keyPresets are the ready-made key sets for "key_preset". The default is the
+// vim-like keys the views are written for.
+var keyPresets = map[string]map[string]config.KeyRemaps{
+	"vim": {},
+	// Arrow keys only: the letter keys that duplicate them do nothing
+	"arrows": {
+		globalScope: {"j": "", "k": ""},
+		"home":      {"h": "", "l": ""},
+		"comic":     {"h": "", "l": ""},
+	},
+	"emacs": {
+		globalScope: {
+			"ctrl+n": "down",
+			"ctrl+p": "up",
+			"ctrl+f": "right",
+			"ctrl+b": "left",
+			"ctrl+v": "pgdown",
+			"alt+v":  "pgup",
+			"alt+<":  "home",
+			"alt+>":  "end",
+			"ctrl+g": "esc",
+		},
+	},
+}
+
+// KeyPresets returns the names of the ready-made key sets
+func KeyPresets() []string {
+	return slices.Sorted(maps.Keys(keyPresets))
+}
+
+// namedKeys maps the names bubbletea gives special keys to their types
+var namedKeys = func() map[string]tea.KeyType {
+	names := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-256); t < 256; t++ {
+		if name := t.String(); name != "" {
+			if _, ok := names[name]; !ok {
+				names[name] = t
+			}
+		}
+	}
+	return names
+}()
+
+// parseKey returns the key press a key name such as "n", "ctrl+d", "alt+v" or
+// "pgdown" stands for
+func parseKey(name string) (tea.Key, bool) {
+	if t, ok := namedKeys[name]; ok {
+		return tea.Key{Type: t}, true
+	}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		if k, ok := parseKey(rest); ok && !k.Alt {
+			k.Alt = true
+			return k, true
+		}
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return tea.Key{Type: tea.KeyRunes, Runes: []rune(name)}, true
+	}
+	return tea.Key{}, false
+}
+
+// actions are the KeyMap's bindings by the names remaps can use as targets
+func (k KeyMap) actions() map[string]key.Binding {
+	return map[string]key.Binding{
+		"up":           k.Up,
+		"down":         k.Down,
+		"left":         k.Left,
+		"right":        k.Right,
+		"page_up":      k.PageUp,
+		"page_down":    k.PageDown,
+		"top":          k.Home,
+		"bottom":       k.End,
+		"select":       k.Enter,
+		"back":         k.Escape,
+		"quit":         k.Quit,
+		"help":         k.Help,
+		"search":       k.Search,
+		"next_field":   k.Tab,
+		"next_chapter": k.NextChapter,
+		"prev_chapter": k.PrevChapter,
+		"toc":          k.TOC,
+		"sort":         k.SortToggle,
+		"view_mode":    k.ViewToggle,
+	}
+}
+
+// Bindings translates remapped key presses into the keys the views handle
+type Bindings struct {
+	global map[string]tea.Key // Pressed key to the key it acts as
+	views  map[views.ViewType]map[string]tea.Key
+}
+
+// unboundKey marks a key that does nothing; no key press has empty runes
+var unboundKey = tea.Key{Type: tea.KeyRunes}
+
+// NewBindings builds the key remaps from a preset ("" for the default) and the
+// config's own remaps, which take precedence. Entries that can't be used are
+// reported in the error and skipped; the rest still apply.
+func NewBindings(keys KeyMap, preset string, custom map[string]config.KeyRemaps) (*Bindings, error) {
+	b := &Bindings{global: make(map[string]tea.Key), views: make(map[views.ViewType]map[string]tea.Key)}
+	var errs []error
+
+	base, ok := keyPresets[preset]
+	if preset != "" && !ok {
+		errs = append(errs, fmt.Errorf("unknown key preset %q (choose from %s)", preset, strings.Join(KeyPresets(), ", ")))
+	}
+	actions := keys.actions()
+	for _, remaps := range []map[string]config.KeyRemaps{base, custom} {
+		for _, scope := range slices.Sorted(maps.Keys(remaps)) {
+			target, err := b.scope(scope)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, pressed := range slices.Sorted(maps.Keys(remaps[scope])) {
+				to, err := resolveKey(actions, remaps[scope][pressed])
+				if _, ok := parseKey(pressed); !ok {
+					err = fmt.Errorf("unknown key %q", pressed)
+				}
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", scope, err))
+					continue
+				}
+				for _, keys := range target {
+					keys[pressed] = to
+				}
+			}
+		}
+	}
+	errs = append(errs, b.conflicts(keys)...)
+	return b, errors.Join(errs...)
+}
+
+// scope returns the remap tables for a config scope, creating them as needed
+func (b *Bindings) scope(name string) ([]map[string]tea.Key, error) {
+	if name == globalScope {
+		return []map[string]tea.Key{b.global}, nil
+	}
+	viewTypes, ok := keyScopes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown key scope %q", name)
+	}
+	tables := make([]map[string]tea.Key, len(viewTypes))
+	for i, view := range viewTypes {
+		if b.views[view] == nil {
+			b.views[view] = make(map[string]tea.Key)
+		}
+		tables[i] = b.views[view]
+	}
+	return tables, nil
+}
+
+// resolveKey returns the key a remap target stands for: a key name, an action
+// name (its first key), or "" for none
+func resolveKey(actions map[string]key.Binding, target string) (tea.Key, error) {
+	if target == "" {
+		return unboundKey, nil
+	}
+	if binding, ok := actions[target]; ok {
+		target = binding.Keys()[0]
+	}
+	k, ok := parseKey(target)
+	if !ok {
+		return tea.Key{}, fmt.Errorf("unknown key or action %q", target)
+	}
+	return k, nil
+}
+
+// actionScopes are the views each action is checked in; actions not listed
+// matter everywhere
+var actionScopes = map[string][]string{
+	"next_chapter": {"reader"},
+	"prev_chapter": {"reader"},
+	"toc":          {"reader"},
+	"sort":         {"library"},
+	"view_mode":    {"library"},
+	"search":       {"library"},
+}
+
+// conflictChecked are the actions a remap may not leave without a key
+var conflictChecked = []string{"back", "quit", "help", "up", "down", "next_chapter", "prev_chapter", "toc", "sort", "view_mode", "search"}
+
+// conflicts reports remaps that take every key of an action for something
+// else, leaving no way to use it in a view
+func (b *Bindings) conflicts(keys KeyMap) []error {
+	var errs []error
+	actions := keys.actions()
+	for _, name := range conflictChecked {
+		scopes, ok := actionScopes[name]
+		if !ok {
+			scopes = slices.Sorted(maps.Keys(keyScopes))
+		}
+		var lost []string
+		for _, scope := range scopes {
+			if !b.reachable(keyScopes[scope][0], actions[name]) {
+				lost = append(lost, scope)
+			}
+		}
+		if len(lost) > 0 && len(lost) == len(keyScopes) {
+			lost = []string{globalScope} // One report for a global remap
+		}
+		for _, scope := range lost {
+			errs = append(errs, fmt.Errorf("%s: no key left for %s", scope, name))
+		}
+	}
+	return errs
+}
+
+// reachable reports whether some key press in view still acts as one of binding's keys
+func (b *Bindings) reachable(view views.ViewType, binding key.Binding) bool {
+	for _, name := range binding.Keys() {
+		if b.lookup(view, name) == nil {
+			return true // Not remapped, so it keeps its meaning
+		}
+	}
+	for _, table := range []map[string]tea.Key{b.views[view], b.global} {
+		for pressed := range table {
+			if to := b.lookup(view, pressed); slices.Contains(binding.Keys(), to.String()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookup returns the remap of a key press in view, or nil if there is none
+func (b *Bindings) lookup(view views.ViewType, pressed string) *tea.Key {
+	if to, ok := b.views[view][pressed]; ok {
+		return &to
+	}
+	if to, ok := b.global[pressed]; ok {
+		return &to
+	}
+	return nil
+}
+
+// KeysFor returns the keys that act as handled in view: the key itself unless
+// it is remapped, and any keys remapped to it
+func (b *Bindings) KeysFor(view views.ViewType, handled string) []string {
+	if b == nil {
+		return []string{handled}
+	}
+	var keys []string
+	if b.lookup(view, handled) == nil {
+		keys = append(keys, handled)
+	}
+	for _, table := range []map[string]tea.Key{b.views[view], b.global} {
+		for _, pressed := range slices.Sorted(maps.Keys(table)) {
+			if slices.Contains(keys, pressed) {
+				continue
+			}
+			if b.lookup(view, pressed).String() == handled {
+				keys = append(keys, pressed)
+			}
+		}
+	}
+	return keys
+}
+
+// Translate returns the key press a remapped key acts as in view, and false if
+// the key is unbound there
+func (b *Bindings) Translate(view views.ViewType, msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if b == nil {
+		return msg, true
+	}
+	to := b.lookup(view, msg.String())
+	switch {
+	case to == nil:
+		return msg, true
+	case to.Type == tea.KeyRunes && len(to.Runes) == 0:
+		return msg, false
+	}
+	return tea.KeyMsg(*to), true
+}
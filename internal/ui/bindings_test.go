@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want tea.Key
+		ok   bool
+	}{
+		{"n", tea.Key{Type: tea.KeyRunes, Runes: []rune("n")}, true},
+		{"ö", tea.Key{Type: tea.KeyRunes, Runes: []rune("ö")}, true},
+		{"ctrl+d", tea.Key{Type: tea.KeyCtrlD}, true},
+		{"pgdown", tea.Key{Type: tea.KeyPgDown}, true},
+		{"alt+v", tea.Key{Type: tea.KeyRunes, Runes: []rune("v"), Alt: true}, true},
+		{"alt+alt+v", tea.Key{}, false},
+		{"alt+", tea.Key{}, false},
+		{"nope", tea.Key{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseKey(tt.name)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseKey(%q) = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.String() != tt.name {
+			t.Errorf("parseKey(%q).String() = %q", tt.name, got.String())
+		}
+	}
+}
+
+func TestBindingsTranslate(t *testing.T) {
+	b, err := NewBindings(DefaultKeyMap(), "", map[string]config.KeyRemaps{
+		"global": {"x": "down"},
+		"reader": {"]": "next_chapter", "x": "up", "Z": ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	press := func(name string) tea.KeyMsg {
+		k, _ := parseKey(name)
+		return tea.KeyMsg(k)
+	}
+	tests := []struct {
+		view    views.ViewType
+		pressed string
+		want    string
+		bound   bool
+	}{
+		{views.ViewLibrary, "x", "down", true},
+		{views.ViewReader, "x", "up", true},
+		{views.ViewReader, "]", "n", true},
+		{views.ViewReader, "Z", "", false},
+		{views.ViewLibrary, "Z", "Z", true},
+	}
+	for _, tt := range tests {
+		got, bound := b.Translate(tt.view, press(tt.pressed))
+		if bound != tt.bound || (bound && got.String() != tt.want) {
+			t.Errorf("view %v: %q = %q, %v; want %q, %v", tt.view, tt.pressed, got.String(), bound, tt.want, tt.bound)
+		}
+	}
+
+	if got, want := b.KeysFor(views.ViewReader, "n"), []string{"n", "]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysFor(reader, n) = %v, want %v", got, want)
+	}
+	if got := b.KeysFor(views.ViewReader, "Z"); len(got) != 0 {
+		t.Errorf("KeysFor(reader, Z) = %v, want none", got)
+	}
+	if got, want := b.KeysFor(views.ViewLibrary, "down"), []string{"down", "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysFor(library, down) = %v, want %v", got, want)
+	}
+}
+
+func TestBindingsReportProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		keys   map[string]config.KeyRemaps
+		want   []string
+	}{
+		{"presets", "emacs", nil, nil},
+		{"unknown preset", "nano", nil, []string{`unknown key preset "nano"`}},
+		{"unknown scope", "", map[string]config.KeyRemaps{"nowhere": {"x": "up"}}, []string{`unknown key scope "nowhere"`}},
+		{"unknown key", "", map[string]config.KeyRemaps{"global": {"hyper+x": "up"}}, []string{`unknown key "hyper+x"`}},
+		{"unknown target", "", map[string]config.KeyRemaps{"global": {"x": "fly"}}, []string{`unknown key or action "fly"`}},
+		{"help unbound everywhere", "", map[string]config.KeyRemaps{"global": {"?": ""}}, []string{"global: no key left for help"}},
+		{"toc unbound in the reader", "", map[string]config.KeyRemaps{"reader": {"t": ""}}, []string{"reader: no key left for toc"}},
+		{"moved, not lost", "", map[string]config.KeyRemaps{"reader": {"t": "", "T": "toc"}}, nil},
+	}
+	for _, tt := range tests {
+		_, err := NewBindings(DefaultKeyMap(), tt.preset, tt.keys)
+		if len(tt.want) == 0 {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: no error, want %q", tt.name, tt.want)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: error %q, want %q", tt.name, err, want)
+			}
+		}
+	}
+}
+
+func TestHelpShowsRemappedKeys(t *testing.T) {
+	b, err := NewBindings(DefaultKeyMap(), "", map[string]config.KeyRemaps{
+		"reader": {"]": "next_chapter", "t": "", "T": "toc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &App{bindings: b, width: 120, height: 200}
+	help := app.renderHelp()
+	for _, want := range []string{"n/]/l   Next chapter", "T       Table of contents", "Ctrl+d  Page down"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help is missing %q", want)
+		}
+	}
+}
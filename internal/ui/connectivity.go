@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+)
+
+// offlineNotice is shown under every view while the server can't be reached
+const offlineNotice = "Offline - showing saved content; changes are sent when the server is back"
+
+// connectivityMsg reports the API client going offline or coming back
+type connectivityMsg api.ConnectivityEvent
+
+// waitForConnectivity waits for the client's next connectivity change
+func (a *App) waitForConnectivity() tea.Cmd {
+	events := a.client.ConnectivityEvents()
+	return func() tea.Msg {
+		return connectivityMsg(<-events)
+	}
+}
+
+// handleConnectivity switches the offline notice on or off. On reconnecting,
+// the favorites and queue are synced again; views keep what they show until
+// refreshed.
+func (a *App) handleConnectivity(msg connectivityMsg) (tea.Model, tea.Cmd) {
+	next := a.waitForConnectivity()
+	a.offline = !msg.Online
+	if a.offline {
+		return a, next
+	}
+	a.err = nil // Most likely the request that found the server gone
+	switch msg.Flushed {
+	case 0:
+		a.statusMsg = "Back online"
+	case 1:
+		a.statusMsg = "Back online - 1 saved change sent"
+	default:
+		a.statusMsg = fmt.Sprintf("Back online - %d saved changes sent", msg.Flushed)
+	}
+	if msg.Dropped > 0 {
+		a.statusMsg += fmt.Sprintf(", %d could not be sent and were dropped", msg.Dropped)
+	}
+	return a, tea.Batch(next, a.startSync())
+}
+
+// busyMsg reports that the server is busy and a request will be retried
+type busyMsg api.ServerBusyEvent
+
+// waitForBusy waits for the client's next notice of a busy server
+func (a *App) waitForBusy() tea.Cmd {
+	events := a.client.BusyEvents()
+	return func() tea.Msg {
+		return busyMsg(<-events)
+	}
+}
+
+// busyClearedMsg removes a busy notice once its retry is due
+type busyClearedMsg struct {
+	notice string
+}
+
+// handleBusy tells the user why a view is slow to load while the client waits
+// out the server's Retry-After. The notice goes once the retry is due, unless
+// something else has been shown since.
+func (a *App) handleBusy(msg busyMsg) (tea.Model, tea.Cmd) {
+	notice := fmt.Sprintf("Server busy, retrying in %s...", max(time.Second, msg.Wait.Round(time.Second)))
+	a.statusMsg = notice
+	clear := tea.Tick(msg.Wait+time.Second, func(time.Time) tea.Msg {
+		return busyClearedMsg{notice: notice}
+	})
+	return a, tea.Batch(a.waitForBusy(), clear)
+}
+
+// handleBusyCleared removes a busy notice that is still shown
+func (a *App) handleBusyCleared(msg busyClearedMsg) (tea.Model, tea.Cmd) {
+	if a.statusMsg == msg.notice {
+		a.statusMsg = ""
+	}
+	return a, nil
+}
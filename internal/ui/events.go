@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+// serverEventMsg carries the next change pushed by the server
+type serverEventMsg struct {
+	events <-chan api.Event
+	event  api.Event
+}
+
+// subscribeEvents follows the server's change events for the rest of the run.
+// Servers without an event stream end the subscription at once, leaving the
+// library to poll.
+func (a *App) subscribeEvents() tea.Cmd {
+	return waitForServerEvent(a.client.SubscribeEvents(context.Background()))
+}
+
+// waitForServerEvent waits for the next event, stopping when the stream ends
+func waitForServerEvent(events <-chan api.Event) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		return serverEventMsg{events: events, event: event}
+	}
+}
+
+// handleServerEvent passes an event to the current view as a ServerEventMsg
+func (a *App) handleServerEvent(msg serverEventMsg) (tea.Model, tea.Cmd) {
+	next := waitForServerEvent(msg.events)
+	if !a.config.IsAuthenticated() {
+		return a, next
+	}
+	model, cmd := a.delegateToView(views.ServerEventMsg{Event: msg.event})
+	return model, tea.Batch(next, cmd)
+}
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+// helpEntry is a line of the help screen: the keys the view handles (none for
+// a note) and what they do
+type helpEntry struct {
+	keys []string
+	desc string
+}
+
+// helpSection is a group of help lines for the view whose key remaps apply
+type helpSection struct {
+	title   string
+	view    views.ViewType
+	entries []helpEntry
+}
+
+// help is the help screen's content, with each view's own key names
+var help = []helpSection{
+	{"Navigation", views.ViewLibrary, []helpEntry{
+		{[]string{"j", "down"}, "Move down"},
+		{[]string{"k", "up"}, "Move up"},
+		{[]string{"g"}, "Go to top"},
+		{[]string{"G"}, "Go to bottom"},
+		{[]string{"ctrl+d"}, "Page down"},
+		{[]string{"ctrl+u"}, "Page up"},
+	}},
+	{"Reader", views.ViewReader, []helpEntry{
+		{[]string{"n", "l"}, "Next chapter"},
+		{[]string{"p", "h"}, "Previous chapter"},
+		{[]string{"t"}, "Table of contents"},
+		{[]string{"B"}, "Add bookmark"},
+		{[]string{"b"}, "View bookmarks"},
+		{[]string{"f"}, "Follow link"},
+		{[]string{"H"}, "Hide/show header and footer"},
+		{[]string{"Z"}, "Zen mode (distraction-free reading)"},
+		{[]string{"ctrl+o"}, "Jump back"},
+		{[]string{"ctrl+i"}, "Jump forward"},
+	}},
+	{"Comic Viewer", views.ViewComic, []helpEntry{
+		{[]string{"h", "j", "k", "l"}, "Navigate pages"},
+		{[]string{"[", "]"}, "First/Last page"},
+		{[]string{":"}, "Go to page"},
+		{[]string{"t"}, "Page thumbnails"},
+		{[]string{"B"}, "Bookmark page"},
+		{[]string{"b"}, "View bookmarks (r rename, N note)"},
+		{[]string{"left", "right"}, "Turn page (pan when zoomed)"},
+		{[]string{"up", "down"}, "Pan/scroll image (shift for fine pans)"},
+		{[]string{"+", "-"}, "Zoom in/out (or mouse wheel)"},
+		{[]string{"z"}, "Set zoom percentage"},
+		{[]string{"L"}, "Lock zoom across page turns"},
+		{[]string{"e"}, "E-ink mode (grayscale, fewer redraws)"},
+		{[]string{"0"}, "Reset zoom"},
+		{[]string{"m"}, "Manga (right-to-left) order"},
+		{[]string{"f"}, "Fit page, width or height"},
+		{[]string{"r", "R"}, "Rotate clockwise/counter-clockwise"},
+		{[]string{"a"}, "Adjust brightness/contrast/gamma"},
+		{[]string{"P"}, "Slideshow play/pause (< > change speed)"},
+		{[]string{"s"}, "Two-page spread (wide terminals)"},
+		{[]string{"S"}, "Split wide pages into halves"},
+		{[]string{"w"}, "Webtoon mode (vertical strip, j/k scroll)"},
+	}},
+	{"Library", views.ViewLibrary, []helpEntry{
+		{[]string{"/"}, "Search (results update as you type)"},
+		{nil, "author:, series:, format: narrow by field"},
+		{[]string{"s"}, "Sort"},
+		{[]string{"N"}, "Recently added (newest uploads first)"},
+		{[]string{"n", "p"}, "Next/previous page (: jumps to a page)"},
+		{[]string{"O"}, "Books shared with me"},
+		{[]string{"v"}, "Filter (All/Books/Comics)"},
+		{[]string{"b", "m"}, "Books only / Comics only"},
+		{[]string{"A"}, "Filter by author"},
+		{[]string{"E"}, "Filter by series"},
+		{[]string{"t"}, "Tags: space toggles, n adds, enter filters"},
+		{[]string{"u"}, "Filter by read status (unread/in progress/finished)"},
+		{[]string{"M"}, "Mark book unread/in progress/finished"},
+		{[]string{"*"}, "Filter by rating (1-5 stars and up, best first)"},
+		{[]string{"z"}, "Archive (hide) book, or restore it"},
+		{[]string{"Z"}, "Show archived books"},
+		{[]string{"x"}, "Clear filter"},
+		{[]string{"ctrl+s"}, "Save filters as a smart collection"},
+		{[]string{"i"}, "Book details"},
+		{[]string{"I"}, "Library summary (counts and size)"},
+		{[]string{"H"}, "Reading history"},
+		{[]string{"~"}, "Home dashboard"},
+		{[]string{"C"}, "Covers: off, list, grid"},
+		{[]string{"B"}, "Browse: list, by author, by series (h/l fold)"},
+		{[]string{" "}, "Mark book (f/w/d/z/+ act on all marked)"},
+		{[]string{"+"}, "Add to collection"},
+		{[]string{"U"}, "Upload a new cover"},
+		{[]string{"D"}, "Download book file"},
+		{[]string{"T"}, "Cycle theme"},
+		{[]string{"ctrl+t"}, "Auto day/night theme"},
+		{[]string{"enter"}, "Open book"},
+	}},
+	{"Home", views.ViewHome, []helpEntry{
+		{[]string{"tab"}, "Next section (continue reading, queue, recently added)"},
+		{[]string{"L"}, "Library"},
+	}},
+	{"General", views.ViewLibrary, []helpEntry{
+		{[]string{","}, "Account settings (email, password)"},
+		{[]string{"P"}, "Switch server profile (Ctrl+p at login)"},
+		{[]string{"q"}, "Quit/Back"},
+		{[]string{"esc"}, "Back"},
+		{[]string{"?"}, "Toggle help"},
+	}},
+}
+
+// helpKeyWidth is the width of the key column on the help screen
+const helpKeyWidth = 8
+
+// keyLabels are how key names are shown on the help screen
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "Space",
+	"enter": "Enter",
+	"esc":   "Esc",
+	"tab":   "Tab",
+}
+
+// keyLabel returns a key name as shown on the help screen, e.g. "Ctrl+d"
+func keyLabel(name string) string {
+	if label, ok := keyLabels[name]; ok {
+		return label
+	}
+	for _, modifier := range []string{"ctrl+", "alt+"} {
+		if rest, ok := strings.CutPrefix(name, modifier); ok {
+			return strings.ToUpper(modifier[:1]) + modifier[1:] + keyLabel(rest)
+		}
+	}
+	return name
+}
+
+// renderHelp renders the help overlay, showing the keys as remapped
+func (a *App) renderHelp() string {
+	var b strings.Builder
+	b.WriteString(styles.DialogTitle.Render("Keyboard Shortcuts") + "\n")
+	for _, section := range help {
+		b.WriteString("\n" + styles.HelpKey.Render(section.title) + "\n")
+		for _, entry := range section.entries {
+			var labels []string
+			for _, handled := range entry.keys {
+				for _, pressed := range a.bindings.KeysFor(section.view, handled) {
+					labels = append(labels, keyLabel(pressed))
+				}
+			}
+			if len(entry.keys) > 0 && len(labels) == 0 {
+				continue // Every key for it is unbound
+			}
+			keys := strings.Join(labels, "/")
+			padding := max(1, helpKeyWidth-lipgloss.Width(keys))
+			b.WriteString("  " + keys + strings.Repeat(" ", padding) + entry.desc + "\n")
+		}
+	}
+	dialog := styles.Dialog.Width(60).Render(b.String())
+
+	// Center the help dialog
+	return lipgloss.Place(
+		a.width,
+		a.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+)
+
+// switchProfile moves the client to another server profile. Its saved login is
+// used if it has one; otherwise the login view opens.
+func (a *App) switchProfile(name string) (tea.Model, tea.Cmd) {
+	if name == a.config.Profile {
+		if a.config.IsAuthenticated() {
+			return a.switchView(a.startView())
+		}
+		return a.switchView(views.ViewLogin)
+	}
+	if err := a.config.UseProfile(name); err != nil {
+		a.err = err
+		return a, nil
+	}
+	a.client.UseProfile(api.Profile{Name: name, URL: a.config.ServerURL, Token: a.config.Token})
+
+	// Nothing learned about the old server carries over
+	a.user = nil
+	a.resumeView = views.ViewLogin
+	a.syncUnsupported = false
+	a.offline = false
+
+	if !a.config.IsAuthenticated() {
+		return a.switchView(views.ViewLogin)
+	}
+	_, cmd := a.switchView(a.startView())
+	a.statusMsg = "Switched to " + name
+	return a, tea.Batch(cmd, a.loadServerInfo(), a.startSync())
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/views"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// loadServerInfo asks the server what it offers, so optional features it lacks
+// are turned away with a message rather than failing with a 404. Failures are
+// silent; every feature stays available and the server's own errors are shown.
+func (a *App) loadServerInfo() tea.Cmd {
+	client := a.client
+	return func() tea.Msg {
+		_, _ = client.LoadServerInfo()
+		return nil
+	}
+}
+
+// viewFeatures are the optional server features a view depends on
+var viewFeatures = map[views.ViewType]string{
+	views.ViewCollections: api.FeatureCollections,
+}
+
+// unsupportedView returns why view can't open on this server, or nil if it can
+func (a *App) unsupportedView(view views.ViewType) error {
+	if feature, ok := viewFeatures[view]; ok && !a.client.Supports(feature) {
+		return &api.FeatureError{Feature: feature}
+	}
+	return nil
+}
+
+// unsupportedBook returns why book can't open on this server, or nil if it can.
+// PDFs are rendered locally, so only comic archives need the server's comics.
+func (a *App) unsupportedBook(book models.Book) error {
+	if book.IsCBZ() && !a.client.Supports(api.FeatureComics) {
+		return &api.FeatureError{Feature: api.FeatureComics}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// Search highlight modes
+const (
+	HighlightBlock     = "block"     // Colored background (default)
+	HighlightUnderline = "underline" // Underline only, keeps the theme's text colors
+)
+
+// Search highlight settings from config; empty colors fall back to the theme
+var (
+	searchHighlightMode = HighlightBlock
+	searchMatchColor    lipgloss.Color
+	searchCurrentColor  lipgloss.Color
+)
+
+// SetSearchHighlight configures how search matches are drawn and rebuilds the styles.
+// In block mode the colors are backgrounds; in underline mode they tint the text.
+func SetSearchHighlight(mode, matchColor, currentColor string) {
+	if mode != HighlightUnderline {
+		mode = HighlightBlock
+	}
+	searchHighlightMode = mode
+	searchMatchColor = lipgloss.Color(matchColor)
+	searchCurrentColor = lipgloss.Color(currentColor)
+	buildSearchStyles(currentTheme)
+}
+
+// buildSearchStyles rebuilds the search match styles for a theme
+func buildSearchStyles(theme Theme) {
+	if searchHighlightMode == HighlightUnderline {
+		SearchMatch = lipgloss.NewStyle().Underline(true)
+		SearchMatchCurrent = lipgloss.NewStyle().Underline(true).Bold(true)
+		if searchMatchColor != "" {
+			SearchMatch = SearchMatch.Foreground(searchMatchColor)
+		}
+		if searchCurrentColor != "" {
+			SearchMatchCurrent = SearchMatchCurrent.Foreground(searchCurrentColor)
+		}
+		return
+	}
+
+	matchBg, currentBg := theme.Border, theme.Warning
+	if searchMatchColor != "" {
+		matchBg = searchMatchColor
+	}
+	if searchCurrentColor != "" {
+		currentBg = searchCurrentColor
+	}
+
+	SearchMatch = lipgloss.NewStyle().
+		Foreground(theme.Foreground).
+		Background(matchBg)
+
+	SearchMatchCurrent = lipgloss.NewStyle().
+		Foreground(theme.Background).
+		Background(currentBg).
+		Bold(true)
+}
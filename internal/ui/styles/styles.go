@@ -59,6 +59,12 @@ var (
 		Bold(true).
 		Padding(0, 1)
 
+	// Warning message, e.g. while offline
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(Warning).
+		Bold(true).
+		Padding(0, 1)
+
 	// Input field
 	InputLabel = lipgloss.NewStyle().
 		Foreground(Foreground).
@@ -104,6 +110,16 @@ var (
 		Foreground(Secondary).
 		Align(lipgloss.Right)
 
+	// Search match highlights (rebuilt from the theme and config, see SetSearchHighlight)
+	SearchMatch = lipgloss.NewStyle().
+		Foreground(Foreground).
+		Background(Border)
+
+	SearchMatchCurrent = lipgloss.NewStyle().
+		Foreground(Background).
+		Background(Warning).
+		Bold(true)
+
 	// Dialog/Modal styles
 	Dialog = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -249,28 +265,67 @@ const (
 	FooterHeight = 1 // Fixed footer height in lines
 )
 
+// ContentHeight returns the number of lines available for content
+// between the header and footer of a view rendered with RenderLayout
+func ContentHeight(height int) int {
+	contentHeight := height - HeaderHeight - FooterHeight - 2 // -2 leaves room for the app error bar
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	return contentHeight
+}
+
+// ChromelessContentHeight returns the number of lines available for content
+// when a view hides its header and footer (see RenderChromeless)
+func ChromelessContentHeight(height int) int {
+	return ContentHeight(height) + HeaderHeight + FooterHeight
+}
+
+// HeaderContent lays out left and right aligned header text so that it fits
+// on a single HeaderBar line, truncating the left side if necessary
+func HeaderContent(left, right string, width int) string {
+	available := width - HeaderBar.GetHorizontalFrameSize()
+	rightWidth := lipgloss.Width(right)
+	if lipgloss.Width(left)+rightWidth > available {
+		left = TruncateText(left, available-rightWidth-1)
+	}
+	return left + repeat(" ", available-lipgloss.Width(left)-rightWidth) + right
+}
+
 // RenderLayout creates a consistent view layout with header, content, and footer
 // It ensures proper spacing and alignment across all views
 func RenderLayout(header, content, footer string, width, height int) string {
 	// Calculate content area height
-	contentHeight := height - HeaderHeight - FooterHeight - 2 // -2 for newlines
+	contentHeight := ContentHeight(height)
 
 	// Ensure header spans full width
-	headerLine := HeaderBar.Width(width).Render(header)
+	headerLine := HeaderBar.Width(width).MaxHeight(HeaderHeight).Render(header)
 
-	// Content area with fixed height
+	// Content area with fixed height; overflowing content is clipped so the
+	// footer always lands on the same line
 	contentArea := lipgloss.NewStyle().
 		Width(width).
 		Height(contentHeight).
+		MaxHeight(contentHeight).
 		Render(content)
 
 	// Footer spans full width
-	footerLine := FooterBar.Width(width).Render(footer)
+	footerLine := FooterBar.Width(width).MaxHeight(FooterHeight).Render(footer)
 
 	// Join vertically
 	return lipgloss.JoinVertical(lipgloss.Left, headerLine, contentArea, footerLine)
 }
 
+// RenderChromeless renders content in the full layout area without header and footer bars
+func RenderChromeless(content string, width, height int) string {
+	contentHeight := ChromelessContentHeight(height)
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(contentHeight).
+		MaxHeight(contentHeight).
+		Render(content)
+}
+
 // RenderCenteredContent centers content within the available space
 func RenderCenteredContent(content string, width, height int) string {
 	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
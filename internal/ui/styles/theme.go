@@ -147,11 +147,48 @@ var (
 
 	// currentTheme holds the active theme
 	currentTheme = DarkTheme
+
+	// customThemes are the user's own themes, see RegisterTheme
+	customThemes []Theme
 )
 
+// RegisterTheme adds a user-defined theme. A theme with the name of a built-in
+// or an earlier user theme replaces it.
+func RegisterTheme(theme Theme) {
+	for i, t := range customThemes {
+		if t.Name == theme.Name {
+			customThemes[i] = theme
+			return
+		}
+	}
+	customThemes = append(customThemes, theme)
+}
+
+// allThemes returns the built-in themes, as replaced by user themes of the same
+// name, followed by the other user themes
+func allThemes() []Theme {
+	themes := make([]Theme, 0, len(BuiltinThemes)+len(customThemes))
+	replaced := make(map[string]bool)
+	for _, builtin := range BuiltinThemes {
+		for _, custom := range customThemes {
+			if custom.Name == builtin.Name {
+				builtin = custom
+				replaced[custom.Name] = true
+			}
+		}
+		themes = append(themes, builtin)
+	}
+	for _, custom := range customThemes {
+		if !replaced[custom.Name] {
+			themes = append(themes, custom)
+		}
+	}
+	return themes
+}
+
 // GetTheme returns a theme by name, or the default theme if not found
 func GetTheme(name string) Theme {
-	for _, t := range BuiltinThemes {
+	for _, t := range allThemes() {
 		if t.Name == name {
 			return t
 		}
@@ -161,8 +198,9 @@ func GetTheme(name string) Theme {
 
 // GetThemeNames returns a list of all available theme names
 func GetThemeNames() []string {
-	names := make([]string, len(BuiltinThemes))
-	for i, t := range BuiltinThemes {
+	themes := allThemes()
+	names := make([]string, len(themes))
+	for i, t := range themes {
 		names[i] = t.Name
 	}
 	return names
@@ -181,11 +219,12 @@ func SetCurrentTheme(name string) {
 
 // NextTheme cycles to the next theme and returns its name
 func NextTheme() string {
-	for i, t := range BuiltinThemes {
+	themes := allThemes()
+	for i, t := range themes {
 		if t.Name == currentTheme.Name {
-			nextIdx := (i + 1) % len(BuiltinThemes)
-			SetCurrentTheme(BuiltinThemes[nextIdx].Name)
-			return BuiltinThemes[nextIdx].Name
+			nextIdx := (i + 1) % len(themes)
+			SetCurrentTheme(themes[nextIdx].Name)
+			return themes[nextIdx].Name
 		}
 	}
 	return currentTheme.Name
@@ -242,6 +281,11 @@ func ApplyTheme(theme Theme) {
 		Bold(true).
 		Padding(0, 1)
 
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(theme.Warning).
+		Bold(true).
+		Padding(0, 1)
+
 	InputLabel = lipgloss.NewStyle().
 		Foreground(theme.Foreground).
 		Bold(true)
@@ -284,6 +328,8 @@ func ApplyTheme(theme Theme) {
 		Foreground(theme.Secondary).
 		Align(lipgloss.Right)
 
+	buildSearchStyles(theme)
+
 	Dialog = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(theme.Primary).
@@ -329,6 +375,25 @@ func ApplyTheme(theme Theme) {
 		Background(theme.BadgeComic).
 		Padding(0, 1).
 		Bold(true)
+
+	HeaderBar = lipgloss.NewStyle().
+		Foreground(theme.SelectionText).
+		Background(theme.Primary).
+		Padding(0, 1).
+		Bold(true)
+
+	FooterBar = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Background(lipgloss.Color("#111827")).
+		Padding(0, 1)
+
+	StatusLine = lipgloss.NewStyle().
+		Foreground(theme.Secondary).
+		Background(lipgloss.Color("#111827")).
+		Padding(0, 1)
+
+	Divider = lipgloss.NewStyle().
+		Foreground(theme.Border)
 }
 
 // init applies the default theme on package load
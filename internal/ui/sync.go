@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+)
+
+// Keys in the server's per-user data store holding the synced lists
+const (
+	favoritesSyncKey = "favorites"
+	queueSyncKey     = "reading_queue"
+)
+
+// syncFetchedMsg carries the favorites and queue stored on the server
+type syncFetchedMsg struct {
+	favorites []string
+	queue     []string
+	err       error
+}
+
+// syncPushedMsg reports whether merged lists were stored on the server
+type syncPushedMsg struct {
+	favorites []string
+	queue     []string
+	err       error
+}
+
+// startSync fetches the server's favorites and queue to merge with the local ones.
+// Only one sync runs at a time, and none once the server is known not to support it.
+func (a *App) startSync() tea.Cmd {
+	if a.config.DisableSync || a.syncUnsupported || a.syncing || !a.config.IsAuthenticated() {
+		return nil
+	}
+	a.syncing = true
+	client := a.client
+	return func() tea.Msg {
+		var msg syncFetchedMsg
+		if _, err := client.GetUserData(favoritesSyncKey, &msg.favorites); err != nil {
+			return syncFetchedMsg{err: err}
+		}
+		if _, err := client.GetUserData(queueSyncKey, &msg.queue); err != nil {
+			return syncFetchedMsg{err: err}
+		}
+		return msg
+	}
+}
+
+// handleSyncFetched merges the server's lists into the local ones and sends the
+// result back if the server is missing any local changes. Failures are silent;
+// local lists keep working and the next sync tries again.
+func (a *App) handleSyncFetched(msg syncFetchedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		a.syncing = false
+		a.syncUnsupported = errors.Is(msg.err, api.ErrNotSupported)
+		return a, nil
+	}
+
+	base := a.config.SyncBase()
+	favorites := mergeSynced(base.Favorites, a.config.Favorites, msg.favorites)
+	queue := mergeSynced(base.Queue, a.config.ReadingQueue, msg.queue)
+	if err := a.config.ApplySync(favorites, queue); err != nil {
+		a.syncing = false
+		return a, nil
+	}
+	if slices.Equal(favorites, msg.favorites) && slices.Equal(queue, msg.queue) {
+		a.syncing = false
+		_ = a.config.MarkSynced(favorites, queue)
+		return a, nil
+	}
+
+	client := a.client
+	return a, func() tea.Msg {
+		if err := client.PutUserData(favoritesSyncKey, favorites); err != nil {
+			return syncPushedMsg{err: err}
+		}
+		if err := client.PutUserData(queueSyncKey, queue); err != nil {
+			return syncPushedMsg{err: err}
+		}
+		return syncPushedMsg{favorites: favorites, queue: queue}
+	}
+}
+
+// handleSyncPushed records the lists the server now holds as the next merge base
+func (a *App) handleSyncPushed(msg syncPushedMsg) (tea.Model, tea.Cmd) {
+	a.syncing = false
+	if msg.err != nil {
+		a.syncUnsupported = errors.Is(msg.err, api.ErrNotSupported)
+		return a, nil
+	}
+	_ = a.config.MarkSynced(msg.favorites, msg.queue)
+	return a, nil
+}
+
+// mergeSynced merges a synced list, never returning nil so an empty list is
+// stored as [] rather than cleared
+func mergeSynced(base, local, remote []string) []string {
+	merged := config.MergeLists(base, local, remote)
+	if merged == nil {
+		return []string{}
+	}
+	return merged
+}
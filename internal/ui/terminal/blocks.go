@@ -0,0 +1,113 @@
+package terminal
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+
+	"github.com/nfnt/resize"
+)
+
+// upperHalfBlock draws the top pixel in the foreground color and the bottom in the background
+const upperHalfBlock = "▀"
+
+// blockTrueColor reports whether the terminal advertises 24-bit color
+var blockTrueColor = detectTrueColor()
+
+// detectTrueColor checks COLORTERM, which truecolor terminals set
+func detectTrueColor() bool {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// RenderImageInCells renders an image for display in an area of cols x rows cells.
+// Half-blocks, and Kitty images given an ID, are scaled to fit and lay out like text;
+// other graphics protocols draw the image at its own size.
+func RenderImageInCells(img image.Image, mode TermImageMode, cols, rows int, kittyID ...uint32) (string, error) {
+	switch {
+	case mode == TermModeBlocks:
+		return renderBlocks(img, cols, rows), nil
+	case mode == TermModeKitty && len(kittyID) > 0:
+		return renderKittyPlaced(img, cols, rows, kittyID[0])
+	}
+	return RenderImageToString(img, mode, kittyID...)
+}
+
+// renderBlocks draws img with half-block characters, two pixels per cell, scaled to fit
+// within cols x rows cells. Half a cell is roughly square, so the aspect ratio is kept.
+func renderBlocks(img image.Image, cols, rows int) string {
+	bounds := img.Bounds()
+	if bounds.Empty() || cols <= 0 || rows <= 0 {
+		return ""
+	}
+	width, height := cols, cols*bounds.Dy()/bounds.Dx()
+	if height > rows*2 {
+		width, height = rows*2*bounds.Dx()/bounds.Dy(), rows*2
+	}
+	scaled := resize.Resize(uint(max(1, width)), uint(max(1, height)), img, resize.Bilinear)
+	bounds = scaled.Bounds()
+
+	var b strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := scaled.At(x, y)
+			bottom := color.Color(color.Black)
+			if y+1 < bounds.Max.Y {
+				bottom = scaled.At(x, y+1)
+			}
+			b.WriteString(blockColor(38, top))
+			b.WriteString(blockColor(48, bottom))
+			b.WriteString(upperHalfBlock)
+		}
+		b.WriteString("\x1b[0m")
+		if y+2 < bounds.Max.Y {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// blockColor returns the SGR sequence setting the foreground (38) or background (48) color
+func blockColor(layer int, c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	if blockTrueColor {
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, rgba.R, rgba.G, rgba.B)
+	}
+	return fmt.Sprintf("\x1b[%d;5;%dm", layer, xterm256(rgba))
+}
+
+// xterm256 maps a color to the nearest entry in the xterm 6x6x6 color cube or grey ramp
+func xterm256(c color.RGBA) int {
+	cube := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		return min(5, (int(v)-35)/40)
+	}
+	cubeLevel := func(i int) int {
+		if i == 0 {
+			return 0
+		}
+		return 55 + i*40
+	}
+	r, g, b := cube(c.R), cube(c.G), cube(c.B)
+	cubeIndex := 16 + 36*r + 6*g + b
+	cubeDist := colorDist(c, cubeLevel(r), cubeLevel(g), cubeLevel(b))
+
+	// The grey ramp (232-255) runs from 8 to 238 in steps of 10
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	grey := max(0, min(23, (avg-3)/10))
+	greyLevel := 8 + grey*10
+	if colorDist(c, greyLevel, greyLevel, greyLevel) < cubeDist {
+		return 232 + grey
+	}
+	return cubeIndex
+}
+
+// colorDist returns the squared distance between c and an RGB triple
+func colorDist(c color.RGBA, r, g, b int) int {
+	dr, dg, db := int(c.R)-r, int(c.G)-g, int(c.B)-b
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,82 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+
+	"github.com/BourgeoisBear/rasterm"
+)
+
+// ComicPlacementID identifies the comic image's placement, so each redraw replaces it
+const ComicPlacementID uint32 = 1
+
+const (
+	// kittyChunkSize is the largest base64 payload Kitty accepts per escape sequence
+	kittyChunkSize = 4096
+	// kittyZIndex draws placed images beneath text, keeping prompts over the page readable
+	kittyZIndex = -1
+)
+
+// renderKittyPlaced transmits img and places it scaled to fit within cols x rows cells.
+// The cursor stays put (C=1) and the placed area is filled with spaces, so the result
+// lays out like ordinary text of the placed size instead of wherever the escape lands.
+func renderKittyPlaced(img image.Image, cols, rows int, id uint32) (string, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() || cols <= 0 || rows <= 0 {
+		return "", nil
+	}
+	width, height := fitCells(bounds.Dx(), bounds.Dy(), cols, rows)
+
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, img); err != nil {
+		return "", err
+	}
+	opts := rasterm.KittyImgOpts{
+		DstCols:     uint32(width),
+		DstRows:     uint32(height),
+		ZIndex:      kittyZIndex,
+		ImageId:     id,
+		PlacementId: ComicPlacementID,
+	}
+
+	var b strings.Builder
+	payload := base64.StdEncoding.EncodeToString(pngData.Bytes())
+	for first := true; first || payload != ""; first = false {
+		chunk := payload[:min(len(payload), kittyChunkSize)]
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			// q=2 keeps the terminal from answering with status replies
+			b.WriteString(opts.ToHeader("a=T", "f=100", "t=d", "q=2", "C=1", fmt.Sprintf("m=%d", more)))
+		} else {
+			fmt.Fprintf(&b, "%sm=%d;", rasterm.KITTY_IMG_HDR, more)
+		}
+		b.WriteString(chunk)
+		b.WriteString(rasterm.KITTY_IMG_FTR)
+	}
+
+	// Reserve the placed area with blank cells
+	blank := strings.Repeat(" ", width)
+	b.WriteString(blank)
+	for i := 1; i < height; i++ {
+		b.WriteString("\n" + blank)
+	}
+	return b.String(), nil
+}
+
+// fitCells returns the size in cells of a width x height pixel image scaled to fit
+// within cols x rows, taking a cell to be about twice as tall as it is wide
+func fitCells(width, height, cols, rows int) (int, int) {
+	fitCols, fitRows := cols, (cols*height+width)/(2*width)
+	if fitRows > rows {
+		fitCols, fitRows = rows*2*width/height, rows
+	}
+	return max(1, fitCols), max(1, fitRows)
+}
@@ -0,0 +1,160 @@
+package terminal
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"sort"
+)
+
+// Sixel palette and dithering choices
+const (
+	SixelPaletteMedianCut = "median-cut" // Adaptive palette built from each image
+	SixelPalettePlan9     = "plan9"      // Fixed 256-color Plan9 palette
+
+	SixelDitherFloydSteinberg = "floyd-steinberg"
+	SixelDitherNone           = "none"
+
+	DefaultSixelColors = 256
+	minSixelColors     = 2
+)
+
+// maxPaletteSamples caps how many pixels are examined when building a palette
+const maxPaletteSamples = 1 << 16
+
+// Current Sixel options (set from config at startup)
+var (
+	sixelPalette = SixelPaletteMedianCut
+	sixelColors  = DefaultSixelColors
+	sixelDither  = SixelDitherFloydSteinberg
+)
+
+// SetSixelOptions configures how images are reduced to a palette for Sixel output.
+// Unknown values fall back to the defaults; colors is clamped to 2-256.
+func SetSixelOptions(paletteName string, colors int, dither string) {
+	if paletteName != SixelPalettePlan9 {
+		paletteName = SixelPaletteMedianCut
+	}
+	if colors <= 0 {
+		colors = DefaultSixelColors
+	}
+	if dither != SixelDitherNone {
+		dither = SixelDitherFloydSteinberg
+	}
+	sixelPalette = paletteName
+	sixelColors = max(minSixelColors, min(DefaultSixelColors, colors))
+	sixelDither = dither
+}
+
+// sixelPaletteFor returns the palette to use for img under the current options
+func sixelPaletteFor(img image.Image) color.Palette {
+	if sixelPalette == SixelPalettePlan9 {
+		return palette.Plan9
+	}
+	return medianCutPalette(img, sixelColors)
+}
+
+// colorBox is a set of sampled colors split during median cut
+type colorBox []color.RGBA
+
+// channelRange returns the channel (0=R, 1=G, 2=B) with the widest spread and that spread
+func (b colorBox) channelRange() (int, int) {
+	lo := [3]uint8{255, 255, 255}
+	var hi [3]uint8
+	for _, c := range b {
+		for i, v := range [3]uint8{c.R, c.G, c.B} {
+			lo[i] = min(lo[i], v)
+			hi[i] = max(hi[i], v)
+		}
+	}
+	widest := 0
+	for i := 1; i < 3; i++ {
+		if hi[i]-lo[i] > hi[widest]-lo[widest] {
+			widest = i
+		}
+	}
+	return widest, int(hi[widest] - lo[widest])
+}
+
+// average returns the mean color of the box
+func (b colorBox) average() color.RGBA {
+	var r, g, bl int
+	for _, c := range b {
+		r += int(c.R)
+		g += int(c.G)
+		bl += int(c.B)
+	}
+	n := max(1, len(b))
+	return color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), 255}
+}
+
+// medianCutPalette builds a palette of up to n colors by repeatedly splitting
+// the box with the widest channel range at its median
+func medianCutPalette(img image.Image, n int) color.Palette {
+	bounds := img.Bounds()
+	step := 1
+	for bounds.Dx()*bounds.Dy()/(step*step) > maxPaletteSamples {
+		step++
+	}
+	var samples colorBox
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			samples = append(samples, color.RGBAModel.Convert(img.At(x, y)).(color.RGBA))
+		}
+	}
+	if len(samples) == 0 {
+		return palette.Plan9
+	}
+
+	boxes := []colorBox{samples}
+	for len(boxes) < n {
+		// Split the box with the widest range; stop when every box is a single color
+		split, splitRange := -1, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			if _, r := box.channelRange(); r > splitRange {
+				split, splitRange = i, r
+			}
+		}
+		if split < 0 {
+			break
+		}
+		box := boxes[split]
+		channel, _ := box.channelRange()
+		sort.Slice(box, func(i, j int) bool {
+			a, b := box[i], box[j]
+			switch channel {
+			case 0:
+				return a.R < b.R
+			case 1:
+				return a.G < b.G
+			}
+			return a.B < b.B
+		})
+		mid := len(box) / 2
+		boxes[split] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+
+	pal := make(color.Palette, len(boxes))
+	for i, box := range boxes {
+		pal[i] = box.average()
+	}
+	return pal
+}
+
+// ImageToPaletted converts an image to a paletted image required for Sixel,
+// using the configured palette and dithering
+func ImageToPaletted(img image.Image) *image.Paletted {
+	bounds := img.Bounds()
+	paletted := image.NewPaletted(bounds, sixelPaletteFor(img))
+	if sixelDither == SixelDitherFloydSteinberg {
+		draw.FloydSteinberg.Draw(paletted, bounds, img, bounds.Min)
+	} else {
+		draw.Draw(paletted, bounds, img, bounds.Min, draw.Src)
+	}
+	return paletted
+}
@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"image"
-	"image/color/palette"
-	"image/draw"
 	"os"
 
 	"github.com/BourgeoisBear/rasterm"
@@ -23,6 +21,8 @@ const (
 	TermModeIterm
 	// TermModeSixel indicates Sixel graphics protocol support
 	TermModeSixel
+	// TermModeBlocks draws images as colored half-block characters (no protocol needed)
+	TermModeBlocks
 )
 
 // ComicImageID is a stable ID for the main comic image (for Kitty protocol)
@@ -37,6 +37,8 @@ func (m TermImageMode) String() string {
 		return "iTerm2"
 	case TermModeSixel:
 		return "Sixel"
+	case TermModeBlocks:
+		return "Blocks"
 	default:
 		return "None"
 	}
@@ -59,20 +61,16 @@ func DetectTerminalMode() TermImageMode {
 		return TermModeSixel
 	}
 
-	// No image support
-	return TermModeNone
-}
-
-// ImageToPaletted converts an image to a paletted image required for Sixel
-func ImageToPaletted(img image.Image) *image.Paletted {
-	bounds := img.Bounds()
-	paletted := image.NewPaletted(bounds, palette.Plan9)
-	draw.Draw(paletted, bounds, img, bounds.Min, draw.Src)
-	return paletted
+	// Fall back to half-block characters, which need only color support
+	if os.Getenv("TERM") == "dumb" {
+		return TermModeNone
+	}
+	return TermModeBlocks
 }
 
 // RenderImageToString renders an image to a string based on the terminal mode.
 // For Kitty protocol, an optional image ID can be passed for targeted clearing.
+// Half-blocks are drawn one column per pixel; use RenderImageInCells to fit an area.
 func RenderImageToString(img image.Image, mode TermImageMode, kittyID ...uint32) (string, error) {
 	var buf bytes.Buffer
 	var renderErr error
@@ -90,6 +88,9 @@ func RenderImageToString(img image.Image, mode TermImageMode, kittyID ...uint32)
 		// Write to buffer instead of stdout for proper bubbletea integration
 		paletted := ImageToPaletted(img)
 		renderErr = rasterm.SixelWriteImage(&buf, paletted)
+	case TermModeBlocks:
+		bounds := img.Bounds()
+		return renderBlocks(img, bounds.Dx(), (bounds.Dy()+1)/2), nil
 	default:
 		return "", nil // No-op for unsupported terminals
 	}
@@ -102,7 +103,8 @@ func RenderImageToString(img image.Image, mode TermImageMode, kittyID ...uint32)
 
 // SupportsImages returns true if the terminal supports any image protocol
 func SupportsImages() bool {
-	return DetectTerminalMode() != TermModeNone
+	mode := DetectTerminalMode()
+	return mode != TermModeNone && mode != TermModeBlocks
 }
 
 // ClearComicImage returns the escape sequence to clear the comic image area.
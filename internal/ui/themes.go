@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// registerThemes adds the user's themes from the config and themes.d to the
+// built-in ones. Themes that can't be used are reported and skipped.
+func registerThemes(cfg *config.Config) error {
+	custom, err := cfg.GetCustomThemes()
+	errs := []error{err}
+	for _, theme := range custom {
+		if theme.Base != "" && !slices.Contains(styles.GetThemeNames(), theme.Base) {
+			errs = append(errs, fmt.Errorf("theme %q: unknown base theme %q", theme.Name, theme.Base))
+			continue
+		}
+		styles.RegisterTheme(customTheme(theme, styles.GetTheme(theme.Base)))
+	}
+	return errors.Join(errs...)
+}
+
+// customTheme builds a theme from a user definition, taking unset colors from base
+func customTheme(def config.CustomTheme, base styles.Theme) styles.Theme {
+	color := func(value string, fallback lipgloss.Color) lipgloss.Color {
+		if value == "" {
+			return fallback
+		}
+		return lipgloss.Color(value)
+	}
+	description := def.Description
+	if description == "" {
+		description = "Custom theme"
+	}
+	return styles.Theme{
+		Name:           def.Name,
+		Description:    description,
+		Primary:        color(def.Primary, base.Primary),
+		Secondary:      color(def.Secondary, base.Secondary),
+		Background:     color(def.Background, base.Background),
+		Foreground:     color(def.Foreground, base.Foreground),
+		Success:        color(def.Success, base.Success),
+		Warning:        color(def.Warning, base.Warning),
+		Error:          color(def.Error, base.Error),
+		Muted:          color(def.Muted, base.Muted),
+		Border:         color(def.Border, base.Border),
+		Selection:      color(def.Selection, base.Selection),
+		SelectionText:  color(def.SelectionText, base.SelectionText),
+		BadgeBook:      color(def.BadgeBook, base.BadgeBook),
+		BadgeBookText:  color(def.BadgeBookText, base.BadgeBookText),
+		BadgeComic:     color(def.BadgeComic, base.BadgeComic),
+		BadgeComicText: color(def.BadgeComicText, base.BadgeComicText),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
@@ -27,6 +28,14 @@ type BookDetailsView struct {
 	// TOC for chapter count
 	chapters []models.Chapter
 
+	// Book file download (D)
+	download  *bookDownload
+	statusMsg string // Result of the last download
+
+	// Personal note being edited (n)
+	editingNote bool
+	noteInput   textinput.Model
+
 	// Dimensions
 	width  int
 	height int
@@ -34,11 +43,17 @@ type BookDetailsView struct {
 
 // NewBookDetailsView creates a new book details view
 func NewBookDetailsView(client *api.Client, cfg *config.Config) *BookDetailsView {
+	noteInput := newTextInput()
+	noteInput.Placeholder = "Note..."
+	noteInput.CharLimit = 500
+	noteInput.Width = 40
+
 	return &BookDetailsView{
-		client: client,
-		config: cfg,
-		width:  80,
-		height: 24,
+		client:    client,
+		config:    cfg,
+		noteInput: noteInput,
+		width:     80,
+		height:    24,
 	}
 }
 
@@ -48,6 +63,9 @@ func (v *BookDetailsView) SetBook(book models.Book) {
 	v.position = nil
 	v.posErr = nil
 	v.chapters = nil
+	v.statusMsg = ""
+	v.editingNote = false
+	v.noteInput.Blur()
 }
 
 // detailsPositionLoadedMsg is sent when reading position is loaded for book details
@@ -56,6 +74,13 @@ type detailsPositionLoadedMsg struct {
 	err      error
 }
 
+// detailsProgressSavedMsg is sent when a book's server position has been set by
+// marking it finished or resetting it
+type detailsProgressSavedMsg struct {
+	status string
+	err    error
+}
+
 // detailsTOCLoadedMsg is sent when TOC is loaded for book details
 type detailsTOCLoadedMsg struct {
 	chapters []models.Chapter
@@ -78,6 +103,9 @@ func (v *BookDetailsView) Init() tea.Cmd {
 func (v *BookDetailsView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.editingNote {
+			return v.handleNoteKeys(msg)
+		}
 		switch msg.String() {
 		case "esc", "q", "i":
 			// Go back to library
@@ -99,6 +127,40 @@ func (v *BookDetailsView) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.book != nil && v.config != nil {
 				_ = v.config.ToggleQueue(v.book.ID)
 			}
+		case "D":
+			// Download the original file
+			if v.book != nil && v.download == nil {
+				var cmd tea.Cmd
+				v.download, cmd = startDownload(v.client, *v.book, downloadDir(v.config))
+				v.statusMsg = ""
+				return v, cmd
+			}
+		case "0", "1", "2", "3", "4", "5":
+			v.setRating(int(msg.String()[0] - '0'))
+		case "n":
+			return v, v.startEditingNote()
+		case "F":
+			return v, v.markFinished()
+		case "R":
+			return v, v.resetProgress()
+		}
+
+	case detailsProgressSavedMsg:
+		v.statusMsg = msg.status
+		if msg.err != nil {
+			v.statusMsg = "Failed to save position: " + msg.err.Error()
+		}
+		return v, v.loadPosition()
+
+	case downloadProgressMsg:
+		if msg.download == v.download {
+			return v, v.download.tick()
+		}
+
+	case downloadDoneMsg:
+		if msg.download == v.download {
+			v.download = nil
+			v.statusMsg = msg.result()
 		}
 
 	case detailsPositionLoadedMsg:
@@ -111,6 +173,13 @@ func (v *BookDetailsView) Update(msg tea.Msg) (View, tea.Cmd) {
 		if msg.err == nil {
 			v.chapters = msg.chapters
 		}
+
+	default:
+		if v.editingNote {
+			var cmd tea.Cmd
+			v.noteInput, cmd = v.noteInput.Update(msg)
+			return v, cmd
+		}
 	}
 
 	return v, nil
@@ -159,12 +228,30 @@ func (v *BookDetailsView) View() string {
 	}
 
 	// File Size
-	b.WriteString(v.renderField("Size", v.formatFileSize(v.book.FileSize)))
+	b.WriteString(v.renderField("Size", formatFileSize(v.book.FileSize)))
 
 	// Upload Date
 	uploadDate := v.book.UploadedAt.Format("January 2, 2006")
 	b.WriteString(v.renderField("Uploaded", uploadDate))
 
+	// Tags
+	if tags := bookTags(v.config, *v.book); len(tags) > 0 {
+		b.WriteString(v.renderField("Tags", strings.Join(tags, ", ")))
+	}
+
+	// Read status, rating and note
+	if v.config != nil {
+		b.WriteString(v.renderField("Status", v.config.GetReadStatus(v.book.ID).Label()))
+		if stars := v.config.GetRating(v.book.ID); stars > 0 {
+			b.WriteString(v.renderField("Rating", ratingStars(stars)))
+		}
+		if v.editingNote {
+			b.WriteString(v.renderField("Note", v.noteInput.View()))
+		} else if note := v.config.GetNote(v.book.ID); note != "" {
+			b.WriteString(v.renderField("Note", note))
+		}
+	}
+
 	// Chapter count (if available)
 	if len(v.chapters) > 0 {
 		b.WriteString(v.renderField("Chapters", fmt.Sprintf("%d", len(v.chapters))))
@@ -202,23 +289,118 @@ func (v *BookDetailsView) View() string {
 		}
 	}
 
-	// Footer
-	footer := v.renderFooter()
-	b.WriteString(footer)
-
-	// Center the content
-	content := lipgloss.NewStyle().
-		Width(v.width - 4).
-		Padding(1, 2).
-		Render(b.String())
-
-	return lipgloss.Place(
-		v.width,
-		v.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		styles.Dialog.Width(min(60, v.width-4)).Render(content),
-	)
+	// Center the details panel in the content area
+	panel := styles.Dialog.Width(min(60, v.width-4)).Render(strings.TrimRight(b.String(), "\n"))
+	content := styles.RenderCenteredContent(panel, v.width, styles.ContentHeight(v.height))
+
+	header := styles.HeaderContent("Book Details", "", v.width)
+	return styles.RenderLayout(header, content, v.renderFooter(), v.width, v.height)
+}
+
+// markFinished records the book as fully read, takes it out of the reading queue,
+// and moves the server position to the end of the last chapter or page
+func (v *BookDetailsView) markFinished() tea.Cmd {
+	if v.book == nil || v.config == nil {
+		return nil
+	}
+	if err := v.config.MarkFinished(v.book.ID); err != nil {
+		v.statusMsg = "Failed to save: " + err.Error()
+		return nil
+	}
+	return v.savePosition(true, "Marked as finished")
+}
+
+// resetProgress forgets the book's progress and moves the server position back
+// to the beginning
+func (v *BookDetailsView) resetProgress() tea.Cmd {
+	if v.book == nil || v.config == nil {
+		return nil
+	}
+	if err := v.config.ResetProgress(v.book.ID); err != nil {
+		v.statusMsg = "Failed to save: " + err.Error()
+		return nil
+	}
+	return v.savePosition(false, "Progress reset")
+}
+
+// savePosition moves the book's server position to its end or its beginning,
+// reporting status once it is saved
+func (v *BookDetailsView) savePosition(end bool, status string) tea.Cmd {
+	book := *v.book
+	chapter, position := 0, 0.0
+	if end {
+		chapter, position = max(0, len(v.chapters)-1), 1
+	}
+	return func() tea.Msg {
+		if end && book.IsComic() {
+			// Comic positions are page indexes
+			info, err := v.client.GetComicPages(book.ID)
+			if err != nil {
+				return detailsProgressSavedMsg{status: status, err: err}
+			}
+			chapter = max(0, info.PageCount-1)
+		}
+		err := v.client.SavePosition(book.ID, fmt.Sprintf("%d", chapter), position)
+		return detailsProgressSavedMsg{status: status, err: err}
+	}
+}
+
+// setRating rates the book, 0 clearing its rating
+func (v *BookDetailsView) setRating(stars int) {
+	if v.book == nil || v.config == nil {
+		return
+	}
+	if err := v.config.SetRating(v.book.ID, stars); err != nil {
+		v.statusMsg = "Failed to save: " + err.Error()
+		return
+	}
+	v.statusMsg = "Rated " + ratingStars(stars)
+	if stars == 0 {
+		v.statusMsg = "Rating cleared"
+	}
+}
+
+// startEditingNote opens the note prompt with the book's current note
+func (v *BookDetailsView) startEditingNote() tea.Cmd {
+	if v.book == nil || v.config == nil {
+		return nil
+	}
+	v.editingNote = true
+	v.noteInput.SetValue(v.config.GetNote(v.book.ID))
+	v.noteInput.CursorEnd()
+	v.noteInput.Focus()
+	return textinput.Blink
+}
+
+// handleNoteKeys handles keys while editing the note: enter saves, esc cancels
+func (v *BookDetailsView) handleNoteKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.editingNote = false
+		v.noteInput.Blur()
+		return v, nil
+	case "enter":
+		v.editingNote = false
+		v.noteInput.Blur()
+		if err := v.config.SetNote(v.book.ID, strings.TrimSpace(v.noteInput.Value())); err != nil {
+			v.statusMsg = "Failed to save: " + err.Error()
+		}
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.noteInput, cmd = v.noteInput.Update(msg)
+	return v, cmd
+}
+
+// CapturingKeys implements KeyCapturer: q and esc go to the note prompt while it is open
+func (v *BookDetailsView) CapturingKeys() bool {
+	return v.editingNote
+}
+
+// ratingStars renders a rating as filled and empty stars, e.g. ★★★☆☆
+func ratingStars(stars int) string {
+	stars = max(0, min(stars, config.MaxRating))
+	return strings.Repeat("★", stars) + strings.Repeat("☆", config.MaxRating-stars)
 }
 
 // renderField renders a label-value pair
@@ -232,16 +414,25 @@ func (v *BookDetailsView) renderField(label, value string) string {
 	return labelStyle.Render(label+":") + " " + valueStyle.Render(value) + "\n"
 }
 
-// renderFooter renders the footer help with consistent styling
+// renderFooter renders the footer help content
 func (v *BookDetailsView) renderFooter() string {
 	help := []string{
 		styles.HelpKey.Render("enter") + styles.Help.Render(" read"),
 		styles.HelpKey.Render("f") + styles.Help.Render(" fav"),
 		styles.HelpKey.Render("w") + styles.Help.Render(" queue"),
+		styles.HelpKey.Render("D") + styles.Help.Render(" download"),
+		styles.HelpKey.Render("F/R") + styles.Help.Render(" finished/reset"),
+		styles.HelpKey.Render("1-5") + styles.Help.Render(" rate"),
+		styles.HelpKey.Render("n") + styles.Help.Render(" note"),
 		styles.HelpKey.Render("esc/q") + styles.Help.Render(" back"),
 	}
-	// Use StatusLine style for footer inside dialog
-	return styles.StatusLine.Render(strings.Join(help, "  "))
+	if v.download != nil {
+		return styles.SecondaryText.Render(v.download.progress())
+	}
+	if v.statusMsg != "" {
+		return styles.SecondaryText.Render(v.statusMsg) + "  " + strings.Join(help, "  ")
+	}
+	return strings.Join(help, "  ")
 }
 
 // SetSize implements View
@@ -251,7 +442,7 @@ func (v *BookDetailsView) SetSize(width, height int) {
 }
 
 // formatFileSize formats bytes to human readable size
-func (v *BookDetailsView) formatFileSize(bytes int64) string {
+func formatFileSize(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
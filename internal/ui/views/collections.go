@@ -1,12 +1,13 @@
 package views
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
 	"github.com/justyntemme/webby-t/internal/ui/styles"
 	"github.com/justyntemme/webby-t/pkg/models"
 )
@@ -14,16 +15,27 @@ import (
 // CollectionsView displays and manages collections
 type CollectionsView struct {
 	client *api.Client
+	config *config.Config
 
-	// Collections
+	// Collections: smart collections (saved library filters) are listed first,
+	// then a tree of nested collections
 	collections []models.Collection
 	cursor      int
+	expanded    map[string]bool // Collections whose children are shown
 
 	// State
 	loading      bool
 	err          error
 	createMode   bool
 	createInput  textinput.Model
+	createParent *models.Collection // Parent of the collection being created, nil for top level
+
+	// Open collection: its books, listed in place of the collections
+	open         *models.Collection
+	books        []models.Book
+	bookCursor   int
+	booksLoading bool
+	statusMsg    string // Result of the last removal, cleared on the next key
 
 	// Dimensions
 	width  int
@@ -31,15 +43,17 @@ type CollectionsView struct {
 }
 
 // NewCollectionsView creates a new collections view
-func NewCollectionsView(client *api.Client) *CollectionsView {
-	createInput := textinput.New()
+func NewCollectionsView(client *api.Client, cfg *config.Config) *CollectionsView {
+	createInput := newTextInput()
 	createInput.Placeholder = "Collection name..."
 	createInput.CharLimit = 100
 	createInput.Width = 40
 
 	return &CollectionsView{
 		client:      client,
+		config:      cfg,
 		createInput: createInput,
+		expanded:    make(map[string]bool),
 		width:       80,
 		height:      24,
 	}
@@ -59,7 +73,7 @@ type collectionCreatedMsg struct {
 // Init implements View
 func (v *CollectionsView) Init() tea.Cmd {
 	v.loading = true
-	return v.loadCollections()
+	return tea.Batch(v.loadCollections(), v.loadCollectionBooks())
 }
 
 // Update implements View
@@ -79,7 +93,12 @@ func (v *CollectionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 				if name != "" {
 					v.createMode = false
 					v.createInput.Blur()
-					return v, v.createCollection(name)
+					parentID := ""
+					if v.createParent != nil {
+						parentID = v.createParent.ID
+						v.expanded[parentID] = true
+					}
+					return v, v.createCollection(name, parentID)
 				}
 				return v, nil
 			default:
@@ -89,32 +108,60 @@ func (v *CollectionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 			}
 		}
 
+		if v.open != nil {
+			return v.handleCollectionBooksKeys(msg)
+		}
+
 		// Normal mode
 		switch msg.String() {
 		case "j", "down":
-			if v.cursor < len(v.collections)-1 {
+			if v.cursor < v.itemCount()-1 {
 				v.cursor++
 			}
 		case "k", "up":
 			if v.cursor > 0 {
 				v.cursor--
 			}
+		case "l", "right":
+			v.expandSelected()
+		case "h", "left":
+			v.collapseSelected()
 		case "c":
 			// Create new collection
 			v.createMode = true
+			v.createParent = nil
 			v.createInput.Focus()
 			v.createInput.SetValue("")
 			return v, textinput.Blink
+		case "C":
+			// Create a collection inside the selected one
+			if row, ok := v.selectedRow(); ok {
+				v.createMode = true
+				v.createParent = &row.collection
+				v.createInput.Focus()
+				v.createInput.SetValue("")
+				return v, textinput.Blink
+			}
 		case "d":
 			// Delete collection
-			if len(v.collections) > 0 {
-				return v, v.deleteCollection(v.collections[v.cursor].ID)
+			if smart, ok := v.selectedSmartCollection(); ok {
+				if err := v.config.DeleteSmartCollection(smart.Name); err != nil {
+					v.err = err
+				}
+				v.cursor = max(0, min(v.cursor, v.itemCount()-1))
+				return v, nil
+			}
+			if row, ok := v.selectedRow(); ok {
+				return v, v.deleteCollection(row.collection.ID)
 			}
 		case "enter":
-			// Select collection (could filter library by this collection)
-			if len(v.collections) > 0 {
-				// Return to library with filter
-				return v, SwitchTo(ViewLibrary)
+			// Show the library with a smart collection's filters
+			if smart, ok := v.selectedSmartCollection(); ok {
+				return v, func() tea.Msg { return ApplySmartCollectionMsg{Collection: smart} }
+			}
+			// List the collection's books
+			if row, ok := v.selectedRow(); ok {
+				return v, v.openCollection(row.collection)
 			}
 		case "r":
 			// Refresh
@@ -129,11 +176,17 @@ func (v *CollectionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 		}
 		v.collections = msg.collections
 		v.err = nil
-		if v.cursor >= len(v.collections) {
-			v.cursor = max(0, len(v.collections)-1)
+		if v.cursor >= v.itemCount() {
+			v.cursor = max(0, v.itemCount()-1)
 		}
 		return v, nil
 
+	case collectionBooksLoadedMsg:
+		v.handleCollectionBooksLoaded(msg)
+
+	case bookRemovedFromCollectionMsg:
+		return v, v.handleBookRemovedFromCollection(msg)
+
 	case collectionCreatedMsg:
 		if msg.err != nil {
 			v.err = msg.err
@@ -148,27 +201,40 @@ func (v *CollectionsView) Update(msg tea.Msg) (View, tea.Cmd) {
 
 // View implements View
 func (v *CollectionsView) View() string {
-	var b strings.Builder
-
-	// Header
-	b.WriteString(styles.BookTitle.Render("Collections") + "\n\n")
+	if v.open != nil {
+		count := ""
+		if !v.booksLoading {
+			count = countBooks(len(v.books))
+		}
+		header := styles.HeaderContent(v.breadcrumbs(*v.open), count, v.width)
+		return styles.RenderLayout(header, v.renderCollectionBooks(), v.renderCollectionBooksFooter(), v.width, v.height)
+	}
 
-	// Create mode input
-	if v.createMode {
-		b.WriteString(styles.SecondaryText.Render("New Collection: ") + v.createInput.View() + "\n\n")
+	count := ""
+	if !v.loading {
+		count = fmt.Sprintf("%d", v.itemCount())
 	}
+	header := styles.HeaderContent("Collections", count, v.width)
 
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the create input and collection list
+func (v *CollectionsView) renderContent() string {
 	// Loading state
 	if v.loading {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render("Loading collections..."),
-		)
-		b.WriteString(content)
-		return b.String()
+		return styles.RenderCenteredContent(styles.MutedText.Render("Loading collections..."), v.width, styles.ContentHeight(v.height))
+	}
+
+	var b strings.Builder
+
+	// Create mode input
+	if v.createMode {
+		label := "New Collection: "
+		if v.createParent != nil {
+			label = "New Collection in " + truncateText(v.createParent.Name, 30) + ": "
+		}
+		b.WriteString(styles.SecondaryText.Render(label) + v.createInput.View() + "\n\n")
 	}
 
 	// Error state
@@ -177,32 +243,63 @@ func (v *CollectionsView) View() string {
 	}
 
 	// Empty state
-	if len(v.collections) == 0 {
-		b.WriteString(styles.MutedText.Render("No collections yet. Press 'c' to create one.") + "\n")
-	} else {
-		// Collection list - simple single-line entries
-		for i, col := range v.collections {
-			if i == v.cursor {
-				// Selected: cyan arrow + bold text
-				b.WriteString(styles.SecondaryText.Render("▸ ") + styles.SecondaryText.Bold(true).Render(col.Name) + "\n")
-			} else {
-				// Not selected: muted text
-				b.WriteString("  " + styles.MutedText.Render(col.Name) + "\n")
-			}
+	if v.itemCount() == 0 {
+		b.WriteString(styles.MutedText.Render("No collections yet. Press 'c' to create one."))
+		return b.String()
+	}
+
+	// Smart collections first, marked as such
+	var lines []string
+	for i, smart := range v.smartCollections() {
+		if i == v.cursor {
+			lines = append(lines, styles.SecondaryText.Render("▸ ")+styles.SecondaryText.Bold(true).Render(smart.Name)+styles.MutedText.Render("  (smart)"))
+		} else {
+			lines = append(lines, "  "+styles.MutedText.Render(smart.Name+"  (smart)"))
 		}
 	}
 
-	// Footer
-	b.WriteString("\n")
+	// Collection tree - nested collections indented under their parents
+	for i, row := range v.treeRows() {
+		lines = append(lines, v.renderTreeRow(row, i+len(v.smartCollections()) == v.cursor))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+
+	return b.String()
+}
+
+// renderFooter renders the footer help content
+func (v *CollectionsView) renderFooter() string {
 	help := []string{
 		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
-		styles.HelpKey.Render("c") + styles.Help.Render(" create"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" open"),
+		styles.HelpKey.Render("h/l") + styles.Help.Render(" fold"),
+		styles.HelpKey.Render("c/C") + styles.Help.Render(" create/inside"),
 		styles.HelpKey.Render("d") + styles.Help.Render(" delete"),
 		styles.HelpKey.Render("esc") + styles.Help.Render(" back"),
 	}
-	b.WriteString(styles.FooterBar.Width(v.width).Render(strings.Join(help, "  ")))
+	return strings.Join(help, "  ")
+}
 
-	return b.String()
+// smartCollections returns the saved library filters
+func (v *CollectionsView) smartCollections() []config.SmartCollection {
+	if v.config == nil {
+		return nil
+	}
+	return v.config.SmartCollections
+}
+
+// itemCount returns the number of smart and server collections listed
+func (v *CollectionsView) itemCount() int {
+	return len(v.smartCollections()) + len(v.treeRows())
+}
+
+// selectedSmartCollection returns the smart collection under the cursor, if it is on one
+func (v *CollectionsView) selectedSmartCollection() (config.SmartCollection, bool) {
+	smart := v.smartCollections()
+	if v.cursor < len(smart) {
+		return smart[v.cursor], true
+	}
+	return config.SmartCollection{}, false
 }
 
 // SetSize implements View
@@ -222,10 +319,10 @@ func (v *CollectionsView) loadCollections() tea.Cmd {
 	}
 }
 
-// createCollection creates a new collection
-func (v *CollectionsView) createCollection(name string) tea.Cmd {
+// createCollection creates a new collection, nested inside parentID unless it is empty
+func (v *CollectionsView) createCollection(name, parentID string) tea.Cmd {
 	return func() tea.Msg {
-		col, err := v.client.CreateCollection(name)
+		col, err := v.client.CreateCollection(name, parentID)
 		if err != nil {
 			return collectionCreatedMsg{err: err}
 		}
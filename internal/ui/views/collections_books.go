@@ -0,0 +1,183 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// collectionBooksLoadedMsg is sent when an open collection's books are loaded
+type collectionBooksLoadedMsg struct {
+	collectionID string
+	books        []models.Book
+	err          error
+}
+
+// bookRemovedFromCollectionMsg is sent when a book has been taken out of a collection
+type bookRemovedFromCollectionMsg struct {
+	title      string
+	collection string
+	err        error
+}
+
+// openCollection lists the books in a collection
+func (v *CollectionsView) openCollection(collection models.Collection) tea.Cmd {
+	v.open = &collection
+	v.books = nil
+	v.bookCursor = 0
+	v.statusMsg = ""
+	return v.loadCollectionBooks()
+}
+
+// loadCollectionBooks fetches the open collection's books
+func (v *CollectionsView) loadCollectionBooks() tea.Cmd {
+	if v.open == nil {
+		return nil
+	}
+	v.booksLoading = true
+	id := v.open.ID
+	return func() tea.Msg {
+		resp, err := v.client.GetCollectionBooks(id)
+		if err != nil {
+			return collectionBooksLoadedMsg{collectionID: id, err: err}
+		}
+		return collectionBooksLoadedMsg{collectionID: id, books: resp.Books}
+	}
+}
+
+// handleCollectionBooksLoaded shows the books of the open collection
+func (v *CollectionsView) handleCollectionBooksLoaded(msg collectionBooksLoadedMsg) {
+	if v.open == nil || msg.collectionID != v.open.ID {
+		return
+	}
+	v.booksLoading = false
+	if msg.err != nil {
+		v.err = msg.err
+		return
+	}
+	v.err = nil
+	v.books = msg.books
+	v.bookCursor = max(0, min(v.bookCursor, len(v.books)-1))
+}
+
+// handleCollectionBooksKeys handles keys while a collection is open
+func (v *CollectionsView) handleCollectionBooksKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	v.statusMsg = ""
+	switch msg.String() {
+	case "j", "down":
+		v.bookCursor = min(v.bookCursor+1, max(0, len(v.books)-1))
+	case "k", "up":
+		v.bookCursor = max(0, v.bookCursor-1)
+	case "g", "home":
+		v.bookCursor = 0
+	case "G", "end":
+		v.bookCursor = max(0, len(v.books)-1)
+	case "enter":
+		if book, ok := v.selectedBook(); ok {
+			return v, func() tea.Msg { return OpenBookMsg{Book: book} }
+		}
+	case "i":
+		if book, ok := v.selectedBook(); ok {
+			return v, func() tea.Msg { return ShowBookDetailsMsg{Book: book} }
+		}
+	case "d", "x":
+		if book, ok := v.selectedBook(); ok {
+			return v, v.removeFromCollection(*v.open, book)
+		}
+	case "r":
+		return v, v.loadCollectionBooks()
+	case "esc", "q", "h", "backspace":
+		v.open = nil
+		v.books = nil
+		v.err = nil
+	}
+	return v, nil
+}
+
+// selectedBook returns the book under the cursor in the open collection
+func (v *CollectionsView) selectedBook() (models.Book, bool) {
+	if v.bookCursor >= 0 && v.bookCursor < len(v.books) {
+		return v.books[v.bookCursor], true
+	}
+	return models.Book{}, false
+}
+
+// removeFromCollection takes a book out of a collection and reloads its books
+func (v *CollectionsView) removeFromCollection(collection models.Collection, book models.Book) tea.Cmd {
+	return func() tea.Msg {
+		err := v.client.RemoveBookFromCollection(collection.ID, book.ID)
+		return bookRemovedFromCollectionMsg{title: book.Title, collection: collection.Name, err: err}
+	}
+}
+
+// handleBookRemovedFromCollection reports a removal and refreshes the list
+func (v *CollectionsView) handleBookRemovedFromCollection(msg bookRemovedFromCollectionMsg) tea.Cmd {
+	if msg.err != nil {
+		v.err = msg.err
+		return nil
+	}
+	v.statusMsg = "Removed " + msg.title + " from " + msg.collection
+	return v.loadCollectionBooks()
+}
+
+// renderCollectionBooks renders the open collection's book list
+func (v *CollectionsView) renderCollectionBooks() string {
+	if v.booksLoading && v.books == nil {
+		return styles.RenderCenteredContent(styles.MutedText.Render("Loading books..."), v.width, styles.ContentHeight(v.height))
+	}
+
+	var b strings.Builder
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render("Error: "+v.err.Error()) + "\n\n")
+	}
+	if len(v.books) == 0 {
+		b.WriteString(styles.MutedText.Render("No books in this collection. Press + on a book in the library to add it."))
+		return b.String()
+	}
+
+	// Keep the cursor on screen
+	visible := max(1, styles.ContentHeight(v.height)-2)
+	start := max(0, v.bookCursor-visible+1)
+	var lines []string
+	for i := start; i < min(len(v.books), start+visible); i++ {
+		book := v.books[i]
+		title := truncateText(book.Title, max(10, v.width/2))
+		author := styles.BookAuthor.Render("  " + truncateText(book.Author, max(10, v.width/3)))
+		if i == v.bookCursor {
+			lines = append(lines, styles.SecondaryText.Render("▸ ")+styles.SecondaryText.Bold(true).Render(title)+author)
+		} else {
+			lines = append(lines, "  "+styles.MutedText.Render(title)+author)
+		}
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	return b.String()
+}
+
+// renderCollectionBooksFooter renders the footer while a collection is open
+func (v *CollectionsView) renderCollectionBooksFooter() string {
+	if v.statusMsg != "" {
+		return styles.SecondaryText.Render(v.statusMsg)
+	}
+	help := []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" open"),
+		styles.HelpKey.Render("i") + styles.Help.Render(" info"),
+		styles.HelpKey.Render("d") + styles.Help.Render(" remove"),
+		styles.HelpKey.Render("esc") + styles.Help.Render(" collections"),
+	}
+	return strings.Join(help, "  ")
+}
+
+// CapturingKeys implements KeyCapturer: the name prompt takes every key, and
+// esc leaves it rather than the collections view
+func (v *CollectionsView) CapturingKeys() bool {
+	return v.createMode
+}
+
+// HandlingBack implements BackHandler: q and esc leave an open collection, not
+// the collections view
+func (v *CollectionsView) HandlingBack() bool {
+	return v.open != nil
+}
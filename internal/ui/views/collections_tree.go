@@ -0,0 +1,124 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// collectionRow is a collection as listed in the tree
+type collectionRow struct {
+	collection  models.Collection
+	depth       int
+	hasChildren bool
+}
+
+// childCollections groups collections by parent ID. Collections whose parent is
+// missing are listed at the top level.
+func (v *CollectionsView) childCollections() map[string][]models.Collection {
+	known := make(map[string]bool, len(v.collections))
+	for _, col := range v.collections {
+		known[col.ID] = true
+	}
+	children := make(map[string][]models.Collection)
+	for _, col := range v.collections {
+		parent := col.ParentID
+		if !known[parent] || parent == col.ID {
+			parent = ""
+		}
+		children[parent] = append(children[parent], col)
+	}
+	return children
+}
+
+// treeRows returns the collections to list: top-level ones, each followed by its
+// children when expanded
+func (v *CollectionsView) treeRows() []collectionRow {
+	children := v.childCollections()
+	var rows []collectionRow
+	visited := make(map[string]bool)
+	var walk func(parentID string, depth int)
+	walk = func(parentID string, depth int) {
+		for _, col := range children[parentID] {
+			if visited[col.ID] {
+				continue // Parent cycle
+			}
+			visited[col.ID] = true
+			rows = append(rows, collectionRow{collection: col, depth: depth, hasChildren: len(children[col.ID]) > 0})
+			if v.expanded[col.ID] {
+				walk(col.ID, depth+1)
+			}
+		}
+	}
+	walk("", 0)
+	return rows
+}
+
+// selectedRow returns the collection row under the cursor, if it is on one
+func (v *CollectionsView) selectedRow() (collectionRow, bool) {
+	rows := v.treeRows()
+	i := v.cursor - len(v.smartCollections())
+	if i >= 0 && i < len(rows) {
+		return rows[i], true
+	}
+	return collectionRow{}, false
+}
+
+// expandSelected shows the children of the collection under the cursor
+func (v *CollectionsView) expandSelected() {
+	if row, ok := v.selectedRow(); ok && row.hasChildren {
+		v.expanded[row.collection.ID] = true
+	}
+}
+
+// collapseSelected hides the children of the collection under the cursor, or moves
+// to its parent if they are already hidden
+func (v *CollectionsView) collapseSelected() {
+	row, ok := v.selectedRow()
+	if !ok {
+		return
+	}
+	if v.expanded[row.collection.ID] {
+		delete(v.expanded, row.collection.ID)
+		return
+	}
+	for i, parent := range v.treeRows() {
+		if parent.collection.ID == row.collection.ParentID {
+			v.cursor = len(v.smartCollections()) + i
+			return
+		}
+	}
+}
+
+// breadcrumbs returns the path to a collection, e.g. "Collections › Comics › Manga"
+func (v *CollectionsView) breadcrumbs(collection models.Collection) string {
+	byID := make(map[string]models.Collection, len(v.collections))
+	for _, col := range v.collections {
+		byID[col.ID] = col
+	}
+	path := []string{truncateText(collection.Name, 30)}
+	seen := map[string]bool{collection.ID: true}
+	for parent, ok := byID[collection.ParentID]; ok && !seen[parent.ID]; parent, ok = byID[parent.ParentID] {
+		seen[parent.ID] = true
+		path = append([]string{truncateText(parent.Name, 20)}, path...)
+	}
+	return "Collections › " + strings.Join(path, " › ")
+}
+
+// renderTreeRow renders a collection indented under its parent, with an
+// expand/collapse marker when it has children
+func (v *CollectionsView) renderTreeRow(row collectionRow, selected bool) string {
+	marker := "  "
+	if row.hasChildren {
+		marker = "+ "
+		if v.expanded[row.collection.ID] {
+			marker = "- "
+		}
+	}
+	indent := strings.Repeat("  ", row.depth)
+	if selected {
+		return styles.SecondaryText.Render("▸ ") + indent + styles.MutedText.Render(marker) + styles.SecondaryText.Bold(true).Render(row.collection.Name)
+	}
+	return "  " + indent + styles.MutedText.Render(marker+row.collection.Name)
+}
@@ -1,32 +1,39 @@
 package views
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
+	"math"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/cache"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/pdf"
 	"github.com/justyntemme/webby-t/internal/ui/styles"
 	"github.com/justyntemme/webby-t/internal/ui/terminal"
 	"github.com/justyntemme/webby-t/pkg/models"
 )
 
-// Zoom levels available
-var zoomLevels = []float64{1.0, 1.5, 2.0, 3.0, 4.0}
-
 // ComicView displays comic pages with image rendering
 type ComicView struct {
-	client *api.Client
+	client    *api.Client
+	config    *config.Config
+	pageCache *cache.DiskCache // On-disk page cache (nil if unavailable)
 
 	// Book info
 	book      models.Book
 	pageCount int
+	pdfPath   string // Downloaded file when viewing a PDF ("" for comic archives)
 
 	// Current state
 	currentPage int
@@ -37,12 +44,84 @@ type ComicView struct {
 	imageData   []byte
 	imageType   string
 	imageLoaded bool
-	decodedImg  image.Image // Cached decoded image for zoom/pan
+	decodedImg  image.Image    // Last decoded image (both pages in a spread), reused for zoom/pan
+	decodedKey  comicDecodeKey // What decodedImg holds
+
+	// Pages are decoded and encoded in the background; results are cached by
+	// page, viewport and size
+	renderCache  map[comicRenderKey]string
+	rendering    bool   // A background render is running
+	lastRendered string // Shown until the render for the current state finishes
+
+	// Two-page spread: the page shown beside currentPage on wide terminals
+	spreadEnabled bool
+	partnerPage   int // 0 when currentPage is shown alone
+	partnerData   []byte
+	partnerLoaded bool
+
+	// Auto-split: wide (two-page) scans are shown one half at a time
+	splitEnabled    bool
+	pageWidth       int // Current page size in pixels, before rotation
+	pageHeight      int
+	splitHalf       int  // 0 for the first half in reading order, 1 for the second
+	startOnLastHalf bool // Show the second half once the page loads (turning back)
 
 	// Zoom and pan state
-	zoomIndex int     // Index into zoomLevels
+	zoom      float64 // Magnification, minZoom-maxZoom
 	panX      float64 // Pan position as fraction (0.0 = left, 1.0 = right)
 	panY      float64 // Pan position as fraction (0.0 = top, 1.0 = bottom)
+	zoomMode  bool    // Typing a zoom percentage in the footer
+	zoomInput numberPrompt
+
+	// Page thumbnail grid
+	showThumbs   bool
+	thumbCursor  int            // 0-indexed page under the cursor
+	thumbOffset  int            // First visible grid row
+	thumbCache   map[int]string // Rendered thumbnails by page
+	thumbLoading map[int]bool
+
+	// Bookmarks overlay
+	showBookmarks    bool
+	bookmarkCursor   int
+	bookmarkSort     bookmarkSort
+	bookmarkEditing  bool // Renaming the selected bookmark or editing its note
+	bookmarkEditNote bool // Whether the edit is for the note rather than the name
+	bookmarkInput    textinput.Model
+	statusMsg        string // Temporary status message shown in the footer
+
+	// Go-to-page prompt
+	gotoMode  bool
+	gotoInput numberPrompt
+
+	// Manga mode: pages read right to left (persisted per book)
+	rightToLeft bool
+
+	// How pages fit the screen: config.FitPage, FitWidth or FitHeight (persisted per book)
+	fitMode string
+
+	// Clockwise quarter turns applied to every page (persisted per book)
+	rotation int
+
+	// Brightness/contrast/gamma applied before encoding (persisted per book)
+	adjust       pageAdjust
+	adjusting    bool // Adjustment panel open in the footer
+	adjustCursor int  // Selected control in the panel
+
+	// Webtoon mode: pages stitched into a vertical strip (persisted per book)
+	webtoon      bool
+	stripOffset  int                 // Pixels scrolled into currentPage
+	stripPages   map[int]image.Image // Decoded pages scaled to stripWidth
+	stripLoading map[int]bool
+	// Fraction of the resumed page to scroll to once it loads
+	pendingStripPos float64
+
+	// Slideshow: pages turn automatically every few seconds
+	slideshow bool
+	slideID   int // Ignores ticks from a stopped or restarted timer
+
+	// Reading session for the history log
+	sessionStart     time.Time
+	sessionStartPage int
 
 	// Terminal capabilities
 	termMode terminal.TermImageMode
@@ -53,13 +132,23 @@ type ComicView struct {
 }
 
 // NewComicView creates a new comic viewer
-func NewComicView(client *api.Client) *ComicView {
+func NewComicView(client *api.Client, cfg *config.Config, pageCache *cache.DiskCache) *ComicView {
+	bookmarkInput := newTextInput()
+	bookmarkInput.Width = 30
+
 	return &ComicView{
-		client:      client,
-		currentPage: 1,
-		width:       80,
-		height:      24,
-		termMode:    terminal.DetectTerminalMode(),
+		client:        client,
+		config:        cfg,
+		pageCache:     pageCache,
+		currentPage:   1,
+		spreadEnabled: true,
+		splitEnabled:  true,
+		bookmarkInput: bookmarkInput,
+		gotoInput:     newNumberPrompt(maxPageDigits),
+		zoomInput:     newNumberPrompt(maxZoomDigits),
+		width:         80,
+		height:        24,
+		termMode:      terminal.DetectTerminalMode(),
 	}
 }
 
@@ -67,36 +156,120 @@ func NewComicView(client *api.Client) *ComicView {
 func (v *ComicView) SetBook(book models.Book) {
 	v.book = book
 	v.currentPage = 1
-	v.imageData = nil
-	v.imageLoaded = false
-	v.decodedImg = nil
+	v.pageCount = 0
+	v.pdfPath = ""
+	v.clearPageImages()
+	v.resetRenderCache()
 	v.err = nil
 	v.resetZoomPan()
+	v.showThumbs = false
+	v.thumbOffset = 0
+	v.thumbCache = make(map[int]string)
+	v.thumbLoading = make(map[int]bool)
+	v.showBookmarks = false
+	v.bookmarkEditing = false
+	v.statusMsg = ""
+	v.gotoMode = false
+	v.zoomMode = false
+	v.slideshow = false
+	v.stripOffset = 0
+	v.pendingStripPos = 0
+	v.stripPages = make(map[int]image.Image)
+	v.stripLoading = make(map[int]bool)
+	v.rightToLeft = false
+	v.fitMode = config.FitPage
+	v.webtoon = false
+	v.rotation = 0
+	v.adjusting = false
+	v.loadAdjust(0, 0, 0)
+	if v.config != nil {
+		settings := v.config.GetComicSettings(book.ID)
+		v.rightToLeft = v.config.BookRightToLeft(book.ID)
+		v.fitMode = v.config.BookFitMode(book.ID)
+		v.webtoon = settings.Webtoon
+		v.rotation = settings.Rotation
+		v.loadAdjust(settings.Brightness, settings.Contrast, settings.Gamma)
+	}
+	v.sessionStart = time.Now()
+	v.sessionStartPage = v.currentPage
+}
+
+// ResumeSession starts a new reading session at the current page, e.g. after
+// logging in again. Renders that finished while another view was open went to
+// that view, so rendering starts over.
+func (v *ComicView) ResumeSession() {
+	v.sessionStart = time.Now()
+	v.sessionStartPage = v.currentPage
+	v.resetRenderCache()
+}
+
+// EndSession records the current reading session in the history log
+func (v *ComicView) EndSession() {
+	if v.config == nil || v.sessionStart.IsZero() {
+		return
+	}
+	session := config.ReadingSession{
+		BookID:       v.book.ID,
+		Title:        v.book.Title,
+		StartedAt:    v.sessionStart,
+		EndedAt:      time.Now(),
+		StartChapter: v.sessionStartPage,
+		EndChapter:   v.currentPage,
+		Comic:        true,
+	}
+	v.sessionStart = time.Time{}
+	if session.Duration() >= minSessionDuration {
+		_ = v.config.AddReadingSession(session)
+	}
+}
+
+// SavePositionOnExit saves the current page (called when leaving the comic viewer)
+func (v *ComicView) SavePositionOnExit() error {
+	if v.book.ID == "" || v.pageCount == 0 {
+		return nil
+	}
+	page, position := v.currentPosition()
+	if v.config != nil {
+		_ = v.config.SetProgress(v.book.ID, float64(v.currentPage)/float64(v.pageCount))
+	}
+	return v.client.SavePosition(v.book.ID, page, position)
+}
+
+// currentPosition returns the 0-indexed page and, in webtoon mode,
+// the fraction of it scrolled past
+func (v *ComicView) currentPosition() (string, float64) {
+	position := 0.0
+	if img, ok := v.stripPages[v.currentPage]; ok && v.webtoon {
+		position = float64(v.stripOffset) / float64(max(1, img.Bounds().Dy()))
+	}
+	return strconv.Itoa(v.currentPage - 1), position
 }
 
 // resetZoomPan resets zoom and pan to default
 func (v *ComicView) resetZoomPan() {
-	v.zoomIndex = 0
+	v.zoom = minZoom
 	v.panX = 0.5 // Center
 	v.panY = 0.5 // Center
+	if v.fitMode == config.FitWidth {
+		v.panY = 0 // Top of a page taller than the screen
+	}
 }
 
 // currentZoom returns the current zoom level
 func (v *ComicView) currentZoom() float64 {
-	if v.zoomIndex >= 0 && v.zoomIndex < len(zoomLevels) {
-		return zoomLevels[v.zoomIndex]
-	}
-	return 1.0
+	return math.Max(minZoom, v.zoom)
 }
 
 // isZoomed returns true if currently zoomed in
 func (v *ComicView) isZoomed() bool {
-	return v.zoomIndex > 0
+	return v.currentZoom() > minZoom
 }
 
 // comicPagesLoadedMsg is sent when page count is retrieved
 type comicPagesLoadedMsg struct {
 	pageCount int
+	position  *models.ReadingPosition // Saved position to resume from (nil if none)
+	pdfPath   string
 	err       error
 }
 
@@ -116,13 +289,31 @@ func (v *ComicView) Init() tea.Cmd {
 
 // Update implements View
 func (v *ComicView) Update(msg tea.Msg) (View, tea.Cmd) {
+	view, cmd := v.update(msg)
+	// Render whatever is now on screen in the background if it isn't cached
+	return view, tea.Batch(cmd, v.ensureRendered())
+}
+
+// update dispatches a message to its handler
+func (v *ComicView) update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		v.statusMsg = "" // Clear transient messages on any key
 		return v.handleKeyMsg(msg)
+	case tea.MouseMsg:
+		return v.handleMouse(msg)
 	case comicPagesLoadedMsg:
 		return v.handlePagesLoaded(msg)
 	case comicPageLoadedMsg:
 		return v.handlePageLoaded(msg)
+	case comicThumbsLoadedMsg:
+		return v.handleThumbsLoaded(msg)
+	case comicStripPageLoadedMsg:
+		return v.handleStripPageLoaded(msg)
+	case comicSlideTickMsg:
+		return v.handleSlideTick(msg)
+	case comicRenderedMsg:
+		return v.handleRendered(msg)
 	}
 	return v, nil
 }
@@ -131,12 +322,39 @@ func (v *ComicView) Update(msg tea.Msg) (View, tea.Cmd) {
 func (v *ComicView) handleKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 	key := msg.String()
 
+	if v.showThumbs {
+		return v.updateThumbs(msg)
+	}
+	if v.showBookmarks {
+		return v.updateBookmarks(msg)
+	}
+	if v.gotoMode {
+		return v.updateGotoPrompt(msg)
+	}
+	if v.zoomMode {
+		return v.updateZoomPrompt(msg)
+	}
+	if v.adjusting {
+		return v.updateAdjust(msg)
+	}
+
 	// Exit
 	if key == "q" || key == "esc" {
 		terminal.ClearImagesCmd(v.termMode)()
 		return v, SwitchTo(ViewLibrary)
 	}
 
+	// The vertical strip scrolls instead of zooming and panning
+	if v.webtoon {
+		if cmd, ok := v.updateStrip(key); ok {
+			return v, cmd
+		}
+		switch key {
+		case "+", "=", "-", "_", "0", "z":
+			return v, nil
+		}
+	}
+
 	// Zoom controls (+ zooms in, - zooms out)
 	switch key {
 	case "+", "=":
@@ -148,29 +366,100 @@ func (v *ComicView) handleKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 	case "0":
 		v.resetZoomPan()
 		return v, nil
+	case "z":
+		return v, v.openZoomPrompt()
+	case "L":
+		v.toggleZoomLock()
+		return v, nil
+	case "e":
+		v.toggleEInk()
+		return v, nil
+	case "m":
+		v.toggleRightToLeft()
+		return v, nil
+	case "f":
+		v.cycleFitMode()
+		return v, nil
+	case "s":
+		return v, v.toggleSpread()
+	case "S":
+		v.toggleSplit()
+		return v, nil
+	case "t":
+		return v, v.openThumbs()
+	case "w":
+		return v, v.toggleWebtoon()
+	case "B":
+		v.addBookmark()
+		return v, nil
+	case "b":
+		v.openBookmarks()
+		return v, nil
+	case ":":
+		return v, v.openGotoPrompt()
+	case "r":
+		return v, v.rotate(1)
+	case "R":
+		return v, v.rotate(-1)
+	case "a":
+		v.adjusting = true
+		return v, nil
+	case "P":
+		return v, v.toggleSlideshow()
+	case "<":
+		return v, v.adjustSlideshow(-1)
+	case ">":
+		return v, v.adjustSlideshow(1)
 	}
 
-	// Arrow keys always pan the viewport (scroll within zoomed image)
+	// Left/right turn pages at 1x (in reading direction) and pan when zoomed
 	switch key {
 	case "left":
-		v.panLeft()
+		if !v.isZoomed() {
+			return v, v.pageLeft()
+		}
+		v.panLeft(false)
 		return v, nil
 	case "right":
-		v.panRight()
+		if !v.isZoomed() {
+			return v, v.pageRight()
+		}
+		v.panRight(false)
 		return v, nil
+	}
+
+	// Up/down always pan the viewport (scroll within zoomed image);
+	// shift pans in finer steps
+	switch key {
 	case "up":
-		v.panUp()
+		v.panUp(false)
 		return v, nil
 	case "down":
-		v.panDown()
+		v.panDown(false)
+		return v, nil
+	case "shift+left":
+		v.panLeft(true)
+		return v, nil
+	case "shift+right":
+		v.panRight(true)
+		return v, nil
+	case "shift+up":
+		v.panUp(true)
+		return v, nil
+	case "shift+down":
+		v.panDown(true)
 		return v, nil
 	}
 
-	// Vim keys (h/j/k/l) navigate pages
+	// Vim keys (h/j/k/l) navigate pages; h/l follow the reading direction
 	switch key {
-	case "l", "j", "n", " ", "pgdown":
+	case "l":
+		return v, v.pageRight()
+	case "h":
+		return v, v.pageLeft()
+	case "j", "n", " ", "pgdown":
 		return v, v.nextPage()
-	case "h", "k", "p", "pgup":
+	case "k", "p", "pgup":
 		return v, v.prevPage()
 	}
 
@@ -185,100 +474,164 @@ func (v *ComicView) handleKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 	return v, nil
 }
 
-// Zoom methods
-func (v *ComicView) zoomIn() {
-	if v.zoomIndex < len(zoomLevels)-1 {
-		v.zoomIndex++
-	}
-}
+// finePanDivisor shrinks the pan step while shift is held
+const finePanDivisor = 4
 
-func (v *ComicView) zoomOut() {
-	if v.zoomIndex > 0 {
-		v.zoomIndex--
-		// Reset pan to center when zooming out to 1x
-		if v.zoomIndex == 0 {
-			v.panX = 0.5
-			v.panY = 0.5
-		}
+// panStep returns how far one pan moves, as a fraction of the pan range.
+// The configured step is a share of the visible area, and the pan range is
+// (zoom-1) visible areas wide, so steps get smaller as zoom increases.
+func (v *ComicView) panStep(fine bool) float64 {
+	step := config.DefaultPanStepPercent / 100.0
+	if v.config != nil {
+		step = v.config.GetPanStep()
+	}
+	if zoom := v.currentZoom(); zoom > 1 {
+		step /= zoom - 1
+	}
+	if fine {
+		step /= finePanDivisor
 	}
+	return math.Min(step, 1)
 }
 
-// Pan methods (move in 10% increments)
-const panStep = 0.1
-
-func (v *ComicView) panLeft() {
-	v.panX -= panStep
+// Pan methods
+func (v *ComicView) panLeft(fine bool) {
+	v.panX -= v.panStep(fine)
 	if v.panX < 0 {
 		v.panX = 0
 	}
 }
 
-func (v *ComicView) panRight() {
-	v.panX += panStep
+func (v *ComicView) panRight(fine bool) {
+	v.panX += v.panStep(fine)
 	if v.panX > 1 {
 		v.panX = 1
 	}
 }
 
-func (v *ComicView) panUp() {
-	v.panY -= panStep
+func (v *ComicView) panUp(fine bool) {
+	v.panY -= v.panStep(fine)
 	if v.panY < 0 {
 		v.panY = 0
 	}
 }
 
-func (v *ComicView) panDown() {
-	v.panY += panStep
+func (v *ComicView) panDown(fine bool) {
+	v.panY += v.panStep(fine)
 	if v.panY > 1 {
 		v.panY = 1
 	}
 }
 
+// toggleRightToLeft switches manga page order and remembers it for this book
+func (v *ComicView) toggleRightToLeft() {
+	v.rightToLeft = !v.rightToLeft
+	v.decodedImg = nil // Spread order flips
+	if v.config == nil {
+		return
+	}
+	if err := v.config.SetBookRightToLeft(v.book.ID, v.rightToLeft); err != nil {
+		v.err = fmt.Errorf("failed to save manga mode: %w", err)
+	}
+}
+
+// pageLeft turns to the page on the left: the next page when reading right to left
+func (v *ComicView) pageLeft() tea.Cmd {
+	if v.rightToLeft {
+		return v.nextPage()
+	}
+	return v.prevPage()
+}
+
+// pageRight turns to the page on the right: the previous page when reading right to left
+func (v *ComicView) pageRight() tea.Cmd {
+	if v.rightToLeft {
+		return v.prevPage()
+	}
+	return v.nextPage()
+}
+
 // Page navigation methods
 func (v *ComicView) nextPage() tea.Cmd {
-	if v.currentPage < v.pageCount {
-		v.currentPage++
-		v.imageLoaded = false
-		v.decodedImg = nil
-		v.resetZoomPan()
-		return v.loadPage(v.currentPage)
+	if v.turnHalf(1) {
+		return nil
+	}
+	step := 1
+	if v.partnerPage != 0 {
+		step = 2
+	}
+	if v.currentPage+step <= v.pageCount {
+		return v.goToPage(v.currentPage + step)
 	}
 	return nil
 }
 
 func (v *ComicView) prevPage() tea.Cmd {
+	if v.turnHalf(0) {
+		return nil
+	}
 	if v.currentPage > 1 {
-		v.currentPage--
-		v.imageLoaded = false
-		v.decodedImg = nil
-		v.resetZoomPan()
-		return v.loadPage(v.currentPage)
+		cmd := v.goToPage(v.currentPage - 1)
+		v.startOnLastHalf = true
+		return cmd
 	}
 	return nil
 }
 
 func (v *ComicView) firstPage() tea.Cmd {
 	if v.currentPage != 1 {
-		v.currentPage = 1
-		v.imageLoaded = false
-		v.decodedImg = nil
-		v.resetZoomPan()
-		return v.loadPage(v.currentPage)
+		return v.goToPage(1)
 	}
 	return nil
 }
 
 func (v *ComicView) lastPage() tea.Cmd {
-	if v.currentPage != v.pageCount && v.pageCount > 0 {
-		v.currentPage = v.pageCount
-		v.imageLoaded = false
-		v.decodedImg = nil
-		v.resetZoomPan()
-		return v.loadPage(v.currentPage)
+	if v.pageCount > 0 && spreadStart(v.pageCount) != v.currentPage {
+		return v.goToPage(v.pageCount)
 	}
 	return nil
 }
 
+// goToPage shows page (or the spread containing it) and loads its image(s)
+func (v *ComicView) goToPage(page int) tea.Cmd {
+	page = max(1, min(page, v.pageCount))
+	v.pendingStripPos = 0
+	v.startOnLastHalf = false
+	if v.webtoon {
+		v.currentPage = page
+		v.stripOffset = 0
+		return v.loadStripPages()
+	}
+	if v.spreadFits() {
+		page = spreadStart(page)
+	}
+	v.currentPage = page
+	v.clearPageImages()
+	v.partnerPage = v.spreadPartner(page)
+	v.resetPageZoomPan()
+	return v.loadVisiblePages()
+}
+
+// clearPageImages drops the loaded page image(s)
+func (v *ComicView) clearPageImages() {
+	v.imageData = nil
+	v.imageLoaded = false
+	v.decodedImg = nil
+	v.partnerPage = 0
+	v.partnerData = nil
+	v.partnerLoaded = false
+	v.pageWidth, v.pageHeight = 0, 0
+	v.splitHalf = 0
+}
+
+// loadVisiblePages loads the current page and its spread partner, if any
+func (v *ComicView) loadVisiblePages() tea.Cmd {
+	if v.partnerPage == 0 {
+		return v.loadPage(v.currentPage)
+	}
+	return tea.Batch(v.loadPage(v.currentPage), v.loadPage(v.partnerPage))
+}
+
 // Message handlers
 func (v *ComicView) handlePagesLoaded(msg comicPagesLoadedMsg) (View, tea.Cmd) {
 	v.loading = false
@@ -287,11 +640,24 @@ func (v *ComicView) handlePagesLoaded(msg comicPagesLoadedMsg) (View, tea.Cmd) {
 		return v, nil
 	}
 	v.pageCount = msg.pageCount
-	return v, v.loadPage(1)
+	v.pdfPath = msg.pdfPath
+
+	// Resume at the saved page (stored 0-indexed, like the page API)
+	page, offset := 1, 0.0
+	if msg.position != nil {
+		if saved, err := strconv.Atoi(msg.position.Chapter); err == nil && saved >= 0 && saved < v.pageCount {
+			page, offset = saved+1, msg.position.Position
+		}
+	}
+	v.sessionStartPage = page
+	cmd := v.goToPage(page)
+	v.pendingStripPos = offset
+	return v, cmd
 }
 
 func (v *ComicView) handlePageLoaded(msg comicPageLoadedMsg) (View, tea.Cmd) {
-	if msg.page == v.currentPage {
+	switch msg.page {
+	case v.currentPage:
 		if msg.err != nil {
 			v.err = msg.err
 			return v, nil
@@ -299,73 +665,99 @@ func (v *ComicView) handlePageLoaded(msg comicPageLoadedMsg) (View, tea.Cmd) {
 		v.imageData = msg.data
 		v.imageType = msg.imageType
 		v.imageLoaded = true
-		v.decodedImg = nil // Will be decoded on render
+		v.decodedImg = nil // Will be decoded in the background
 		v.err = nil
+		v.readPageSize(msg.data)
+		if v.startOnLastHalf && v.showingSplit() {
+			v.splitHalf = 1
+		}
+		v.startOnLastHalf = false
+		return v, v.prefetchPages()
+	case v.partnerPage:
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		v.partnerData = msg.data
+		v.partnerLoaded = true
+		v.decodedImg = nil
 	}
 	return v, nil
 }
 
 // View implements View
 func (v *ComicView) View() string {
-	var b strings.Builder
-
-	// Header
-	b.WriteString(v.renderHeader() + "\n")
-
-	// Content area
-	contentHeight := v.height - 4 // Header + footer + margins
+	if v.showThumbs {
+		return v.renderThumbs()
+	}
+	if v.showBookmarks {
+		return v.renderBookmarks()
+	}
 
-	if v.loading {
-		content := lipgloss.Place(
-			v.width,
-			contentHeight,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render("Loading comic..."),
-		)
-		b.WriteString(content)
-	} else if v.err != nil {
-		content := lipgloss.Place(
-			v.width,
-			contentHeight,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.ErrorStyle.Render("Error: "+v.err.Error()),
-		)
-		b.WriteString(content)
-	} else if v.termMode == terminal.TermModeNone {
+	header := v.renderHeader()
+	footer := v.renderFooter()
+	switch {
+	case v.gotoMode:
+		footer = v.renderGotoPrompt()
+	case v.zoomMode:
+		footer = v.renderZoomPrompt()
+	case v.adjusting:
+		footer = v.renderAdjustFooter()
+	case v.statusMsg != "":
+		footer = styles.SecondaryText.Render(v.statusMsg)
+	case v.webtoon:
+		footer = v.renderStripFooter()
+	}
+	contentHeight := styles.ContentHeight(v.height)
+
+	var content string
+	switch {
+	case v.loading:
+		content = styles.RenderCenteredContent(styles.MutedText.Render("Loading comic..."), v.width, contentHeight)
+	case v.err != nil:
+		content = styles.RenderCenteredContent(styles.ErrorStyle.Render("Error: "+v.err.Error()), v.width, contentHeight)
+	case v.termMode == terminal.TermModeNone:
 		// No image protocol support
-		content := lipgloss.Place(
-			v.width,
-			contentHeight,
-			lipgloss.Center,
-			lipgloss.Center,
+		content = styles.RenderCenteredContent(
 			styles.MutedText.Render("Terminal does not support images.\n\nSupported terminals: Kitty, iTerm2, or Sixel-capable terminals."),
+			v.width, contentHeight,
 		)
-		b.WriteString(content)
-	} else if !v.imageLoaded {
-		content := lipgloss.Place(
-			v.width,
-			contentHeight,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render(fmt.Sprintf("Loading page %d...", v.currentPage)),
-		)
-		b.WriteString(content)
-	} else {
-		// Render the image
-		imageStr := v.renderImage()
-		b.WriteString(imageStr)
+	case v.webtoon:
+		if _, ok := v.stripPages[v.currentPage]; !ok {
+			content = styles.RenderCenteredContent(styles.MutedText.Render(fmt.Sprintf("Loading page %d...", v.currentPage)), v.width, contentHeight)
+			break
+		}
+		if v.imageInLayout() {
+			content = styles.RenderCenteredContent(v.renderImage(), v.width, contentHeight)
+			break
+		}
+		return styles.HeaderBar.Width(v.width).Render(header) + "\n" +
+			v.renderImage() + "\n" +
+			styles.FooterBar.Width(v.width).Render(footer)
+	case (!v.imageLoaded || (v.partnerPage != 0 && !v.partnerLoaded)) && !v.holdingPage():
+		content = styles.RenderCenteredContent(styles.MutedText.Render(fmt.Sprintf("Loading page %s...", v.pageLabel())), v.width, contentHeight)
+	case v.imageInLayout():
+		// Half-blocks and placed Kitty images occupy a known block of cells,
+		// so they go through the normal layout
+		content = styles.RenderCenteredContent(v.renderImage(), v.width, contentHeight)
+	default:
+		// Image escape sequences must not pass through lipgloss width/height
+		// handling, so the page is placed between the shared header and footer bars
+		return styles.HeaderBar.Width(v.width).Render(header) + "\n" +
+			v.renderImage() + "\n" +
+			styles.FooterBar.Width(v.width).Render(footer)
 	}
 
-	// Footer
-	b.WriteString("\n")
-	b.WriteString(v.renderFooter())
+	return styles.RenderLayout(header, content, footer, v.width, v.height)
+}
 
-	return b.String()
+// imageInLayout reports whether rendered pages have a known size in cells and can be
+// centered like text, rather than drawn at their own size between the header and footer
+func (v *ComicView) imageInLayout() bool {
+	return v.termMode == terminal.TermModeBlocks || v.termMode == terminal.TermModeKitty
 }
 
-// renderHeader renders the header bar with proper truncation
+// renderHeader renders the header content with proper truncation
 func (v *ComicView) renderHeader() string {
 	// Title (unicode-safe truncation)
 	maxTitleWidth := 40
@@ -373,71 +765,44 @@ func (v *ComicView) renderHeader() string {
 		maxTitleWidth = v.width / 2
 	}
 	title := styles.TruncateText(v.book.Title, maxTitleWidth)
-	titlePart := styles.BookTitle.Render(title)
 
 	// Page and zoom indicator
-	rightPart := ""
+	pageStr := ""
 	if v.pageCount > 0 {
-		pageStr := fmt.Sprintf("%d/%d", v.currentPage, v.pageCount)
-		if v.isZoomed() {
-			zoomPct := int(v.currentZoom() * 100)
-			pageStr += fmt.Sprintf(" [%d%%]", zoomPct)
+		pageStr = v.pageLabel()
+		if v.rightToLeft {
+			pageStr = "RTL " + pageStr
 		}
-		rightPart = styles.MutedText.Render(pageStr)
-	}
-
-	// Combine
-	gap := v.width - lipgloss.Width(titlePart) - lipgloss.Width(rightPart)
-	if gap < 0 {
-		gap = 0
-	}
-
-	return titlePart + strings.Repeat(" ", gap) + rightPart
-}
-
-// renderImage renders the current page image to the terminal
-func (v *ComicView) renderImage() string {
-	if len(v.imageData) == 0 {
-		return styles.MutedText.Render("No image data")
-	}
-
-	// Decode and cache the image if not already done
-	if v.decodedImg == nil {
-		img, _, err := image.Decode(bytes.NewReader(v.imageData))
-		if err != nil {
-			return styles.ErrorStyle.Render("Failed to decode image: " + err.Error())
+		if v.webtoon {
+			pageStr = "strip " + pageStr
+		}
+		if rotation := v.rotationLabel(); rotation != "" {
+			pageStr = rotation + " " + pageStr
+		}
+		if v.slideshow {
+			pageStr = "▶ " + pageStr
+		}
+		if v.einkMode() {
+			pageStr = "e-ink " + pageStr
+		}
+		if v.zoomLocked() {
+			pageStr += fmt.Sprintf(" [%d%% locked]", v.zoomPercent())
+		} else if v.isZoomed() {
+			pageStr += fmt.Sprintf(" [%d%%]", v.zoomPercent())
 		}
-		v.decodedImg = img
-	}
-
-	// Get the image to render (possibly cropped for zoom)
-	imgToRender := v.getViewportImage()
-
-	// Clear previous image before rendering new one (prevents zoom artifacts)
-	clearSeq := terminal.ClearComicImage(v.termMode)
-
-	// Use shared utility to render the image with stable ID for targeted clearing
-	imgStr, renderErr := terminal.RenderImageToString(imgToRender, v.termMode, terminal.ComicImageID)
-	if renderErr != nil {
-		return styles.ErrorStyle.Render("Render error: " + renderErr.Error())
 	}
 
-	return clearSeq + imgStr
+	return styles.HeaderContent(title, pageStr, v.width)
 }
 
-// getViewportImage returns the portion of the image visible at current zoom/pan
-func (v *ComicView) getViewportImage() image.Image {
-	if v.decodedImg == nil {
-		return nil
-	}
-
-	zoom := v.currentZoom()
+// viewportImage returns the portion of img visible at the given zoom and pan
+func viewportImage(img image.Image, zoom, panX, panY float64) image.Image {
 	if zoom <= 1.0 {
 		// No zoom, return full image
-		return v.decodedImg
+		return img
 	}
 
-	bounds := v.decodedImg.Bounds()
+	bounds := img.Bounds()
 	imgWidth := bounds.Dx()
 	imgHeight := bounds.Dy()
 
@@ -450,8 +815,8 @@ func (v *ComicView) getViewportImage() image.Image {
 	maxOffsetX := imgWidth - viewWidth
 	maxOffsetY := imgHeight - viewHeight
 
-	offsetX := int(v.panX * float64(maxOffsetX))
-	offsetY := int(v.panY * float64(maxOffsetY))
+	offsetX := int(panX * float64(maxOffsetX))
+	offsetY := int(panY * float64(maxOffsetY))
 
 	// Clamp offsets
 	if offsetX < 0 {
@@ -472,7 +837,7 @@ func (v *ComicView) getViewportImage() image.Image {
 		SubImage(r image.Rectangle) image.Image
 	}
 
-	if si, ok := v.decodedImg.(subImager); ok {
+	if si, ok := img.(subImager); ok {
 		cropRect := image.Rect(
 			bounds.Min.X+offsetX,
 			bounds.Min.Y+offsetY,
@@ -483,19 +848,19 @@ func (v *ComicView) getViewportImage() image.Image {
 	}
 
 	// Fallback: return full image if SubImage not supported
-	return v.decodedImg
+	return img
 }
 
-// renderFooter renders the footer help with consistent styling
+// renderFooter renders the footer help content
 func (v *ComicView) renderFooter() string {
 	var help []string
 
 	if v.isZoomed() {
 		// Zoomed mode: show pan and zoom controls
-		zoomPct := int(v.currentZoom() * 100)
 		help = []string{
 			styles.HelpKey.Render("←→↑↓") + styles.Help.Render(" pan"),
-			styles.HelpKey.Render("+/-") + styles.Help.Render(fmt.Sprintf(" zoom (%d%%)", zoomPct)),
+			styles.HelpKey.Render("+/-") + styles.Help.Render(fmt.Sprintf(" zoom (%d%%)", v.zoomPercent())),
+			styles.HelpKey.Render("z") + styles.Help.Render(" set zoom"),
 			styles.HelpKey.Render("0") + styles.Help.Render(" reset"),
 			styles.HelpKey.Render("hjkl") + styles.Help.Render(" page"),
 			styles.HelpKey.Render("[]") + styles.Help.Render(" first/last"),
@@ -506,13 +871,28 @@ func (v *ComicView) renderFooter() string {
 		help = []string{
 			styles.HelpKey.Render("hjkl") + styles.Help.Render(" prev/next"),
 			styles.HelpKey.Render("[]") + styles.Help.Render(" first/last"),
+			styles.HelpKey.Render(":") + styles.Help.Render(" go to"),
+			styles.HelpKey.Render("t") + styles.Help.Render(" pages"),
+			styles.HelpKey.Render("b") + styles.Help.Render(" bookmarks"),
 			styles.HelpKey.Render("+/-") + styles.Help.Render(" zoom"),
-			styles.HelpKey.Render("←→↑↓") + styles.Help.Render(" pan"),
-			styles.HelpKey.Render("q") + styles.Help.Render(" back"),
+			styles.HelpKey.Render("m") + styles.Help.Render(" "+v.readingOrderLabel()),
+			styles.HelpKey.Render("f") + styles.Help.Render(" fit "+v.fitMode),
+		}
+		if v.width >= spreadMinWidth {
+			help = append(help, styles.HelpKey.Render("s")+styles.Help.Render(" spread"))
 		}
+		help = append(help, styles.HelpKey.Render("q")+styles.Help.Render(" back"))
 	}
 
-	return styles.FooterBar.Width(v.width).Render(strings.Join(help, "  "))
+	return strings.Join(help, "  ")
+}
+
+// readingOrderLabel describes the current page order for the footer
+func (v *ComicView) readingOrderLabel() string {
+	if v.rightToLeft {
+		return "manga (rtl)"
+	}
+	return "ltr"
 }
 
 // SetSize implements View
@@ -521,6 +901,12 @@ func (v *ComicView) SetSize(width, height int) {
 	v.height = height
 }
 
+// CapturingKeys implements KeyCapturer: the page grid, bookmarks overlay,
+// go-to-page and zoom prompts and adjustment panel handle q and esc themselves
+func (v *ComicView) CapturingKeys() bool {
+	return v.showThumbs || v.showBookmarks || v.gotoMode || v.zoomMode || v.adjusting
+}
+
 // GetTermMode returns the terminal image mode for cleanup purposes
 func (v *ComicView) GetTermMode() terminal.TermImageMode {
 	return v.termMode
@@ -528,23 +914,111 @@ func (v *ComicView) GetTermMode() terminal.TermImageMode {
 
 // loadPageCount fetches the comic page count
 func (v *ComicView) loadPageCount() tea.Cmd {
+	if v.book.IsPDF() {
+		return v.loadPDF()
+	}
 	return func() tea.Msg {
 		resp, err := v.client.GetComicPages(v.book.ID)
 		if err != nil {
 			return comicPagesLoadedMsg{err: err}
 		}
-		return comicPagesLoadedMsg{pageCount: resp.PageCount}
+		// A missing position just means the comic hasn't been read yet
+		pos, _ := v.client.GetPosition(v.book.ID)
+		return comicPagesLoadedMsg{pageCount: resp.PageCount, position: pos}
 	}
 }
 
-// loadPage fetches a specific page image (converts 1-indexed to 0-indexed for API)
+// loadPage fetches a specific page image
 func (v *ComicView) loadPage(page int) tea.Cmd {
+	bookID := v.book.ID
 	return func() tea.Msg {
-		// API uses 0-indexed pages, UI uses 1-indexed
-		data, imageType, err := v.client.GetComicPage(v.book.ID, page-1)
+		data, imageType, err := v.fetchPage(bookID, page)
 		if err != nil {
 			return comicPageLoadedMsg{page: page, err: err}
 		}
 		return comicPageLoadedMsg{page: page, data: data, imageType: imageType}
 	}
 }
+
+// fetchPage returns a page image from the disk cache, the API or, for PDFs,
+// the rasterizer (converts 1-indexed to 0-indexed for API)
+func (v *ComicView) fetchPage(bookID string, page int) ([]byte, string, error) {
+	// API uses 0-indexed pages, UI uses 1-indexed
+	key := cache.PageKey(bookID, page-1)
+	if data, ok := v.pageCache.Get(key); ok {
+		return data, http.DetectContentType(data), nil
+	}
+
+	var data []byte
+	var imageType string
+	var err error
+	if v.pdfPath != "" {
+		data, err = pdf.RenderPage(v.pdfPath, page, pdf.DefaultDPI)
+		imageType = "image/png"
+	} else {
+		data, imageType, err = v.client.GetComicPage(bookID, page-1)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	_ = v.pageCache.Put(key, data)
+	return data, imageType, nil
+}
+
+// fetchPages is fetchPage for several pages (1-indexed), asking the API for the
+// uncached ones together. Results are in the order asked for, each carrying
+// its page number.
+func (v *ComicView) fetchPages(bookID string, pages []int) []api.ComicPage {
+	results := make([]api.ComicPage, len(pages))
+	var missing []int // 0-indexed, for the API
+	var missingAt []int
+	for i, page := range pages {
+		if data, ok := v.pageCache.Get(cache.PageKey(bookID, page-1)); ok {
+			results[i] = api.ComicPage{Index: page, Data: data, ContentType: http.DetectContentType(data)}
+			continue
+		}
+		if v.pdfPath != "" {
+			data, imageType, err := v.fetchPage(bookID, page)
+			results[i] = api.ComicPage{Index: page, Data: data, ContentType: imageType, Err: err}
+			continue
+		}
+		missing = append(missing, page-1)
+		missingAt = append(missingAt, i)
+	}
+	if len(missing) == 0 {
+		return results
+	}
+
+	for j, fetched := range v.client.GetComicPageSet(context.Background(), bookID, missing) {
+		if fetched.Err == nil {
+			_ = v.pageCache.Put(cache.PageKey(bookID, fetched.Index), fetched.Data)
+		}
+		fetched.Index++
+		results[missingAt[j]] = fetched
+	}
+	return results
+}
+
+// comicPrefetchPages is how many pages past the screen are fetched ahead
+const comicPrefetchPages = 3
+
+// prefetchPages fetches the pages after the ones on screen into the disk cache,
+// so turning the page doesn't wait on the network
+func (v *ComicView) prefetchPages() tea.Cmd {
+	if v.pageCache == nil || v.pdfPath != "" {
+		return nil
+	}
+	next := max(v.currentPage, v.partnerPage) + 1
+	var pages []int
+	for page := next; page < next+comicPrefetchPages && page <= v.pageCount; page++ {
+		pages = append(pages, page)
+	}
+	if len(pages) == 0 {
+		return nil
+	}
+	bookID := v.book.ID
+	return func() tea.Msg {
+		v.fetchPages(bookID, pages)
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package views
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// Image adjustment limits and step sizes
+const (
+	adjustPercentStep = 10
+	adjustPercentMax  = 100
+	gammaStep         = 0.1
+	gammaMin          = 0.3
+	gammaMax          = 3.0
+)
+
+// Controls in the adjustment panel, in display order
+const (
+	adjustBrightness = iota
+	adjustContrast
+	adjustGamma
+	adjustControlCount
+)
+
+// pageAdjust holds the brightness, contrast and gamma applied to pages before encoding
+type pageAdjust struct {
+	brightness int     // -100 to 100 percent of full scale
+	contrast   int     // -100 to 100 percent
+	gamma      float64 // 1.0 is unchanged
+}
+
+// isIdentity reports whether the adjustment leaves images unchanged
+func (a pageAdjust) isIdentity() bool {
+	return a.brightness == 0 && a.contrast == 0 && a.gamma == 1
+}
+
+// lookupTable maps each 8-bit channel value to its adjusted value.
+// Gamma is applied first, then contrast around mid-grey, then brightness.
+func (a pageAdjust) lookupTable() [256]uint8 {
+	var table [256]uint8
+	for i := range table {
+		value := math.Pow(float64(i)/255, 1/a.gamma)
+		value = (value-0.5)*(1+float64(a.contrast)/100) + 0.5
+		value += float64(a.brightness) / 100
+		table[i] = uint8(math.Round(math.Max(0, math.Min(1, value)) * 255))
+	}
+	return table
+}
+
+// adjustImage applies the adjustment to img, returning img itself if nothing changes
+func adjustImage(img image.Image, adjust pageAdjust) image.Image {
+	if adjust.isIdentity() {
+		return img
+	}
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Src)
+
+	table := adjust.lookupTable()
+	for i := 0; i < len(out.Pix); i += 4 {
+		out.Pix[i] = table[out.Pix[i]]
+		out.Pix[i+1] = table[out.Pix[i+1]]
+		out.Pix[i+2] = table[out.Pix[i+2]]
+	}
+	return out
+}
+
+// loadAdjust reads a comic's saved adjustment from its settings
+func (v *ComicView) loadAdjust(brightness, contrast int, gamma float64) {
+	if gamma == 0 {
+		gamma = 1
+	}
+	v.adjust = pageAdjust{brightness: brightness, contrast: contrast, gamma: gamma}
+}
+
+// updateAdjust handles keys while the adjustment panel is open
+func (v *ComicView) updateAdjust(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "a", "enter", "q":
+		v.adjusting = false
+		return v, nil
+	case "j", "down", "tab":
+		v.adjustCursor = (v.adjustCursor + 1) % adjustControlCount
+		return v, nil
+	case "k", "up", "shift+tab":
+		v.adjustCursor = (v.adjustCursor + adjustControlCount - 1) % adjustControlCount
+		return v, nil
+	case "l", "right", "+", "=":
+		v.stepAdjust(1)
+	case "h", "left", "-", "_":
+		v.stepAdjust(-1)
+	case "0":
+		v.adjust = pageAdjust{gamma: 1}
+	default:
+		return v, nil
+	}
+	v.saveAdjust()
+	return v, nil
+}
+
+// stepAdjust moves the selected control one step in direction (+1 or -1)
+func (v *ComicView) stepAdjust(direction int) {
+	switch v.adjustCursor {
+	case adjustBrightness:
+		v.adjust.brightness = max(-adjustPercentMax, min(adjustPercentMax, v.adjust.brightness+direction*adjustPercentStep))
+	case adjustContrast:
+		v.adjust.contrast = max(-adjustPercentMax, min(adjustPercentMax, v.adjust.contrast+direction*adjustPercentStep))
+	case adjustGamma:
+		gamma := math.Round((v.adjust.gamma+float64(direction)*gammaStep)*10) / 10
+		v.adjust.gamma = math.Max(gammaMin, math.Min(gammaMax, gamma))
+	}
+}
+
+// saveAdjust redraws the page with the new adjustment and remembers it for this book
+func (v *ComicView) saveAdjust() {
+	v.decodedImg = nil
+	if v.config == nil {
+		return
+	}
+	settings := v.config.GetComicSettings(v.book.ID)
+	settings.Brightness = v.adjust.brightness
+	settings.Contrast = v.adjust.contrast
+	settings.Gamma = 0
+	if v.adjust.gamma != 1 {
+		settings.Gamma = v.adjust.gamma
+	}
+	if err := v.config.SetComicSettings(v.book.ID, settings); err != nil {
+		v.err = fmt.Errorf("failed to save image adjustment: %w", err)
+	}
+}
+
+// renderAdjustFooter renders the adjustment panel in the footer
+func (v *ComicView) renderAdjustFooter() string {
+	values := []string{
+		fmt.Sprintf("brightness %+d%%", v.adjust.brightness),
+		fmt.Sprintf("contrast %+d%%", v.adjust.contrast),
+		fmt.Sprintf("gamma %.1f", v.adjust.gamma),
+	}
+	parts := make([]string, len(values))
+	for i, value := range values {
+		if i == v.adjustCursor {
+			parts[i] = styles.HelpKey.Render("▸ " + value)
+		} else {
+			parts[i] = styles.Help.Render(value)
+		}
+	}
+	return strings.Join(parts, "  ") + "  " + styles.Help.Render("h/l change • j/k next • esc done")
+}
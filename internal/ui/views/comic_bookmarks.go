@@ -0,0 +1,255 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/internal/ui/terminal"
+)
+
+// Comic bookmarks reuse config.Bookmark: Chapter holds the 0-indexed page
+// (as saved positions do) and Position the webtoon scroll fraction.
+
+// addBookmark bookmarks the current page
+func (v *ComicView) addBookmark() {
+	if v.config == nil || v.pageCount == 0 {
+		return
+	}
+	_, position := v.currentPosition()
+	err := v.config.AddBookmark(v.book.ID, v.book.Title, v.currentPage-1, "", position, "")
+	if err != nil {
+		v.statusMsg = "Failed to add bookmark"
+	} else {
+		v.statusMsg = fmt.Sprintf("Bookmarked page %d", v.currentPage)
+	}
+}
+
+// openBookmarks shows the bookmarks overlay
+func (v *ComicView) openBookmarks() {
+	terminal.ClearImagesCmd(v.termMode)()
+	v.showBookmarks = true
+	v.bookmarkCursor = 0
+}
+
+// closeBookmarks hides the bookmarks overlay so the page is drawn again
+func (v *ComicView) closeBookmarks() {
+	v.showBookmarks = false
+}
+
+// updateBookmarks handles keys while the bookmarks overlay is open
+func (v *ComicView) updateBookmarks(msg tea.KeyMsg) (View, tea.Cmd) {
+	bookmarks := v.getBookmarksForCurrentBook()
+
+	if v.bookmarkEditing {
+		return v.updateBookmarkEdit(msg, bookmarks)
+	}
+
+	switch msg.String() {
+	case "esc", "b", "q":
+		v.closeBookmarks()
+	case "j", "down":
+		if v.bookmarkCursor < len(bookmarks)-1 {
+			v.bookmarkCursor++
+		}
+	case "k", "up":
+		if v.bookmarkCursor > 0 {
+			v.bookmarkCursor--
+		}
+	case "g", "home":
+		v.bookmarkCursor = 0
+	case "G", "end":
+		if len(bookmarks) > 0 {
+			v.bookmarkCursor = len(bookmarks) - 1
+		}
+	case "enter":
+		if v.bookmarkCursor < len(bookmarks) {
+			v.closeBookmarks()
+			return v, v.goToBookmark(bookmarks[v.bookmarkCursor])
+		}
+	case "s":
+		// Toggle sort order, keeping the selected bookmark under the cursor
+		selectedID := ""
+		if v.bookmarkCursor < len(bookmarks) {
+			selectedID = bookmarks[v.bookmarkCursor].ID
+		}
+		v.bookmarkSort = (v.bookmarkSort + 1) % bookmarkSortCount
+		for i, bm := range v.getBookmarksForCurrentBook() {
+			if bm.ID == selectedID {
+				v.bookmarkCursor = i
+			}
+		}
+	case "r":
+		if v.bookmarkCursor < len(bookmarks) {
+			return v, v.editBookmark(bookmarks[v.bookmarkCursor].Name, false)
+		}
+	case "N":
+		if v.bookmarkCursor < len(bookmarks) {
+			return v, v.editBookmark(bookmarks[v.bookmarkCursor].Note, true)
+		}
+	case "d", "x":
+		if v.bookmarkCursor < len(bookmarks) {
+			_ = v.config.DeleteBookmark(bookmarks[v.bookmarkCursor].ID)
+			if v.bookmarkCursor >= len(bookmarks)-1 && v.bookmarkCursor > 0 {
+				v.bookmarkCursor--
+			}
+		}
+	}
+	return v, nil
+}
+
+// editBookmark starts editing the selected bookmark's name or note
+func (v *ComicView) editBookmark(value string, note bool) tea.Cmd {
+	v.bookmarkEditing = true
+	v.bookmarkEditNote = note
+	v.bookmarkInput.Placeholder = "Bookmark name..."
+	v.bookmarkInput.CharLimit = 60
+	if note {
+		v.bookmarkInput.Placeholder = "Note..."
+		v.bookmarkInput.CharLimit = 200
+	}
+	v.bookmarkInput.SetValue(value)
+	v.bookmarkInput.CursorEnd()
+	v.bookmarkInput.Focus()
+	return textinput.Blink
+}
+
+// updateBookmarkEdit handles input while renaming a bookmark or editing its note
+func (v *ComicView) updateBookmarkEdit(msg tea.KeyMsg, bookmarks []config.Bookmark) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.bookmarkEditing = false
+		v.bookmarkInput.Blur()
+		return v, nil
+	case "enter":
+		v.bookmarkEditing = false
+		v.bookmarkInput.Blur()
+		if v.bookmarkCursor < len(bookmarks) {
+			id := bookmarks[v.bookmarkCursor].ID
+			value := strings.TrimSpace(v.bookmarkInput.Value())
+			if v.bookmarkEditNote {
+				if err := v.config.SetBookmarkNote(id, value); err != nil {
+					v.statusMsg = "Failed to save note"
+				}
+			} else if err := v.config.RenameBookmark(id, value); err != nil {
+				v.statusMsg = "Failed to rename bookmark"
+			}
+		}
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.bookmarkInput, cmd = v.bookmarkInput.Update(msg)
+	return v, cmd
+}
+
+// getBookmarksForCurrentBook returns bookmarks for this comic in the selected sort order
+func (v *ComicView) getBookmarksForCurrentBook() []config.Bookmark {
+	if v.config == nil {
+		return nil
+	}
+	bookmarks := v.config.GetBookmarksForBook(v.book.ID)
+	switch v.bookmarkSort {
+	case bookmarkSortChapter:
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			if bookmarks[i].Chapter != bookmarks[j].Chapter {
+				return bookmarks[i].Chapter < bookmarks[j].Chapter
+			}
+			return bookmarks[i].Position < bookmarks[j].Position
+		})
+	case bookmarkSortDate:
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			return bookmarks[i].CreatedAt.After(bookmarks[j].CreatedAt)
+		})
+	}
+	return bookmarks
+}
+
+// goToBookmark shows a bookmarked page, restoring the strip scroll in webtoon mode
+func (v *ComicView) goToBookmark(bookmark config.Bookmark) tea.Cmd {
+	cmd := v.goToPage(bookmark.Chapter + 1)
+	if img, ok := v.stripPages[v.currentPage]; ok && v.webtoon {
+		offset := int(bookmark.Position * float64(img.Bounds().Dy()))
+		return tea.Batch(cmd, v.scrollStrip(offset))
+	}
+	v.pendingStripPos = bookmark.Position
+	return cmd
+}
+
+// bookmarkLabel returns the display text for a comic bookmark
+func (v *ComicView) bookmarkLabel(bm config.Bookmark) string {
+	label := fmt.Sprintf("Page %d", bm.Chapter+1)
+	if bm.Name != "" {
+		label += ": " + bm.Name
+	}
+	if bm.Note != "" {
+		label += " ✎"
+	}
+	if v.bookmarkSort == bookmarkSortDate {
+		label += " " + bm.CreatedAt.Format("Jan 2")
+	}
+	return label
+}
+
+// renderBookmarks renders the bookmarks overlay
+func (v *ComicView) renderBookmarks() string {
+	var b strings.Builder
+
+	sortLabel := "page"
+	if v.bookmarkSort == bookmarkSortDate {
+		sortLabel = "date"
+	}
+	b.WriteString(styles.DialogTitle.Render("Bookmarks") + styles.MutedText.Render(" by "+sortLabel) + "\n\n")
+
+	bookmarks := v.getBookmarksForCurrentBook()
+	dialogWidth := min(50, v.width-4)
+	labelWidth := dialogWidth - 8
+
+	if len(bookmarks) == 0 {
+		b.WriteString(styles.MutedText.Render("No bookmarks for this comic.\n\nPress B to bookmark a page."))
+	} else {
+		// Leave room for the note preview under the list
+		maxVisible := max(1, v.height-14)
+		offset := 0
+		if v.bookmarkCursor >= maxVisible {
+			offset = v.bookmarkCursor - maxVisible + 1
+		}
+		for i := offset; i < min(offset+maxVisible, len(bookmarks)); i++ {
+			line := styles.TruncateText(v.bookmarkLabel(bookmarks[i]), labelWidth)
+			if i == v.bookmarkCursor {
+				b.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString(styles.ListItem.Render("  "+line) + "\n")
+			}
+		}
+		if v.bookmarkCursor < len(bookmarks) && bookmarks[v.bookmarkCursor].Note != "" && !v.bookmarkEditing {
+			note := lipgloss.NewStyle().Width(labelWidth).Render(bookmarks[v.bookmarkCursor].Note)
+			b.WriteString("\n" + styles.MutedText.Render(note) + "\n")
+		}
+	}
+
+	if v.bookmarkEditing {
+		prompt := "Name: "
+		if v.bookmarkEditNote {
+			prompt = "Note: "
+		}
+		b.WriteString("\n" + styles.SecondaryText.Render(prompt) + v.bookmarkInput.View())
+		b.WriteString("\n" + styles.Help.Render("enter save • esc cancel"))
+	} else {
+		b.WriteString("\n" + styles.Help.Render("j/k navigate • enter go • r rename • N note • s sort • d delete • esc close"))
+	}
+
+	dialog := styles.Dialog.Width(dialogWidth).Render(b.String())
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
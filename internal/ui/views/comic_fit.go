@@ -0,0 +1,66 @@
+package views
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/justyntemme/webby-t/internal/config"
+)
+
+// cycleFitMode switches between fitting the whole page, its width and its
+// height, and remembers it for this book
+func (v *ComicView) cycleFitMode() {
+	next := config.FitModes[0]
+	for i, mode := range config.FitModes {
+		if mode == v.fitMode {
+			next = config.FitModes[(i+1)%len(config.FitModes)]
+		}
+	}
+	v.fitMode = next
+	v.resetZoomPan()
+	v.statusMsg = "Fit: " + v.fitMode
+	if v.config == nil {
+		return
+	}
+	if err := v.config.SetBookFitMode(v.book.ID, v.fitMode); err != nil {
+		v.err = fmt.Errorf("failed to save fit mode: %w", err)
+	}
+}
+
+// fitImage crops img to the shape of a cols x rows cell area along the side
+// that would otherwise leave the area's width (FitWidth) or height (FitHeight)
+// unfilled. Pan picks the part shown. A cell is taken to be about twice as
+// tall as it is wide.
+func fitImage(img image.Image, fit string, cols, rows int, panX, panY float64) image.Image {
+	bounds := img.Bounds()
+	if fit == config.FitPage || cols <= 0 || rows <= 0 || bounds.Empty() {
+		return img
+	}
+	w, h := bounds.Dx(), bounds.Dy()
+	crop := bounds
+	switch fit {
+	case config.FitWidth:
+		// Height of the page shown when its width fills the area
+		viewHeight := w * rows * 2 / cols
+		if viewHeight <= 0 || viewHeight >= h {
+			return img
+		}
+		crop.Min.Y += int(panY * float64(h-viewHeight))
+		crop.Max.Y = crop.Min.Y + viewHeight
+	case config.FitHeight:
+		viewWidth := h * cols / (rows * 2)
+		if viewWidth <= 0 || viewWidth >= w {
+			return img
+		}
+		crop.Min.X += int(panX * float64(w-viewWidth))
+		crop.Max.X = crop.Min.X + viewWidth
+	}
+
+	type subImager interface {
+		SubImage(r image.Rectangle) image.Image
+	}
+	if si, ok := img.(subImager); ok {
+		return si.SubImage(crop)
+	}
+	return img
+}
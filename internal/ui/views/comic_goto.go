@@ -0,0 +1,45 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// maxPageDigits limits the go-to-page input length
+const maxPageDigits = 6
+
+// openGotoPrompt starts the go-to-page prompt in the footer
+func (v *ComicView) openGotoPrompt() tea.Cmd {
+	if v.pageCount == 0 {
+		return nil
+	}
+	v.gotoMode = true
+	return v.gotoInput.open(1, v.pageCount, fmt.Sprintf("Page must be 1-%d", v.pageCount))
+}
+
+// updateGotoPrompt handles keys while typing a page number
+func (v *ComicView) updateGotoPrompt(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.gotoMode = false
+		v.gotoInput.input.Blur()
+	case "enter":
+		page, ok := v.gotoInput.number()
+		if !ok {
+			return v, nil // Keep the prompt open with its error
+		}
+		v.gotoMode = false
+		v.gotoInput.input.Blur()
+		return v, v.goToPage(page)
+	default:
+		return v, v.gotoInput.update(msg)
+	}
+	return v, nil
+}
+
+// renderGotoPrompt renders the go-to-page prompt for the footer
+func (v *ComicView) renderGotoPrompt() string {
+	return styles.HelpKey.Render(":") + v.gotoInput.view(styles.MutedText.Render(fmt.Sprintf(" / %d", v.pageCount)), "enter go • esc cancel")
+}
@@ -0,0 +1,64 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/cache"
+	"github.com/justyntemme/webby-t/internal/pdf"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// loadPDF downloads a PDF (once) and counts its pages so it can be viewed like a comic
+func (v *ComicView) loadPDF() tea.Cmd {
+	bookID := v.book.ID
+	return func() tea.Msg {
+		if !pdf.Available() {
+			return comicPagesLoadedMsg{err: pdf.ErrNoRasterizer}
+		}
+		path, err := v.downloadPDF(bookID)
+		if err != nil {
+			return comicPagesLoadedMsg{err: err}
+		}
+		count, err := pdf.PageCount(path)
+		if err != nil {
+			return comicPagesLoadedMsg{err: err}
+		}
+		// A missing position just means the PDF hasn't been read yet
+		pos, _ := v.client.GetPosition(bookID)
+		return comicPagesLoadedMsg{pageCount: count, position: pos, pdfPath: path}
+	}
+}
+
+// downloadPDF returns the local copy of a PDF, downloading it if it isn't cached
+func (v *ComicView) downloadPDF(bookID string) (string, error) {
+	path, err := cache.DocumentPath(bookID, models.FileFormatPDF)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return path, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+
+	// Download to a temp file so an interrupted download isn't mistaken for the PDF
+	tmp, err := os.CreateTemp(filepath.Dir(path), "download-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if err := v.client.DownloadBook(bookID, tmp); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,201 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/internal/ui/terminal"
+)
+
+// maxRenderedPages bounds the rendered-page cache; Kitty and Sixel output for a
+// full page can run to megabytes
+const maxRenderedPages = 8
+
+// comicDecodeKey identifies a decoded page image: which page(s) and how they are transformed
+type comicDecodeKey struct {
+	page        int
+	partner     int // Spread partner, 0 when the page is shown alone
+	half        int // Split half, -1 when the whole page is shown
+	rotation    int
+	rightToLeft bool
+	adjust      pageAdjust
+	eink        bool
+}
+
+// comicRenderKey identifies a rendered page: the decoded image, the visible part and the area it fills
+type comicRenderKey struct {
+	comicDecodeKey
+	fit    string
+	zoom   float64
+	panX   float64
+	panY   float64
+	width  int
+	height int
+
+	// Webtoon mode: the strip from page down, scrolled by stripOffset pixels,
+	// with stripDrawn pages loaded so that one loading below redraws it
+	strip       bool
+	stripOffset int
+	stripDrawn  int
+}
+
+// comicRenderedMsg is sent when a page has been decoded and encoded in the background
+type comicRenderedMsg struct {
+	bookID   string
+	key      comicRenderKey
+	decoded  image.Image // Decoded image, reused for later pans and zooms
+	rendered string
+}
+
+// renderKey describes what the page view should show now, reporting false when
+// there is no single page image to render
+func (v *ComicView) renderKey() (comicRenderKey, bool) {
+	if v.webtoon {
+		return v.stripRenderKey()
+	}
+	if v.termMode == terminal.TermModeNone || !v.imageLoaded || len(v.imageData) == 0 ||
+		(v.partnerPage != 0 && !v.partnerLoaded) {
+		return comicRenderKey{}, false
+	}
+	key := comicDecodeKey{
+		page:        v.currentPage,
+		half:        -1,
+		rotation:    v.rotation,
+		rightToLeft: v.rightToLeft,
+		adjust:      v.adjust,
+		eink:        v.einkMode(),
+	}
+	if v.showingSplit() {
+		key.half = v.splitHalf
+	}
+	if v.showingSpread() {
+		key.partner = v.partnerPage
+	}
+	return comicRenderKey{
+		comicDecodeKey: key,
+		fit:            v.fitMode,
+		zoom:           v.currentZoom(),
+		panX:           v.panX,
+		panY:           v.panY,
+		width:          v.width,
+		height:         v.height,
+	}, true
+}
+
+// ensureRendered starts rendering the current page in the background unless it is
+// cached. One render runs at a time; changes made meanwhile are picked up when it finishes.
+func (v *ComicView) ensureRendered() tea.Cmd {
+	key, ok := v.renderKey()
+	if !ok || v.rendering {
+		return nil
+	}
+	if _, cached := v.renderCache[key]; cached {
+		return nil
+	}
+	v.rendering = true
+
+	bookID, termMode := v.book.ID, v.termMode
+	if key.strip {
+		pages := v.visibleStripPages()
+		return func() tea.Msg {
+			return comicRenderedMsg{bookID: bookID, key: key, rendered: renderStrip(pages, key, termMode)}
+		}
+	}
+	data, partnerData := v.imageData, v.partnerData
+	var decoded image.Image
+	if v.decodedImg != nil && v.decodedKey == key.comicDecodeKey {
+		decoded = v.decodedImg
+	}
+	return func() tea.Msg {
+		msg := comicRenderedMsg{bookID: bookID, key: key, decoded: decoded}
+		if msg.decoded == nil {
+			img, err := decodePage(key.comicDecodeKey, data, partnerData)
+			if err != nil {
+				msg.rendered = styles.ErrorStyle.Render("Failed to decode image: " + err.Error())
+				return msg
+			}
+			msg.decoded = img
+		}
+		img := fitImage(msg.decoded, key.fit, key.width, styles.ContentHeight(key.height), key.panX, key.panY)
+		img = viewportImage(img, key.zoom, key.panX, key.panY)
+		// Stable ID for targeted clearing
+		rendered, err := terminal.RenderImageInCells(img, termMode, key.width, styles.ContentHeight(key.height), terminal.ComicImageID)
+		if err != nil {
+			rendered = styles.ErrorStyle.Render("Render error: " + err.Error())
+		}
+		msg.rendered = rendered
+		return msg
+	}
+}
+
+// handleRendered caches a finished render
+func (v *ComicView) handleRendered(msg comicRenderedMsg) (View, tea.Cmd) {
+	if msg.bookID != v.book.ID {
+		return v, nil
+	}
+	v.rendering = false
+	if msg.decoded != nil {
+		v.decodedImg = msg.decoded
+		v.decodedKey = msg.key.comicDecodeKey
+	}
+	if v.renderCache == nil || len(v.renderCache) >= maxRenderedPages {
+		v.renderCache = make(map[comicRenderKey]string)
+	}
+	v.renderCache[msg.key] = msg.rendered
+	return v, nil
+}
+
+// resetRenderCache drops rendered pages, e.g. when another comic is opened
+func (v *ComicView) resetRenderCache() {
+	v.renderCache = make(map[comicRenderKey]string)
+	v.rendering = false
+	v.lastRendered = ""
+	v.decodedImg = nil
+}
+
+// renderImage returns the rendered current page. Until a render for the current
+// state finishes, the last page drawn stays on screen.
+func (v *ComicView) renderImage() string {
+	key, ok := v.renderKey()
+	if !ok {
+		if v.holdingPage() {
+			return v.lastRendered
+		}
+		return styles.MutedText.Render("No image data")
+	}
+	if rendered, cached := v.renderCache[key]; cached {
+		v.lastRendered = rendered
+	} else if v.lastRendered == "" {
+		return styles.MutedText.Render(fmt.Sprintf("Rendering page %s...", v.pageLabel()))
+	}
+	// Clear previous image before drawing (prevents zoom artifacts)
+	return terminal.ClearComicImage(v.termMode) + v.lastRendered
+}
+
+// decodePage decodes the page (and spread partner) and applies the rotation,
+// split, adjustments and e-ink conversion described by key
+func decodePage(key comicDecodeKey, data, partnerData []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	img = rotateImage(img, key.rotation)
+	if key.half >= 0 {
+		img = splitImage(img, key.half, key.rightToLeft)
+	}
+	if key.partner != 0 {
+		partner, _, err := image.Decode(bytes.NewReader(partnerData))
+		if err != nil {
+			return nil, err
+		}
+		img = joinSpread(img, rotateImage(partner, key.rotation), key.rightToLeft)
+	}
+	img = adjustImage(img, key.adjust)
+	if key.eink {
+		img = einkImage(img)
+	}
+	return img, nil
+}
@@ -0,0 +1,77 @@
+package views
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/terminal"
+)
+
+// rotate turns pages by delta clockwise quarter turns and remembers it for this book
+func (v *ComicView) rotate(delta int) tea.Cmd {
+	v.rotation = ((v.rotation+delta)%4 + 4) % 4
+	if v.config != nil {
+		settings := v.config.GetComicSettings(v.book.ID)
+		settings.Rotation = v.rotation
+		if err := v.config.SetComicSettings(v.book.ID, settings); err != nil {
+			v.err = fmt.Errorf("failed to save rotation: %w", err)
+		}
+	}
+	v.decodedImg = nil
+	v.resetZoomPan()
+	if !v.webtoon {
+		return nil
+	}
+
+	// Strip pages are scaled after rotating, so they all need reloading
+	terminal.ClearImagesCmd(v.termMode)()
+	v.stripOffset = 0
+	v.stripPages = make(map[int]image.Image)
+	v.stripLoading = make(map[int]bool)
+	return v.loadStripPages()
+}
+
+// rotationLabel describes the rotation for the header, or "" when upright
+func (v *ComicView) rotationLabel() string {
+	if v.rotation == 0 {
+		return ""
+	}
+	return fmt.Sprintf("↻%d°", v.rotation*90)
+}
+
+// rotateImage turns img clockwise by the given number of quarter turns
+func rotateImage(img image.Image, quarterTurns int) image.Image {
+	quarterTurns = (quarterTurns%4 + 4) % 4
+	if quarterTurns == 0 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dstWidth, dstHeight := h, w
+	if quarterTurns == 2 {
+		dstWidth, dstHeight = w, h
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch quarterTurns {
+			case 1:
+				dx, dy = h-1-y, x
+			case 2:
+				dx, dy = w-1-x, h-1-y
+			case 3:
+				dx, dy = y, w-1-x
+			}
+			si, di := src.PixOffset(x, y), dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
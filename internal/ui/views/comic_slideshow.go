@@ -0,0 +1,78 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+)
+
+// comicSlideTickMsg advances the slideshow; ticks from an earlier run are ignored
+type comicSlideTickMsg struct {
+	id int
+}
+
+// slideshowSeconds returns the time each page is shown
+func (v *ComicView) slideshowSeconds() int {
+	if v.config == nil {
+		return config.DefaultSlideshowSeconds
+	}
+	return v.config.GetSlideshowSeconds()
+}
+
+// toggleSlideshow starts or pauses automatic page turning
+func (v *ComicView) toggleSlideshow() tea.Cmd {
+	if v.pageCount == 0 {
+		return nil
+	}
+	v.slideshow = !v.slideshow
+	if !v.slideshow {
+		v.slideID++ // Drop the pending tick
+		v.statusMsg = "Slideshow paused"
+		return nil
+	}
+	v.statusMsg = fmt.Sprintf("Slideshow: %ds per page", v.slideshowSeconds())
+	return v.scheduleSlide()
+}
+
+// adjustSlideshow changes the time per page by delta seconds and restarts the timer
+func (v *ComicView) adjustSlideshow(delta int) tea.Cmd {
+	if v.config == nil {
+		return nil
+	}
+	_ = v.config.SetSlideshowSeconds(v.slideshowSeconds() + delta)
+	v.statusMsg = fmt.Sprintf("Slideshow: %ds per page", v.slideshowSeconds())
+	if !v.slideshow {
+		return nil
+	}
+	return v.scheduleSlide()
+}
+
+// scheduleSlide starts the timer for the next page turn
+func (v *ComicView) scheduleSlide() tea.Cmd {
+	v.slideID++
+	id := v.slideID
+	return tea.Tick(time.Duration(v.slideshowSeconds())*time.Second, func(time.Time) tea.Msg {
+		return comicSlideTickMsg{id: id}
+	})
+}
+
+// handleSlideTick turns the page, stopping at the end of the comic.
+// While an overlay or prompt is open the page stays put and the timer restarts.
+func (v *ComicView) handleSlideTick(msg comicSlideTickMsg) (View, tea.Cmd) {
+	if !v.slideshow || msg.id != v.slideID {
+		return v, nil
+	}
+	if v.CapturingKeys() {
+		return v, v.scheduleSlide()
+	}
+	page, half := v.currentPage, v.splitHalf
+	cmd := v.nextPage()
+	if v.currentPage == page && v.splitHalf == half {
+		v.slideshow = false
+		v.statusMsg = "Slideshow finished"
+		return v, nil
+	}
+	return v, tea.Batch(cmd, v.scheduleSlide())
+}
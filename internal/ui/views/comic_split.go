@@ -0,0 +1,66 @@
+package views
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+)
+
+// wideAspect is how much wider than tall a page must be to count as a scanned spread
+const wideAspect = 1.2
+
+// readPageSize records the current page's dimensions without decoding the whole image
+func (v *ComicView) readPageSize(data []byte) {
+	v.pageWidth, v.pageHeight = 0, 0
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		v.pageWidth, v.pageHeight = cfg.Width, cfg.Height
+	}
+}
+
+// pageIsWide reports whether the current page (as rotated) is a two-page scan
+func (v *ComicView) pageIsWide() bool {
+	width, height := v.pageWidth, v.pageHeight
+	if v.rotation%2 == 1 {
+		width, height = height, width
+	}
+	return height > 0 && float64(width) > float64(height)*wideAspect
+}
+
+// showingSplit reports whether the current page is being shown one half at a time.
+// Splitting only applies to pages shown on their own.
+func (v *ComicView) showingSplit() bool {
+	return v.splitEnabled && !v.webtoon && v.partnerPage == 0 && v.pageIsWide()
+}
+
+// toggleSplit turns automatic splitting of wide pages on or off
+func (v *ComicView) toggleSplit() {
+	v.splitEnabled = !v.splitEnabled
+	v.splitHalf = 0
+	v.decodedImg = nil
+	v.resetZoomPan()
+}
+
+// turnHalf moves between the halves of a split page, reporting whether it did
+func (v *ComicView) turnHalf(half int) bool {
+	if !v.showingSplit() || v.splitHalf == half {
+		return false
+	}
+	v.splitHalf = half
+	v.decodedImg = nil
+	v.resetPageZoomPan()
+	return true
+}
+
+// splitImage returns one half of a wide page. The first half is the left one,
+// or the right one when reading right to left.
+func splitImage(img image.Image, half int, rightToLeft bool) image.Image {
+	bounds := img.Bounds()
+	width := bounds.Dx() / 2
+	x := bounds.Min.X
+	if (half == 1) != rightToLeft {
+		x += bounds.Dx() - width
+	}
+	out := image.NewRGBA(image.Rect(0, 0, width, bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, image.Pt(x, bounds.Min.Y), draw.Src)
+	return out
+}
@@ -0,0 +1,80 @@
+package views
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nfnt/resize"
+)
+
+// spreadMinWidth is the terminal width (columns) needed to show two pages side by side
+const spreadMinWidth = 140
+
+// spreadFits reports whether two-page spreads are enabled and the terminal is wide enough.
+// The webtoon strip never uses spreads.
+func (v *ComicView) spreadFits() bool {
+	return v.spreadEnabled && !v.webtoon && v.width >= spreadMinWidth
+}
+
+// spreadStart returns the first page of the spread containing page.
+// The cover is shown alone, then pages pair up: 2-3, 4-5, ...
+func spreadStart(page int) int {
+	if page <= 1 || page%2 == 0 {
+		return max(1, page)
+	}
+	return page - 1
+}
+
+// spreadPartner returns the page shown beside page in a spread, or 0 if it is shown alone
+func (v *ComicView) spreadPartner(page int) int {
+	if !v.spreadFits() || page <= 1 || page%2 != 0 || page >= v.pageCount {
+		return 0
+	}
+	return page + 1
+}
+
+// showingSpread reports whether both pages of the current spread can be drawn
+func (v *ComicView) showingSpread() bool {
+	return v.partnerPage != 0 && v.partnerLoaded && v.spreadFits()
+}
+
+// toggleSpread turns two-page spreads on or off, re-pairing the current page
+func (v *ComicView) toggleSpread() tea.Cmd {
+	v.spreadEnabled = !v.spreadEnabled
+	if v.pageCount == 0 {
+		return nil
+	}
+	return v.goToPage(v.currentPage)
+}
+
+// pageLabel describes the visible page(s) for the header, e.g. "4-5/40"
+func (v *ComicView) pageLabel() string {
+	if v.partnerPage != 0 && v.spreadFits() {
+		return fmt.Sprintf("%d-%d/%d", v.currentPage, v.partnerPage, v.pageCount)
+	}
+	if v.showingSplit() {
+		return fmt.Sprintf("%d/%d (%d/2)", v.currentPage, v.pageCount, v.splitHalf+1)
+	}
+	return fmt.Sprintf("%d/%d", v.currentPage, v.pageCount)
+}
+
+// joinSpread draws two pages side by side, scaling the second to the first's height.
+// In right-to-left order the first page goes on the right.
+func joinSpread(first, second image.Image, rightToLeft bool) image.Image {
+	height := first.Bounds().Dy()
+	if second.Bounds().Dy() != height {
+		second = resize.Resize(0, uint(height), second, resize.Bilinear)
+	}
+
+	left, right := first, second
+	if rightToLeft {
+		left, right = second, first
+	}
+	leftWidth := left.Bounds().Dx()
+	out := image.NewRGBA(image.Rect(0, 0, leftWidth+right.Bounds().Dx(), height))
+	draw.Draw(out, image.Rect(0, 0, leftWidth, height), left, left.Bounds().Min, draw.Src)
+	draw.Draw(out, image.Rect(leftWidth, 0, out.Bounds().Dx(), height), right, right.Bounds().Min, draw.Src)
+	return out
+}
@@ -0,0 +1,268 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/internal/ui/terminal"
+	"github.com/nfnt/resize"
+)
+
+// Webtoon (vertical strip) mode layout
+const (
+	stripWidth       = 800 // Pixel width every page is scaled to
+	stripScrollSteps = 12  // j/k scroll this fraction of the viewport
+	cellAspect       = 2   // Terminal cells are roughly twice as tall as wide
+)
+
+// stripKeptPages is how many decoded strip pages are kept above the current page
+// and below the screen; long webtoon pages can run to tens of megabytes each
+const stripKeptPages = 3
+
+// comicStripPageLoadedMsg is sent when a page for the vertical strip has been decoded and scaled
+type comicStripPageLoadedMsg struct {
+	bookID   string
+	page     int
+	rotation int // Rotation the page was loaded with
+	img      image.Image
+	err      error
+}
+
+// toggleWebtoon switches between single pages and the vertical strip, remembering it for this book
+func (v *ComicView) toggleWebtoon() tea.Cmd {
+	v.webtoon = !v.webtoon
+	if v.config != nil {
+		settings := v.config.GetComicSettings(v.book.ID)
+		settings.Webtoon = v.webtoon
+		if err := v.config.SetComicSettings(v.book.ID, settings); err != nil {
+			v.err = fmt.Errorf("failed to save webtoon mode: %w", err)
+		}
+	}
+	terminal.ClearImagesCmd(v.termMode)()
+	v.lastRendered = "" // The other mode's image
+	if v.pageCount == 0 {
+		return nil
+	}
+	return v.goToPage(v.currentPage)
+}
+
+// stripViewportHeight returns the strip's visible height in pixels for the content area
+func (v *ComicView) stripViewportHeight() int {
+	return stripViewport(v.width, v.height)
+}
+
+// stripViewport returns the strip's visible height in pixels for a view of width by height cells
+func stripViewport(width, height int) int {
+	return max(1, stripWidth*styles.ContentHeight(height)*cellAspect/max(1, width))
+}
+
+// updateStrip handles scrolling keys in webtoon mode. It returns false for keys it doesn't use.
+func (v *ComicView) updateStrip(key string) (tea.Cmd, bool) {
+	viewport := v.stripViewportHeight()
+	step := max(1, viewport/stripScrollSteps)
+	switch key {
+	case "j", "down":
+		return v.scrollStrip(step), true
+	case "k", "up":
+		return v.scrollStrip(-step), true
+	case "ctrl+d":
+		return v.scrollStrip(viewport / 2), true
+	case "ctrl+u":
+		return v.scrollStrip(-viewport / 2), true
+	case " ", "pgdown":
+		return v.scrollStrip(viewport * 9 / 10), true
+	case "pgup":
+		return v.scrollStrip(-viewport * 9 / 10), true
+	}
+	return nil, false
+}
+
+// scrollStrip moves the strip by delta pixels, crossing page boundaries as needed.
+// Scrolling stops at pages that haven't loaded yet.
+func (v *ComicView) scrollStrip(delta int) tea.Cmd {
+	v.stripOffset += delta
+	for v.stripOffset < 0 {
+		prev, ok := v.stripPages[v.currentPage-1]
+		if !ok {
+			v.stripOffset = 0
+			break
+		}
+		v.currentPage--
+		v.stripOffset += prev.Bounds().Dy()
+	}
+	for v.currentPage < v.pageCount {
+		img, ok := v.stripPages[v.currentPage]
+		if !ok || v.stripOffset < img.Bounds().Dy() {
+			break
+		}
+		v.stripOffset -= img.Bounds().Dy()
+		v.currentPage++
+	}
+	// Stop when the bottom of the last page reaches the bottom of the screen
+	if img, ok := v.stripPages[v.currentPage]; ok && v.currentPage == v.pageCount {
+		v.stripOffset = max(0, min(v.stripOffset, img.Bounds().Dy()-v.stripViewportHeight()))
+	}
+	return v.loadStripPages()
+}
+
+// loadStripPages loads the pages needed to fill the viewport, plus one on either
+// side, and drops pages that have scrolled well out of view
+func (v *ComicView) loadStripPages() tea.Cmd {
+	var cmds []tea.Cmd
+	load := func(page int) {
+		if page < 1 || page > v.pageCount || v.stripLoading[page] {
+			return
+		}
+		if _, ok := v.stripPages[page]; ok {
+			return
+		}
+		v.stripLoading[page] = true
+		cmds = append(cmds, v.loadStripPage(page))
+	}
+
+	load(v.currentPage - 1)
+	covered := -v.stripOffset
+	page := v.currentPage
+	for ; page <= v.pageCount && covered < v.stripViewportHeight(); page++ {
+		img, ok := v.stripPages[page]
+		if !ok {
+			load(page)
+			break
+		}
+		covered += img.Bounds().Dy()
+	}
+	load(page) // Prefetch the next page below the screen
+
+	for loaded := range v.stripPages {
+		if loaded < v.currentPage-stripKeptPages || loaded > page+stripKeptPages {
+			delete(v.stripPages, loaded)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadStripPage fetches a page and scales it to the strip width
+func (v *ComicView) loadStripPage(page int) tea.Cmd {
+	bookID := v.book.ID
+	rotation := v.rotation
+	return func() tea.Msg {
+		data, _, err := v.fetchPage(bookID, page)
+		if err != nil {
+			return comicStripPageLoadedMsg{bookID: bookID, page: page, rotation: rotation, err: err}
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return comicStripPageLoadedMsg{bookID: bookID, page: page, rotation: rotation, err: err}
+		}
+		img = rotateImage(img, rotation)
+		if img.Bounds().Dx() != stripWidth {
+			img = resize.Resize(stripWidth, 0, img, resize.Bilinear)
+		}
+		return comicStripPageLoadedMsg{bookID: bookID, page: page, rotation: rotation, img: img}
+	}
+}
+
+// handleStripPageLoaded stores a strip page and loads any further pages now needed
+func (v *ComicView) handleStripPageLoaded(msg comicStripPageLoadedMsg) (View, tea.Cmd) {
+	if msg.bookID != v.book.ID || msg.rotation != v.rotation {
+		return v, nil // Stale: the comic or rotation changed while loading
+	}
+	delete(v.stripLoading, msg.page)
+	if msg.err != nil {
+		if msg.page == v.currentPage {
+			v.err = msg.err
+		}
+		return v, nil
+	}
+	v.stripPages[msg.page] = msg.img
+	if !v.webtoon {
+		return v, nil
+	}
+	if msg.page == v.currentPage && v.pendingStripPos > 0 {
+		offset := int(v.pendingStripPos * float64(msg.img.Bounds().Dy()))
+		v.pendingStripPos = 0
+		return v, v.scrollStrip(offset)
+	}
+	return v, v.loadStripPages()
+}
+
+// stripRenderKey describes the visible part of the strip, reporting false until
+// the current page has loaded
+func (v *ComicView) stripRenderKey() (comicRenderKey, bool) {
+	pages := v.visibleStripPages()
+	if v.termMode == terminal.TermModeNone || len(pages) == 0 {
+		return comicRenderKey{}, false
+	}
+	return comicRenderKey{
+		comicDecodeKey: comicDecodeKey{
+			page:     v.currentPage,
+			half:     -1,
+			rotation: v.rotation,
+			adjust:   v.adjust,
+			eink:     v.einkMode(),
+		},
+		width:       v.width,
+		height:      v.height,
+		strip:       true,
+		stripOffset: v.stripOffset,
+		stripDrawn:  len(pages),
+	}, true
+}
+
+// visibleStripPages returns the loaded pages on screen, from the current page down
+func (v *ComicView) visibleStripPages() []image.Image {
+	viewport := v.stripViewportHeight()
+	var pages []image.Image
+	for page, y := v.currentPage, -v.stripOffset; page <= v.pageCount && y < viewport; page++ {
+		img, ok := v.stripPages[page]
+		if !ok {
+			break
+		}
+		pages = append(pages, img)
+		y += img.Bounds().Dy()
+	}
+	return pages
+}
+
+// renderStrip draws the visible part of the vertical strip. It runs in the
+// background, from ensureRendered.
+func renderStrip(pages []image.Image, key comicRenderKey, termMode terminal.TermImageMode) string {
+	out := image.NewRGBA(image.Rect(0, 0, stripWidth, stripViewport(key.width, key.height)))
+	y := -key.stripOffset
+	for _, img := range pages {
+		height := img.Bounds().Dy()
+		draw.Draw(out, image.Rect(0, y, stripWidth, y+height), img, img.Bounds().Min, draw.Src)
+		y += height
+	}
+
+	strip := adjustImage(out, key.adjust)
+	if key.eink {
+		strip = einkImage(strip)
+	}
+	rendered, err := terminal.RenderImageInCells(strip, termMode, key.width, styles.ContentHeight(key.height), terminal.ComicImageID)
+	if err != nil {
+		return styles.ErrorStyle.Render("Render error: " + err.Error())
+	}
+	return rendered
+}
+
+// renderStripFooter renders the footer help in webtoon mode
+func (v *ComicView) renderStripFooter() string {
+	help := []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" scroll"),
+		styles.HelpKey.Render("n/p") + styles.Help.Render(" page"),
+		styles.HelpKey.Render(":") + styles.Help.Render(" go to"),
+		styles.HelpKey.Render("t") + styles.Help.Render(" pages"),
+		styles.HelpKey.Render("w") + styles.Help.Render(" single pages"),
+		styles.HelpKey.Render("q") + styles.Help.Render(" back"),
+	}
+	return strings.Join(help, "  ")
+}
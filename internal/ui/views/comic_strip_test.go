@@ -0,0 +1,33 @@
+package views
+
+import (
+	"image"
+	"testing"
+)
+
+func TestStripPagesOutOfViewAreDropped(t *testing.T) {
+	v := &ComicView{
+		width:        80,
+		height:       24,
+		pageCount:    40,
+		currentPage:  20,
+		stripPages:   make(map[int]image.Image),
+		stripLoading: make(map[int]bool),
+	}
+	for page := 1; page <= v.pageCount; page++ {
+		v.stripPages[page] = image.NewRGBA(image.Rect(0, 0, stripWidth, 100))
+	}
+	visible := len(v.visibleStripPages())
+
+	v.loadStripPages()
+	for page := range v.stripPages {
+		if page < v.currentPage-stripKeptPages || page > v.currentPage+visible+stripKeptPages {
+			t.Errorf("page %d kept, viewing pages %d-%d", page, v.currentPage, v.currentPage+visible-1)
+		}
+	}
+	for page := v.currentPage - 1; page < v.currentPage+visible; page++ {
+		if _, ok := v.stripPages[page]; !ok {
+			t.Errorf("page %d dropped, viewing pages %d-%d", page, v.currentPage, v.currentPage+visible-1)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package views
+
+import "testing"
+
+// A render that finished while the login view was open went there; resuming
+// mustn't wait for it
+func TestComicResumeSessionRestartsRendering(t *testing.T) {
+	v := &ComicView{currentPage: 7, rendering: true, lastRendered: "old page"}
+	v.EndSession()
+	v.ResumeSession()
+	if v.rendering || v.lastRendered != "" {
+		t.Errorf("rendering = %v, last rendered = %q after resuming", v.rendering, v.lastRendered)
+	}
+	if v.sessionStart.IsZero() || v.sessionStartPage != 7 {
+		t.Errorf("session started %v at page %d, want now at page 7", v.sessionStart, v.sessionStartPage)
+	}
+}
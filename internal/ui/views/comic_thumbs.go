@@ -0,0 +1,229 @@
+package views
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/internal/ui/terminal"
+	"github.com/nfnt/resize"
+)
+
+// Page thumbnail grid layout
+const (
+	thumbCellWidth  = 14 // Columns per grid cell
+	thumbImageLines = 6  // Lines for the thumbnail image
+	thumbCellHeight = thumbImageLines + 1
+)
+
+// comicThumbLoadedMsg is a page thumbnail that was fetched and rendered
+type comicThumbLoadedMsg struct {
+	bookID   string
+	page     int
+	rendered string
+	err      error
+}
+
+// comicThumbsLoadedMsg is sent when a row of thumbnails has been loaded
+type comicThumbsLoadedMsg []comicThumbLoadedMsg
+
+// openThumbs shows the page grid with the cursor on the current page
+func (v *ComicView) openThumbs() tea.Cmd {
+	if v.pageCount == 0 {
+		return nil
+	}
+	terminal.ClearImagesCmd(v.termMode)()
+	v.showThumbs = true
+	v.thumbCursor = v.currentPage - 1
+	v.ensureThumbVisible()
+	return v.loadVisibleThumbs()
+}
+
+// closeThumbs hides the page grid and clears its images
+func (v *ComicView) closeThumbs() {
+	terminal.ClearImagesCmd(v.termMode)()
+	v.showThumbs = false
+}
+
+// updateThumbs handles keys while the page grid is open
+func (v *ComicView) updateThumbs(msg tea.KeyMsg) (View, tea.Cmd) {
+	cols, rows := v.thumbColumns(), v.thumbRows()
+	switch msg.String() {
+	case "h", "left":
+		v.thumbCursor--
+	case "l", "right":
+		v.thumbCursor++
+	case "k", "up":
+		v.thumbCursor -= cols
+	case "j", "down":
+		v.thumbCursor += cols
+	case "ctrl+u", "pgup":
+		v.thumbCursor -= cols * rows
+	case "ctrl+d", "pgdown":
+		v.thumbCursor += cols * rows
+	case "g", "home":
+		v.thumbCursor = 0
+	case "G", "end":
+		v.thumbCursor = v.pageCount - 1
+	case "enter":
+		v.closeThumbs()
+		return v, v.goToPage(v.thumbCursor + 1)
+	case "esc", "q", "t":
+		v.closeThumbs()
+		return v, nil
+	}
+	v.thumbCursor = max(0, min(v.thumbCursor, v.pageCount-1))
+	v.ensureThumbVisible()
+	return v, v.loadVisibleThumbs()
+}
+
+// thumbColumns returns how many thumbnails fit across the screen
+func (v *ComicView) thumbColumns() int {
+	return max(1, v.width/thumbCellWidth)
+}
+
+// thumbRows returns how many rows of thumbnails fit on screen
+func (v *ComicView) thumbRows() int {
+	return max(1, styles.ContentHeight(v.height)/thumbCellHeight)
+}
+
+// ensureThumbVisible scrolls the grid so the cursor's row is on screen
+func (v *ComicView) ensureThumbVisible() {
+	row := v.thumbCursor / v.thumbColumns()
+	if row < v.thumbOffset {
+		v.thumbOffset = row
+	}
+	if row >= v.thumbOffset+v.thumbRows() {
+		v.thumbOffset = row - v.thumbRows() + 1
+	}
+}
+
+// visibleThumbRange returns the first and past-the-end page indexes (0-based) on screen
+func (v *ComicView) visibleThumbRange() (int, int) {
+	cols := v.thumbColumns()
+	start := v.thumbOffset * cols
+	return start, min(v.pageCount, start+cols*v.thumbRows())
+}
+
+// loadVisibleThumbs starts loading thumbnails for the pages on screen, a row
+// at a time so the grid fills in from the top
+func (v *ComicView) loadVisibleThumbs() tea.Cmd {
+	if v.termMode == terminal.TermModeNone {
+		return nil // Page numbers only
+	}
+	var cmds []tea.Cmd
+	cols := v.thumbColumns()
+	start, end := v.visibleThumbRange()
+	for rowStart := start; rowStart < end; rowStart += cols {
+		var pages []int
+		for i := rowStart; i < min(rowStart+cols, end); i++ {
+			page := i + 1
+			if _, ok := v.thumbCache[page]; ok || v.thumbLoading[page] {
+				continue
+			}
+			v.thumbLoading[page] = true
+			pages = append(pages, page)
+		}
+		if len(pages) > 0 {
+			cmds = append(cmds, v.loadThumbs(pages))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Sequence(cmds...)
+}
+
+// loadThumbs fetches pages (through the disk cache) together and renders small
+// thumbnails of them
+func (v *ComicView) loadThumbs(pages []int) tea.Cmd {
+	bookID := v.book.ID
+	termMode := v.termMode
+	return func() tea.Msg {
+		var thumbs comicThumbsLoadedMsg
+		for _, page := range v.fetchPages(bookID, pages) {
+			thumbs = append(thumbs, renderThumb(bookID, page, termMode))
+		}
+		return thumbs
+	}
+}
+
+// renderThumb renders a fetched page as a thumbnail
+func renderThumb(bookID string, page api.ComicPage, termMode terminal.TermImageMode) comicThumbLoadedMsg {
+	if page.Err != nil {
+		return comicThumbLoadedMsg{bookID: bookID, page: page.Index, err: page.Err}
+	}
+	img, _, err := image.Decode(bytes.NewReader(page.Data))
+	if err != nil {
+		return comicThumbLoadedMsg{bookID: bookID, page: page.Index, err: err}
+	}
+	// Height in pixels, roughly 8 pixels per line (as for library covers)
+	thumb := resize.Resize(0, uint(thumbImageLines*8), img, resize.Lanczos3)
+	rendered, err := terminal.RenderImageInCells(thumb, termMode, thumbCellWidth, thumbImageLines)
+	return comicThumbLoadedMsg{bookID: bookID, page: page.Index, rendered: rendered, err: err}
+}
+
+// handleThumbsLoaded caches rendered thumbnails; failed pages show their number only
+func (v *ComicView) handleThumbsLoaded(msg comicThumbsLoadedMsg) (View, tea.Cmd) {
+	for _, thumb := range msg {
+		if thumb.bookID != v.book.ID {
+			continue
+		}
+		delete(v.thumbLoading, thumb.page)
+		v.thumbCache[thumb.page] = thumb.rendered
+	}
+	return v, nil
+}
+
+// renderThumbs renders the page grid
+func (v *ComicView) renderThumbs() string {
+	cols := v.thumbColumns()
+	start, end := v.visibleThumbRange()
+
+	var rows []string
+	for rowStart := start; rowStart < end; rowStart += cols {
+		var cells []string
+		for i := rowStart; i < min(rowStart+cols, end); i++ {
+			cells = append(cells, v.renderThumbCell(i+1, i == v.thumbCursor))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	header := styles.HeaderContent(styles.TruncateText(v.book.Title, v.width/2), fmt.Sprintf("Page %d/%d", v.thumbCursor+1, v.pageCount), v.width)
+	help := []string{
+		styles.HelpKey.Render("hjkl") + styles.Help.Render(" move"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" go to page"),
+		styles.HelpKey.Render("esc") + styles.Help.Render(" close"),
+	}
+	return styles.RenderLayout(header, strings.Join(rows, "\n"), strings.Join(help, "  "), v.width, v.height)
+}
+
+// renderThumbCell renders one page thumbnail with its page number underneath
+func (v *ComicView) renderThumbCell(page int, selected bool) string {
+	// Without image support (or if the page failed to load) only the number is shown
+	thumb := v.thumbCache[page]
+	if v.thumbLoading[page] {
+		thumb = styles.MutedText.Render("[...]")
+	}
+	imageCell := lipgloss.NewStyle().
+		Width(thumbCellWidth).
+		Height(thumbImageLines).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(thumb)
+
+	label := fmt.Sprintf("%d", page)
+	labelStyle := styles.MutedText
+	switch {
+	case selected:
+		label = "▸ " + label
+		labelStyle = styles.HelpKey
+	case page == v.currentPage:
+		labelStyle = styles.BookAuthor
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, imageCell, labelStyle.Width(thumbCellWidth).Align(lipgloss.Center).Render(label))
+}
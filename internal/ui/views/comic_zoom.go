@@ -0,0 +1,128 @@
+package views
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// Zoom limits and the factor applied by each +/- press or wheel notch
+const (
+	minZoom        = 1.0
+	maxZoom        = 8.0
+	zoomStep       = 1.25
+	maxZoomDigits  = 3 // Percent input, up to 800
+	zoomPercentMin = int(minZoom * 100)
+	zoomPercentMax = int(maxZoom * 100)
+)
+
+// setZoom changes the zoom level, clamped to minZoom-maxZoom.
+// Returning to 1x re-centers the view.
+func (v *ComicView) setZoom(zoom float64) {
+	v.zoom = math.Max(minZoom, math.Min(maxZoom, zoom))
+	// Snap to 1x so repeated steps don't leave it just above
+	if v.zoom < minZoom+0.01 {
+		v.zoom = minZoom
+		v.panX = 0.5
+		v.panY = 0.5
+	}
+}
+
+func (v *ComicView) zoomIn() {
+	v.setZoom(v.currentZoom() * zoomStep)
+}
+
+func (v *ComicView) zoomOut() {
+	v.setZoom(v.currentZoom() / zoomStep)
+}
+
+// zoomPercent returns the zoom level as a whole percentage
+func (v *ComicView) zoomPercent() int {
+	return int(math.Round(v.currentZoom() * 100))
+}
+
+// handleMouse zooms with the wheel, or scrolls the strip in webtoon mode
+func (v *ComicView) handleMouse(msg tea.MouseMsg) (View, tea.Cmd) {
+	if v.CapturingKeys() || v.pageCount == 0 || msg.Action != tea.MouseActionPress {
+		return v, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if v.webtoon {
+			cmd, _ := v.updateStrip("k")
+			return v, cmd
+		}
+		v.zoomIn()
+	case tea.MouseButtonWheelDown:
+		if v.webtoon {
+			cmd, _ := v.updateStrip("j")
+			return v, cmd
+		}
+		v.zoomOut()
+	}
+	return v, nil
+}
+
+// openZoomPrompt starts the zoom percentage prompt in the footer
+func (v *ComicView) openZoomPrompt() tea.Cmd {
+	if v.pageCount == 0 {
+		return nil
+	}
+	v.zoomMode = true
+	return v.zoomInput.open(zoomPercentMin, zoomPercentMax, fmt.Sprintf("Zoom must be %d-%d%%", zoomPercentMin, zoomPercentMax))
+}
+
+// updateZoomPrompt handles keys while typing a zoom percentage
+func (v *ComicView) updateZoomPrompt(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.zoomMode = false
+		v.zoomInput.input.Blur()
+	case "enter":
+		percent, ok := v.zoomInput.number()
+		if !ok {
+			return v, nil // Keep the prompt open with its error
+		}
+		v.zoomMode = false
+		v.zoomInput.input.Blur()
+		v.setZoom(float64(percent) / 100)
+	default:
+		return v, v.zoomInput.update(msg)
+	}
+	return v, nil
+}
+
+// renderZoomPrompt renders the zoom percentage prompt for the footer
+func (v *ComicView) renderZoomPrompt() string {
+	return styles.HelpKey.Render("zoom ") + v.zoomInput.view(styles.MutedText.Render("%"), "enter set • esc cancel")
+}
+
+// zoomLocked reports whether zoom and vertical pan carry over between pages
+func (v *ComicView) zoomLocked() bool {
+	return v.config != nil && v.config.ComicZoomLock
+}
+
+// toggleZoomLock turns carrying zoom and vertical pan across page turns on or off
+func (v *ComicView) toggleZoomLock() {
+	if v.config == nil {
+		return
+	}
+	_ = v.config.SetComicZoomLock(!v.config.ComicZoomLock)
+	if v.zoomLocked() {
+		v.statusMsg = "Zoom locked across page turns"
+	} else {
+		v.statusMsg = "Zoom unlocked"
+	}
+}
+
+// resetPageZoomPan resets zoom and pan for a newly shown page. With the lock
+// on, the zoom and vertical position are kept and only the horizontal pan re-centers.
+func (v *ComicView) resetPageZoomPan() {
+	if !v.zoomLocked() {
+		v.resetZoomPan()
+		return
+	}
+	v.panX = 0.5
+}
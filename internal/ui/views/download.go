@@ -0,0 +1,126 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// downloadProgressInterval is how often the progress indicator is redrawn
+const downloadProgressInterval = 250 * time.Millisecond
+
+// bookDownload is a book file being saved to the download directory
+type bookDownload struct {
+	book    models.Book
+	path    string
+	written atomic.Int64
+	total   atomic.Int64 // File size from the server, 0 if unknown
+}
+
+// downloadProgressMsg redraws a download's progress while it runs
+type downloadProgressMsg struct {
+	download *bookDownload
+}
+
+// downloadDoneMsg is sent when a download finishes
+type downloadDoneMsg struct {
+	download *bookDownload
+	err      error
+}
+
+// report records the bytes received so far
+func (d *bookDownload) report(written, total int64) {
+	d.written.Store(written)
+	d.total.Store(total)
+}
+
+// downloadDir returns the configured download directory, or the working directory without a config
+func downloadDir(cfg *config.Config) string {
+	if cfg == nil {
+		return "."
+	}
+	return cfg.GetDownloadDir()
+}
+
+// startDownload saves book's original file into dir under an unused name
+func startDownload(client *api.Client, book models.Book, dir string) (*bookDownload, tea.Cmd) {
+	d := &bookDownload{book: book, path: downloadPath(dir, book)}
+	run := func() tea.Msg {
+		return downloadDoneMsg{download: d, err: d.run(client)}
+	}
+	return d, tea.Batch(run, d.tick())
+}
+
+// run downloads to a partial file, renamed into place once complete
+func (d *bookDownload) run(client *api.Client) error {
+	if err := os.MkdirAll(filepath.Dir(d.path), 0755); err != nil {
+		return err
+	}
+	partial := d.path + ".part"
+	file, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(partial)
+
+	if err := client.DownloadBookProgress(d.book.ID, file, d.report); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partial, d.path)
+}
+
+// tick schedules the next progress redraw
+func (d *bookDownload) tick() tea.Cmd {
+	return tea.Tick(downloadProgressInterval, func(time.Time) tea.Msg {
+		return downloadProgressMsg{download: d}
+	})
+}
+
+// progress describes how far the download has got, e.g. "Downloading Dune... 42% of 1.2 MB"
+func (d *bookDownload) progress() string {
+	written, total := d.written.Load(), d.book.FileSize
+	if total <= 0 {
+		total = d.total.Load()
+	}
+	if total <= 0 {
+		return fmt.Sprintf("Downloading %s... %s", d.book.Title, formatFileSize(written))
+	}
+	percent := min(100, int(written*100/total))
+	return fmt.Sprintf("Downloading %s... %d%% of %s", d.book.Title, percent, formatFileSize(total))
+}
+
+// result describes a finished download
+func (msg downloadDoneMsg) result() string {
+	if msg.err != nil {
+		return "Download failed: " + msg.err.Error()
+	}
+	return "Saved to " + msg.download.path
+}
+
+// downloadPath returns a file in dir named after the book that doesn't exist yet,
+// numbering the name ("Title (2).epub") if needed
+func downloadPath(dir string, book models.Book) string {
+	fileName := book.FileName()
+	ext := filepath.Ext(fileName)
+	name := strings.TrimSuffix(fileName, ext)
+
+	path := filepath.Join(dir, fileName)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+	}
+}
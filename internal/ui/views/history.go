@@ -0,0 +1,216 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// HistoryView lists past reading sessions and reopens their books
+type HistoryView struct {
+	client *api.Client
+	config *config.Config
+
+	// Sessions, newest first
+	sessions []config.ReadingSession
+	cursor   int
+	offset   int
+
+	// State
+	opening bool // Fetching the selected book before opening it
+	err     error
+
+	// Dimensions
+	width  int
+	height int
+}
+
+// NewHistoryView creates a new reading history view
+func NewHistoryView(client *api.Client, cfg *config.Config) *HistoryView {
+	return &HistoryView{
+		client: client,
+		config: cfg,
+		width:  80,
+		height: 24,
+	}
+}
+
+// historyBookLoadedMsg is sent when the book for a history entry has been fetched
+type historyBookLoadedMsg struct {
+	book *models.Book
+	err  error
+}
+
+// Init implements View
+func (v *HistoryView) Init() tea.Cmd {
+	v.sessions = v.config.GetHistory()
+	v.opening = false
+	v.err = nil
+	if v.cursor >= len(v.sessions) {
+		v.cursor = max(0, len(v.sessions)-1)
+	}
+	return nil
+}
+
+// Update implements View
+func (v *HistoryView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if v.cursor < len(v.sessions)-1 {
+				v.cursor++
+			}
+		case "k", "up":
+			if v.cursor > 0 {
+				v.cursor--
+			}
+		case "g", "home":
+			v.cursor = 0
+		case "G", "end":
+			v.cursor = max(0, len(v.sessions)-1)
+		case "ctrl+d", "pgdown":
+			v.cursor = min(max(0, len(v.sessions)-1), v.cursor+v.visibleRows()/2)
+		case "ctrl+u", "pgup":
+			v.cursor = max(0, v.cursor-v.visibleRows()/2)
+		case "enter":
+			// Reopen the selected book
+			if v.cursor < len(v.sessions) && !v.opening {
+				v.opening = true
+				v.err = nil
+				return v, v.loadBook(v.sessions[v.cursor].BookID)
+			}
+		}
+		v.ensureCursorVisible()
+
+	case historyBookLoadedMsg:
+		v.opening = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		book := *msg.book
+		return v, func() tea.Msg {
+			return OpenBookMsg{Book: book}
+		}
+	}
+	return v, nil
+}
+
+// View implements View
+func (v *HistoryView) View() string {
+	count := fmt.Sprintf("%d sessions", len(v.sessions))
+	header := styles.HeaderContent("Reading History", count, v.width)
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the session list
+func (v *HistoryView) renderContent() string {
+	if len(v.sessions) == 0 {
+		return styles.RenderCenteredContent(styles.MutedText.Render("No reading sessions yet."), v.width, styles.ContentHeight(v.height))
+	}
+
+	var b strings.Builder
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render("Error: "+v.err.Error()) + "\n")
+	} else if v.opening {
+		b.WriteString(styles.MutedText.Render("Opening...") + "\n")
+	}
+
+	// Fixed-width columns: date, progress, duration; the title takes the rest
+	const dateWidth, progressWidth, durationWidth = 14, 12, 8
+	titleWidth := max(10, v.width-dateWidth-progressWidth-durationWidth-8)
+
+	end := min(len(v.sessions), v.offset+v.visibleRows())
+	for i := v.offset; i < end; i++ {
+		s := v.sessions[i]
+		line := padRight(s.StartedAt.Local().Format("Jan 02 15:04"), dateWidth) +
+			padRight(s.Title, titleWidth) + "  " +
+			padRight(sessionProgress(s), progressWidth) +
+			padRight(formatSessionDuration(s.Duration()), durationWidth)
+
+		if i == v.cursor {
+			b.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
+		} else {
+			b.WriteString(styles.ListItem.Render("  "+line) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderFooter renders the footer help content
+func (v *HistoryView) renderFooter() string {
+	var total time.Duration
+	for _, s := range v.sessions {
+		total += s.Duration()
+	}
+	help := []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" open"),
+		styles.HelpKey.Render("esc") + styles.Help.Render(" back"),
+		styles.MutedText.Render("total " + formatSessionDuration(total)),
+	}
+	return strings.Join(help, "  ")
+}
+
+// SetSize implements View
+func (v *HistoryView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.ensureCursorVisible()
+}
+
+// visibleRows returns how many sessions fit in the content area
+func (v *HistoryView) visibleRows() int {
+	return max(1, styles.ContentHeight(v.height)-1)
+}
+
+// ensureCursorVisible scrolls the list so the cursor stays on screen
+func (v *HistoryView) ensureCursorVisible() {
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.visibleRows() {
+		v.offset = v.cursor - v.visibleRows() + 1
+	}
+}
+
+// loadBook fetches a book so it can be reopened
+func (v *HistoryView) loadBook(bookID string) tea.Cmd {
+	return func() tea.Msg {
+		book, err := v.client.GetBook(bookID)
+		return historyBookLoadedMsg{book: book, err: err}
+	}
+}
+
+// sessionProgress describes the chapters (or comic pages) covered by a session
+func sessionProgress(s config.ReadingSession) string {
+	if s.Comic {
+		if s.StartChapter == s.EndChapter {
+			return fmt.Sprintf("p. %d", s.StartChapter)
+		}
+		return fmt.Sprintf("p. %d–%d", s.StartChapter, s.EndChapter)
+	}
+	if s.StartChapter == s.EndChapter {
+		return fmt.Sprintf("ch. %d", s.StartChapter+1)
+	}
+	return fmt.Sprintf("ch. %d–%d", s.StartChapter+1, s.EndChapter+1)
+}
+
+// formatSessionDuration formats a duration as "1h05m", "12m" or "40s"
+func formatSessionDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
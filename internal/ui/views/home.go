@@ -0,0 +1,370 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Home dashboard limits
+const (
+	homeMaxCards  = 6  // Continue-reading cards
+	homeListSize  = 5  // Books in the queue and recently added lists
+	homeCardWidth = 28 // Card width including its border
+)
+
+// homeSection is one part of the home dashboard
+type homeSection int
+
+const (
+	homeContinue homeSection = iota
+	homeQueue
+	homeRecent
+	homeSections
+)
+
+// Title returns the section heading
+func (s homeSection) Title() string {
+	switch s {
+	case homeContinue:
+		return "Continue reading"
+	case homeQueue:
+		return "Up next in your queue"
+	default:
+		return "Recently added"
+	}
+}
+
+// homeItem is a selectable book on the dashboard
+type homeItem struct {
+	section homeSection
+	book    models.Book
+}
+
+// homeLoadedMsg carries the books shown on the dashboard
+type homeLoadedMsg struct {
+	reading []models.Book
+	queue   []models.Book
+	recent  []models.Book
+	err     error
+}
+
+// HomeView is the landing dashboard: books in progress, the head of the reading
+// queue and the newest uploads
+type HomeView struct {
+	client *api.Client
+	config *config.Config
+
+	// Dashboard books, in section order
+	items   []homeItem
+	cursor  int
+	loading bool
+	loaded  bool
+	err     error
+
+	// Dimensions
+	width  int
+	height int
+}
+
+// NewHomeView creates a new home dashboard
+func NewHomeView(client *api.Client, cfg *config.Config) *HomeView {
+	return &HomeView{
+		client: client,
+		config: cfg,
+		width:  80,
+		height: 24,
+	}
+}
+
+// Init implements View. The dashboard is reloaded each time it is shown, so
+// progress made in the reader is reflected.
+func (v *HomeView) Init() tea.Cmd {
+	return v.load()
+}
+
+// load fetches the books in progress, the queue head and the newest uploads.
+// Books that can no longer be fetched are left out.
+func (v *HomeView) load() tea.Cmd {
+	v.loading = true
+	var readingIDs []string
+	for _, entry := range v.config.RecentlyRead {
+		if len(readingIDs) < homeMaxCards && v.config.GetReadStatus(entry.BookID) == config.StatusReading {
+			readingIDs = append(readingIDs, entry.BookID)
+		}
+	}
+	queueIDs := v.config.GetQueueIDs()
+	if len(queueIDs) > homeListSize {
+		queueIDs = queueIDs[:homeListSize]
+	}
+	queueIDs = append([]string(nil), queueIDs...)
+
+	return func() tea.Msg {
+		resp, err := v.client.ListBooks(1, homeListSize, sortDate.String(), "desc", "", "")
+		if err != nil {
+			return homeLoadedMsg{err: err}
+		}
+		return homeLoadedMsg{
+			reading: v.fetchBooks(readingIDs),
+			queue:   v.fetchBooks(queueIDs),
+			recent:  resp.Books,
+		}
+	}
+}
+
+// fetchBooks looks up books by ID, skipping any that fail
+func (v *HomeView) fetchBooks(ids []string) []models.Book {
+	var books []models.Book
+	for _, id := range ids {
+		if book, err := v.client.GetBook(id); err == nil {
+			books = append(books, *book)
+		}
+	}
+	return books
+}
+
+// Update implements View
+func (v *HomeView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case homeLoadedMsg:
+		v.handleLoaded(msg)
+	case tea.KeyMsg:
+		return v.handleKeys(msg)
+	case ServerEventMsg:
+		// Every section can change when a book or position changes elsewhere
+		if v.loaded && !v.loading {
+			return v, v.load()
+		}
+	}
+	return v, nil
+}
+
+// handleLoaded lays out the loaded books, keeping the cursor where it was
+func (v *HomeView) handleLoaded(msg homeLoadedMsg) {
+	v.loading = false
+	if msg.err != nil {
+		v.err = msg.err
+		return
+	}
+	v.err = nil
+	v.loaded = true
+	v.items = v.items[:0]
+	for section, books := range [][]models.Book{msg.reading, msg.queue, msg.recent} {
+		for _, book := range books {
+			v.items = append(v.items, homeItem{section: homeSection(section), book: book})
+		}
+	}
+	v.cursor = max(0, min(v.cursor, len(v.items)-1))
+}
+
+// handleKeys handles dashboard navigation and shortcuts to the other views
+func (v *HomeView) handleKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down", "l", "right":
+		v.cursor = min(v.cursor+1, max(0, len(v.items)-1))
+	case "k", "up", "h", "left":
+		v.cursor = max(0, v.cursor-1)
+	case "g", "home":
+		v.cursor = 0
+	case "G", "end":
+		v.cursor = max(0, len(v.items)-1)
+	case "tab":
+		v.jumpSection(1)
+	case "shift+tab":
+		v.jumpSection(-1)
+	case "enter":
+		if item, ok := v.selectedItem(); ok {
+			return v, func() tea.Msg { return OpenBookMsg{Book: item.book} }
+		}
+	case "i":
+		if item, ok := v.selectedItem(); ok {
+			return v, func() tea.Msg { return ShowBookDetailsMsg{Book: item.book} }
+		}
+	case "r":
+		return v, v.load()
+	case "L", "/":
+		return v, SwitchTo(ViewLibrary)
+	case "c":
+		return v, SwitchTo(ViewCollections)
+	case "a":
+		return v, SwitchTo(ViewUpload)
+	case "H":
+		return v, SwitchTo(ViewHistory)
+	case ",":
+		return v, SwitchTo(ViewSettings)
+	case "P":
+		return v, SwitchTo(ViewProfiles)
+	}
+	return v, nil
+}
+
+// jumpSection moves the cursor to the first book of the next (or previous)
+// section that has any
+func (v *HomeView) jumpSection(delta int) {
+	item, ok := v.selectedItem()
+	if !ok {
+		return
+	}
+	for section := item.section + homeSection(delta); section >= 0 && section < homeSections; section += homeSection(delta) {
+		for i, other := range v.items {
+			if other.section == section {
+				v.cursor = i
+				return
+			}
+		}
+	}
+}
+
+// selectedItem returns the book under the cursor
+func (v *HomeView) selectedItem() (homeItem, bool) {
+	if v.cursor >= 0 && v.cursor < len(v.items) {
+		return v.items[v.cursor], true
+	}
+	return homeItem{}, false
+}
+
+// sectionRange returns the index of a section's first book and how many it has
+func (v *HomeView) sectionRange(section homeSection) (first, count int) {
+	first = len(v.items)
+	for i, item := range v.items {
+		if item.section == section {
+			first = min(first, i)
+			count++
+		}
+	}
+	return first, count
+}
+
+// scrollStart returns the first book to show of a section when only rows fit,
+// keeping the cursor in view while it is in the section
+func (v *HomeView) scrollStart(first, count, rows int) int {
+	if v.cursor < first || v.cursor >= first+count {
+		return first
+	}
+	return max(first, v.cursor-rows+1)
+}
+
+// View implements View
+func (v *HomeView) View() string {
+	right := ""
+	if v.config.Username != "" {
+		right = "Signed in as " + v.config.Username
+	}
+	header := styles.HeaderContent("Home", right, v.width)
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the dashboard sections
+func (v *HomeView) renderContent() string {
+	if !v.loaded {
+		if v.err != nil {
+			return styles.ErrorStyle.Render("Error: " + v.err.Error())
+		}
+		return styles.RenderCenteredContent(styles.MutedText.Render("Loading..."), v.width, styles.ContentHeight(v.height))
+	}
+
+	var b strings.Builder
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render("Error: "+v.err.Error()) + "\n\n")
+	}
+
+	// The two lists share the height left after the cards
+	listRows := max(1, min(homeListSize, (styles.ContentHeight(v.height)-12)/2))
+
+	b.WriteString(styles.HelpKey.Render(homeContinue.Title()) + "\n")
+	b.WriteString(v.renderCards() + "\n\n")
+	b.WriteString(styles.HelpKey.Render(homeQueue.Title()) + "\n")
+	b.WriteString(v.renderList(homeQueue, listRows, "Queue is empty. Press w on a book in the library to add it.") + "\n\n")
+	b.WriteString(styles.HelpKey.Render(homeRecent.Title()) + "\n")
+	b.WriteString(v.renderList(homeRecent, listRows, "No books yet. Press a to upload one."))
+	return b.String()
+}
+
+// renderCards renders books in progress as a row of cards with their progress
+func (v *HomeView) renderCards() string {
+	first, count := v.sectionRange(homeContinue)
+	fit := max(1, (v.width-2)/homeCardWidth)
+	var cards []string
+	for i := v.scrollStart(first, count, fit); i < first+count && len(cards) < fit; i++ {
+		cards = append(cards, v.renderCard(v.items[i].book, i == v.cursor))
+	}
+	if len(cards) == 0 {
+		return "  " + styles.MutedText.Render("Nothing in progress. Books you start reading show up here.")
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cards...)
+}
+
+// renderCard renders one continue-reading card
+func (v *HomeView) renderCard(book models.Book, selected bool) string {
+	inner := homeCardWidth - 4
+	fraction, _ := v.config.GetProgress(book.ID)
+	title := styles.MutedText.Render(truncateText(book.Title, inner))
+	border := styles.Border
+	if selected {
+		title = styles.SecondaryText.Bold(true).Render(truncateText(book.Title, inner))
+		border = styles.Primary
+	}
+	progress := fmt.Sprintf("%s %d%%", progressBar(fraction, inner-5), int(fraction*100))
+	body := title + "\n" +
+		styles.BookAuthor.Render(truncateText(book.Author, inner)) + "\n" +
+		styles.SecondaryText.Render(progress)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Width(homeCardWidth - 2).
+		Render(body)
+}
+
+// renderList renders one section's books as a list, or a hint when it is empty
+func (v *HomeView) renderList(section homeSection, rows int, empty string) string {
+	first, count := v.sectionRange(section)
+	titleWidth := max(10, v.width/2)
+	var lines []string
+	for i := v.scrollStart(first, count, rows); i < first+count && len(lines) < rows; i++ {
+		book := v.items[i].book
+		title := truncateText(book.Title, titleWidth)
+		meta := "  " + truncateText(book.Author, max(10, v.width/4))
+		if section == homeRecent && !book.UploadedAt.IsZero() {
+			meta += "  " + book.UploadedAt.Format("Jan 2")
+		}
+		if i == v.cursor {
+			lines = append(lines, styles.SecondaryText.Render("▸ ")+styles.SecondaryText.Bold(true).Render(title)+styles.BookAuthor.Render(meta))
+		} else {
+			lines = append(lines, "  "+styles.MutedText.Render(title)+styles.BookAuthor.Render(meta))
+		}
+	}
+	if len(lines) == 0 {
+		return "  " + styles.MutedText.Render(empty)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderFooter renders the footer help content
+func (v *HomeView) renderFooter() string {
+	if v.loading && v.loaded {
+		return styles.MutedText.Render("Refreshing...")
+	}
+	help := []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
+		styles.HelpKey.Render("tab") + styles.Help.Render(" section"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" open"),
+		styles.HelpKey.Render("i") + styles.Help.Render(" info"),
+		styles.HelpKey.Render("L") + styles.Help.Render(" library"),
+		styles.HelpKey.Render("c") + styles.Help.Render(" collections"),
+		styles.HelpKey.Render("H") + styles.Help.Render(" history"),
+	}
+	return strings.Join(help, "  ")
+}
+
+// SetSize implements View
+func (v *HomeView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
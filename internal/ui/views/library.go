@@ -2,12 +2,17 @@ package views
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	_ "image/jpeg"
 	_ "image/png"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -121,10 +126,24 @@ type LibraryView struct {
 	recentlyReadMode bool
 	favoritesMode    bool         // Show only favorites
 	queueMode        bool         // Show only reading queue
+	recentlyAdded    bool         // Newest uploads first, whatever the sort (N)
+	sharedMode       bool         // Show books other users shared with me (O)
 	confirmDelete    bool         // Show delete confirmation
 	deleteBook       *models.Book // Book pending deletion
 	filterAuthor     string       // Filter by author name
 	filterSeries     string       // Filter by series name
+	filterTag        string       // Filter by tag
+	filterStatus     string       // Filter by read status (unread, reading, finished)
+	minRating        int          // Show only books rated at least this, best first (*)
+	showArchived     bool         // Show only archived books, which are otherwise hidden (Z)
+
+	// Live search: results reload as the query is typed
+	searchSeq    int                // Bumped on each edit; only the latest pause searches
+	cancelSearch context.CancelFunc // Cancels the live search request in flight
+
+	// Books loaded so far, matched fuzzily when a search finds nothing on the server
+	seenBooks []models.Book
+	seenIDs   map[string]bool
 
 	// Sorting
 	sortBy    sortField
@@ -138,11 +157,66 @@ type LibraryView struct {
 	pageSize  int
 	total     int
 
+	// Page jump prompt (:)
+	jumpingPage bool
+	pageInput   textinput.Model
+
+	loadingMore bool // Infinite scroll is fetching the next page to append
+
 	// Thumbnail support
 	termMode   terminal.TermImageMode
 	coverCache map[string]string // Rendered image strings by book ID
 	showCovers bool              // Toggle for showing covers (default true if supported)
 
+	// Cover grid: books shown as a wall of larger covers
+	gridMode       bool
+	gridOffset     int               // First visible grid row
+	gridCoverCache map[string]string // Rendered grid covers by book ID
+
+	// Multi-select: marked books are the target of f/w/d and collection adds
+	marked            map[string]bool
+	pickingCollection bool // Collection picker open
+	collections       []models.Collection
+	collectionCursor  int
+	statusMsg         string // Result of the last batch action, cleared on the next key
+
+	// Cover replacement: a file picker for the new cover image
+	pickingCover bool
+	coverPicker  filepicker.Model
+	coverBook    *models.Book // Book whose cover is being replaced
+
+	download *bookDownload // Book file being downloaded (D), nil when idle
+
+	// Tags: a picker to tag the target books or filter by a tag
+	pickingTag bool
+	tagChoices []string
+	tagCursor  int
+	tagInput   textinput.Model // New tag name, focused while typing one
+	localTags  bool            // The server doesn't store tags, so they are kept in the config
+
+	// Smart collections: the current filters saved under a name (ctrl+s)
+	savingFilter   bool
+	smartNameInput textinput.Model
+
+	// Grouped browsing (B): books listed under collapsible headings instead of pages
+	browse         browseMode
+	groups         []bookGroup
+	groupsLoading  bool
+	expandedGroups map[string]bool // Expanded headings by name
+	browseCursor   int
+	browseOffset   int
+
+	// Auto-refresh: periodic checks for books added or removed on the server
+	pollSeq     int       // Current chain of checks; older timers are ignored
+	polled      bool      // A check has recorded where the library stands
+	polledTotal int       // Book count on the server as of the last check
+	lastUpload  time.Time // Newest upload seen by a check
+
+	// Library summary popup (I)
+	showingStats bool
+	stats        *models.LibraryStats // nil while loading
+	statsErr     error
+
 	// Dimensions
 	width  int
 	height int
@@ -150,33 +224,63 @@ type LibraryView struct {
 
 // NewLibraryView creates a new library view
 func NewLibraryView(client *api.Client, cfg *config.Config) *LibraryView {
-	searchInput := textinput.New()
-	searchInput.Placeholder = "Search books..."
+	searchInput := newTextInput()
+	searchInput.Placeholder = "Search books (author:, series:, format:)..."
 	searchInput.CharLimit = 100
 	searchInput.Width = 40
 
-	termMode := terminal.DetectTerminalMode()
-	return &LibraryView{
-		client:      client,
-		config:      cfg,
-		pageSize:    50,
-		page:        1,
-		sortBy:      sortTitle,
-		sortAsc:     true,
-		searchInput: searchInput,
-		termMode:    termMode,
-		coverCache:  make(map[string]string),
-		showCovers:  false, // Disabled by default - press C to enable
-		width:       80,
-		height:      24,
+	tagInput := newTextInput()
+	tagInput.Placeholder = "New tag..."
+	tagInput.CharLimit = 40
+	tagInput.Width = 30
+
+	smartNameInput := newTextInput()
+	smartNameInput.Placeholder = "Smart collection name..."
+	smartNameInput.CharLimit = 60
+	smartNameInput.Width = 30
+
+	pageInput := newTextInput()
+	pageInput.CharLimit = 6
+	pageInput.Width = 8
+
+	pageSize := config.DefaultPageSize
+	if cfg != nil {
+		pageSize = cfg.GetPageSize()
 	}
+
+	termMode := terminal.DetectTerminalMode()
+	v := &LibraryView{
+		client:         client,
+		config:         cfg,
+		pageSize:       pageSize,
+		page:           1,
+		sortBy:         sortTitle,
+		sortAsc:        true,
+		searchInput:    searchInput,
+		tagInput:       tagInput,
+		smartNameInput: smartNameInput,
+		pageInput:      pageInput,
+		termMode:       termMode,
+		coverCache:     make(map[string]string),
+		gridCoverCache: make(map[string]string),
+		marked:         make(map[string]bool),
+		expandedGroups: make(map[string]bool),
+		seenIDs:        make(map[string]bool),
+		showCovers:     false, // Disabled by default - press C to enable
+		width:          80,
+		height:         24,
+	}
+	v.restoreState()
+	return v
 }
 
 // booksLoadedMsg is sent when books are loaded
 type booksLoadedMsg struct {
-	books []models.Book
-	total int
-	err   error
+	books    []models.Book
+	total    int
+	fuzzy    bool // Close matches from loaded books; the server found none
+	appended bool // Next page for infinite scroll, added to the end of the list
+	err      error
 }
 
 // bookDeletedMsg is sent when a book is deleted
@@ -185,49 +289,79 @@ type bookDeletedMsg struct {
 	err    error
 }
 
-// coverLoadedMsg is sent when a book cover is fetched and rendered
+// coverLoadedMsg is a book cover that was fetched and rendered
 type coverLoadedMsg struct {
 	bookID        string
 	renderedImage string
+	grid          bool // Rendered at grid size rather than list size
 	err           error
 }
 
-// loadCoverCmd creates a command to fetch, render, and cache a book cover
-func (v *LibraryView) loadCoverCmd(bookID string) tea.Cmd {
+// coversLoadedMsg carries the covers fetched by one loadCoversCmd
+type coversLoadedMsg []coverLoadedMsg
+
+// loadCoversCmd creates a command to fetch, render, and cache the covers of
+// the given books that aren't cached yet, a few at a time
+func (v *LibraryView) loadCoversCmd(bookIDs []string) tea.Cmd {
 	if v.termMode == terminal.TermModeNone {
 		return nil // No image support
 	}
-	if _, exists := v.coverCache[bookID]; exists {
+	grid := v.gridMode
+	cache := v.coverCache
+	if grid {
+		cache = v.gridCoverCache
+	}
+	var missing []string
+	for _, id := range bookIDs {
+		if _, exists := cache[id]; !exists {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
 		return nil // Already cached
 	}
 
 	return func() tea.Msg {
-		imgData, _, err := v.client.GetBookCover(bookID)
-		if err != nil || len(imgData) == 0 {
-			return coverLoadedMsg{bookID: bookID, err: err}
-		}
+		covers := make(coversLoadedMsg, len(missing))
+		_ = api.ForEach(context.Background(), len(missing), api.DefaultParallelism, func(ctx context.Context, i int) error {
+			covers[i] = v.renderCover(missing[i], grid)
+			return nil // One missing cover shouldn't stop the rest
+		})
+		return covers
+	}
+}
 
-		img, _, err := image.Decode(bytes.NewReader(imgData))
-		if err != nil {
-			return coverLoadedMsg{bookID: bookID, err: err}
-		}
+// renderCover fetches a book cover and renders it at list or grid size
+func (v *LibraryView) renderCover(bookID string, grid bool) coverLoadedMsg {
+	width, height := thumbWidth, thumbHeight
+	if grid {
+		width, height = gridCoverWidth, gridCoverLines
+	}
+	imgData, _, err := v.client.GetBookCover(bookID)
+	if err != nil || len(imgData) == 0 {
+		return coverLoadedMsg{bookID: bookID, grid: grid, err: err}
+	}
 
-		// Resize to thumbnail size (height in pixels, roughly 8 pixels per line)
-		resizedImg := resize.Resize(0, uint(thumbHeight*8), img, resize.Lanczos3)
+	img, _, err := image.Decode(bytes.NewReader(imgData))
+	if err != nil {
+		return coverLoadedMsg{bookID: bookID, grid: grid, err: err}
+	}
 
-		renderedImage, err := terminal.RenderImageToString(resizedImg, v.termMode)
-		if err != nil {
-			return coverLoadedMsg{bookID: bookID, err: err}
-		}
+	// Resize to thumbnail size (height in pixels, roughly 8 pixels per line)
+	resizedImg := resize.Resize(0, uint(height*8), img, resize.Lanczos3)
 
-		return coverLoadedMsg{bookID: bookID, renderedImage: renderedImage}
+	renderedImage, err := terminal.RenderImageInCells(resizedImg, v.termMode, width, height)
+	if err != nil {
+		return coverLoadedMsg{bookID: bookID, grid: grid, err: err}
 	}
+
+	return coverLoadedMsg{bookID: bookID, renderedImage: renderedImage, grid: grid}
 }
 
 // Init implements View
 func (v *LibraryView) Init() tea.Cmd {
 	v.loading = true
-	return v.loadBooks()
+	return tea.Batch(v.loadBooks(), v.checkForChanges())
 }
 
 // Update implements View - delegates to specialized handlers
@@ -236,11 +370,62 @@ func (v *LibraryView) Update(msg tea.Msg) (View, tea.Cmd) {
 	case tea.KeyMsg:
 		return v.handleKeyMsg(msg)
 	case booksLoadedMsg:
+		if msg.appended {
+			return v, v.handleMoreBooksLoaded(msg)
+		}
 		return v, v.handleBooksLoaded(msg)
-	case coverLoadedMsg:
-		return v, v.handleCoverLoaded(msg)
+	case ServerEventMsg:
+		return v, v.handleServerEvent(msg)
+	case coversLoadedMsg:
+		for _, cover := range msg {
+			v.handleCoverLoaded(cover)
+		}
+		return v, nil
 	case bookDeletedMsg:
 		return v, v.handleBookDeleted(msg)
+	case booksDeletedMsg:
+		return v, v.handleBooksDeleted(msg)
+	case libraryStatsMsg:
+		v.handleLibraryStats(msg)
+		return v, nil
+	case libraryPollMsg:
+		return v, v.handleLibraryPoll(msg)
+	case libraryChangesMsg:
+		return v, v.handleLibraryChanges(msg)
+	case libraryCollectionsLoadedMsg:
+		if msg.err != nil {
+			v.pickingCollection = false
+			v.err = msg.err
+			return v, nil
+		}
+		v.collections = append([]models.Collection{}, msg.collections...) // Non-nil once loaded
+	case booksAddedToCollectionMsg:
+		v.handleBooksAddedToCollection(msg)
+	case coverUploadedMsg:
+		return v, v.handleCoverUploaded(msg)
+	case tagsSavedMsg:
+		v.handleTagsSaved(msg)
+	case booksGroupedMsg:
+		v.handleBooksGrouped(msg)
+	case searchDebouncedMsg:
+		return v, v.handleSearchDebounced(msg)
+	case downloadProgressMsg:
+		if msg.download == v.download {
+			return v, v.download.tick()
+		}
+	case downloadDoneMsg:
+		if msg.download == v.download {
+			v.download = nil
+			if msg.err != nil {
+				v.err = msg.err
+			} else {
+				v.statusMsg = msg.result()
+			}
+		}
+	default:
+		if v.pickingCover {
+			return v.updateCoverPicker(msg) // Directory listings for the picker
+		}
 	}
 	return v, nil
 }
@@ -251,6 +436,9 @@ func (v *LibraryView) Update(msg tea.Msg) (View, tea.Cmd) {
 
 // getSelectedBook safely retrieves the book at the current cursor position
 func (v *LibraryView) getSelectedBook() (models.Book, bool) {
+	if v.browse != browseList {
+		return v.selectedGroupBook()
+	}
 	if v.cursor >= 0 && v.cursor < len(v.books) {
 		return v.books[v.cursor], true
 	}
@@ -259,28 +447,30 @@ func (v *LibraryView) getSelectedBook() (models.Book, bool) {
 
 // resetAndLoadBooks resets pagination/cursor and reloads books
 func (v *LibraryView) resetAndLoadBooks() tea.Cmd {
+	v.loadingMore = false // Drop any page still being appended
 	v.page = 1
 	v.cursor = 0
 	v.offset = 0
+	v.gridOffset = 0
 	return v.loadBooks()
 }
 
-// loadVisibleCovers loads cover images for currently visible books
+// loadVisibleCovers loads cover images for currently visible books, and
+// prefetches the next screenful so scrolling finds them ready
 func (v *LibraryView) loadVisibleCovers() tea.Cmd {
 	if v.termMode == terminal.TermModeNone || !v.showCovers {
 		return nil
 	}
-	var cmds []tea.Cmd
-	visibleCount := v.visibleLines()
-	for i := 0; i < min(visibleCount, len(v.books)); i++ {
-		if cmd := v.loadCoverCmd(v.books[i].ID); cmd != nil {
-			cmds = append(cmds, cmd)
-		}
+	start, end := 0, min(v.visibleLines(), len(v.books))
+	if v.gridMode {
+		start, end = v.visibleGridRange()
 	}
-	if len(cmds) == 0 {
-		return nil
+	end = min(end+(end-start), len(v.books))
+	ids := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		ids = append(ids, v.books[i].ID)
 	}
-	return tea.Batch(cmds...)
+	return v.loadCoversCmd(ids)
 }
 
 // ============================================================
@@ -289,10 +479,31 @@ func (v *LibraryView) loadVisibleCovers() tea.Cmd {
 
 // handleKeyMsg dispatches key presses based on current mode
 func (v *LibraryView) handleKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
+	v.statusMsg = "" // Clear batch results on any key
+
 	// Modal states take priority
+	if v.showingStats {
+		v.showingStats = false // Any key closes the summary
+		return v, nil
+	}
 	if v.confirmDelete {
 		return v.handleDeleteConfirmKeys(msg)
 	}
+	if v.pickingCollection {
+		return v.handleCollectionPickerKeys(msg)
+	}
+	if v.pickingCover {
+		return v.updateCoverPicker(msg)
+	}
+	if v.pickingTag {
+		return v.handleTagPickerKeys(msg)
+	}
+	if v.savingFilter {
+		return v.handleSaveFilterKeys(msg)
+	}
+	if v.jumpingPage {
+		return v.handlePageJumpKeys(msg)
+	}
 	if v.searchMode {
 		return v.handleSearchInputKeys(msg)
 	}
@@ -307,6 +518,7 @@ func (v *LibraryView) handleDeleteConfirmKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 		if v.deleteBook != nil {
 			return v, v.deleteBookCmd(v.deleteBook.ID)
 		}
+		return v, v.deleteBooksCmd(v.targetBooks())
 	case "n", "N", "esc":
 		v.confirmDelete = false
 		v.deleteBook = nil
@@ -324,10 +536,15 @@ func (v *LibraryView) handleSearchInputKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 	case "enter":
 		v.searchMode = false
 		v.searchInput.Blur()
+		v.stopLiveSearch()
 		return v, v.resetAndLoadBooks()
 	default:
+		query := v.searchInput.Value()
 		var cmd tea.Cmd
 		v.searchInput, cmd = v.searchInput.Update(msg)
+		if v.searchInput.Value() != query {
+			return v, tea.Batch(cmd, v.queueLiveSearch())
+		}
 		return v, cmd
 	}
 }
@@ -336,9 +553,18 @@ func (v *LibraryView) handleSearchInputKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 	key := msg.String()
 
-	// Navigation keys (no command returned)
+	if v.browse != browseList {
+		if view, cmd, handled := v.handleBrowseKeys(key); handled {
+			return view, cmd
+		}
+	}
+
+	// Navigation keys (no command returned, except covers scrolled into the grid)
+	if v.gridMode && v.handleGridNavigation(key) {
+		return v, tea.Batch(v.loadVisibleCovers(), v.maybeLoadMore())
+	}
 	if v.handleNavigation(key) {
-		return v, nil
+		return v, v.maybeLoadMore()
 	}
 
 	// Keys that return commands
@@ -352,9 +578,11 @@ func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 	// Sorting
 	case "s":
 		v.sortBy = (v.sortBy + 1) % 4
+		v.recentlyAdded = false
 		return v, v.resetAndLoadBooks()
 	case "S":
 		v.sortAsc = !v.sortAsc
+		v.recentlyAdded = false
 		return v, v.resetAndLoadBooks()
 
 	// Pagination
@@ -369,13 +597,26 @@ func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 			return v, v.loadBooks()
 		}
 	case "r":
+		if v.infiniteScroll() {
+			return v, v.resetAndLoadBooks()
+		}
 		return v, v.loadBooks()
+	case ":":
+		return v, v.startPageJump()
 
 	// View switching
 	case "c":
 		return v, SwitchTo(ViewCollections)
 	case "a":
 		return v, SwitchTo(ViewUpload)
+	case "H":
+		return v, SwitchTo(ViewHistory)
+	case "~":
+		return v, SwitchTo(ViewHome)
+	case ",":
+		return v, SwitchTo(ViewSettings)
+	case "P":
+		return v, SwitchTo(ViewProfiles)
 
 	// Content filtering
 	case "b", "m", "v":
@@ -383,6 +624,12 @@ func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 	case "R":
 		v.recentlyReadMode = !v.recentlyReadMode
 		return v, v.resetAndLoadBooks()
+	case "N":
+		v.recentlyAdded = !v.recentlyAdded
+		return v, v.resetAndLoadBooks()
+	case "O":
+		v.sharedMode = !v.sharedMode
+		return v, v.resetAndLoadBooks()
 	case "F":
 		v.favoritesMode = !v.favoritesMode
 		v.queueMode = false
@@ -391,15 +638,62 @@ func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 		v.queueMode = !v.queueMode
 		v.favoritesMode = false
 		return v, v.resetAndLoadBooks()
+	// Multi-select
+	case " ":
+		v.toggleMark()
+		return v, nil
+	case "esc":
+		v.clearMarks()
+		return v, nil
+	case "+":
+		return v, v.openCollectionPicker()
+	case "U":
+		return v, v.openCoverPicker()
+	case "t":
+		v.openTagPicker()
+		return v, nil
+	case "ctrl+s":
+		return v, v.startSavingFilter()
+	case "u":
+		v.filterStatus = nextStatusFilter(v.filterStatus)
+		return v, v.resetAndLoadBooks()
+	case "*":
+		v.minRating = (v.minRating + 1) % (config.MaxRating + 1)
+		return v, v.resetAndLoadBooks()
+	case "I":
+		return v, v.openStats()
+	case "z":
+		return v, v.toggleArchived()
+	case "Z":
+		v.showArchived = !v.showArchived
+		return v, v.resetAndLoadBooks()
+	case "M":
+		v.markNextStatus()
+		if v.filterStatus != "" {
+			return v, v.loadBooks()
+		}
+		return v, nil
+	case "B":
+		return v, v.cycleBrowseMode()
+
 	case "x":
-		if v.filterAuthor != "" || v.filterSeries != "" {
+		if v.filterAuthor != "" || v.filterSeries != "" || v.filterTag != "" || v.filterStatus != "" || v.minRating > 0 || v.showArchived {
 			v.filterAuthor = ""
 			v.filterSeries = ""
+			v.filterTag = ""
+			v.filterStatus = ""
+			v.minRating = 0
+			v.showArchived = false
 			return v, v.resetAndLoadBooks()
 		}
 
-	// Book actions
-	case "enter", "d", "f", "w", "i", "A", "E":
+	// Book actions (f/w/d apply to every marked book when there are marks)
+	case "d", "f", "w":
+		if len(v.marked) > 0 {
+			return v.handleBatchAction(key)
+		}
+		return v.handleBookAction(key)
+	case "enter", "i", "A", "E", "D":
 		return v.handleBookAction(key)
 
 	// Queue reordering
@@ -415,6 +709,8 @@ func (v *LibraryView) handleLibraryKeys(msg tea.KeyMsg) (View, tea.Cmd) {
 			_ = v.config.SetTheme(newTheme)
 		}
 		return v, NotifyThemeChanged(newTheme)
+	case "ctrl+t":
+		return v.handleToggleAutoTheme()
 	case "C":
 		return v.handleToggleCovers()
 	}
@@ -500,14 +796,24 @@ func (v *LibraryView) handleBookAction(key string) (View, tea.Cmd) {
 		if book.Author != "" {
 			v.filterAuthor = book.Author
 			v.filterSeries = ""
+			v.filterTag = ""
 			return v, v.resetAndLoadBooks()
 		}
 	case "E":
 		if book.Series != "" {
 			v.filterSeries = book.Series
 			v.filterAuthor = ""
+			v.filterTag = ""
 			return v, v.resetAndLoadBooks()
 		}
+	case "D":
+		if v.download != nil {
+			v.statusMsg = "Wait for the current download to finish"
+			return v, nil
+		}
+		var cmd tea.Cmd
+		v.download, cmd = startDownload(v.client, book, downloadDir(v.config))
+		return v, cmd
 	}
 	return v, nil
 }
@@ -534,18 +840,39 @@ func (v *LibraryView) handleQueueReorder(key string) (View, tea.Cmd) {
 	return v, v.loadBooks()
 }
 
-// handleToggleCovers toggles cover thumbnail display
+// handleToggleCovers cycles the layout: text list, list with covers, cover grid
 func (v *LibraryView) handleToggleCovers() (View, tea.Cmd) {
 	if v.termMode == terminal.TermModeNone {
 		return v, nil
 	}
-	v.showCovers = !v.showCovers
+	switch {
+	case !v.showCovers:
+		v.showCovers = true
+	case !v.gridMode:
+		v.gridMode = true
+		v.ensureGridVisible()
+	default:
+		v.showCovers = false
+		v.gridMode = false
+		v.updateOffset()
+	}
 	if v.showCovers && len(v.books) > 0 {
 		return v, v.loadVisibleCovers()
 	}
 	return v, nil
 }
 
+// handleToggleAutoTheme turns automatic day/night theme switching on or off
+func (v *LibraryView) handleToggleAutoTheme() (View, tea.Cmd) {
+	if v.config == nil {
+		return v, nil
+	}
+	_ = v.config.SetAutoTheme(!v.config.AutoTheme)
+	themeName := v.config.ActiveThemeName(time.Now())
+	styles.SetCurrentTheme(themeName)
+	return v, NotifyThemeChanged(themeName)
+}
+
 // ============================================================
 // Message Handlers
 // ============================================================
@@ -560,16 +887,26 @@ func (v *LibraryView) handleBooksLoaded(msg booksLoadedMsg) tea.Cmd {
 	v.books = msg.books
 	v.total = msg.total
 	v.err = nil
+	if msg.fuzzy {
+		v.statusMsg = "No exact matches, showing close ones"
+	} else {
+		v.rememberBooks(msg.books)
+	}
 	if v.cursor >= len(v.books) {
 		v.cursor = max(0, len(v.books)-1)
 	}
+	v.updateOffset() // Keep a restored cursor on screen
 	return v.loadVisibleCovers()
 }
 
 // handleCoverLoaded processes the result of a cover loading command
 func (v *LibraryView) handleCoverLoaded(msg coverLoadedMsg) tea.Cmd {
 	if msg.err == nil && msg.renderedImage != "" {
-		v.coverCache[msg.bookID] = msg.renderedImage
+		if msg.grid {
+			v.gridCoverCache[msg.bookID] = msg.renderedImage
+		} else {
+			v.coverCache[msg.bookID] = msg.renderedImage
+		}
 	}
 	return nil
 }
@@ -586,77 +923,82 @@ func (v *LibraryView) handleBookDeleted(msg bookDeletedMsg) tea.Cmd {
 
 // View implements View
 func (v *LibraryView) View() string {
-	var b strings.Builder
-
 	// Delete confirmation dialog
 	if v.confirmDelete && v.deleteBook != nil {
 		return v.renderDeleteConfirmation()
 	}
+	if v.confirmDelete {
+		return v.renderBatchDeleteConfirmation()
+	}
+	if v.pickingCollection {
+		return v.renderCollectionPicker()
+	}
+	if v.pickingCover {
+		return v.renderCoverPicker()
+	}
+	if v.pickingTag {
+		return v.renderTagPicker()
+	}
+	if v.showingStats {
+		return v.renderStats()
+	}
 
-	// Header
-	header := v.renderHeader()
-	b.WriteString(header + "\n")
+	return styles.RenderLayout(v.renderHeader(), v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the search bar and book list between header and footer
+func (v *LibraryView) renderContent() string {
+	var b strings.Builder
 
 	// Search bar (if active)
 	if v.searchMode {
-		searchBar := styles.InputFieldFocused.Render(v.searchInput.View())
-		b.WriteString(searchBar + "\n")
+		b.WriteString(v.renderSearchBar() + "\n")
+	}
+
+	if v.browse != browseList {
+		b.WriteString(v.renderBrowser())
+		return b.String()
 	}
 
 	// Loading state
 	if v.loading {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render("Loading books..."),
-		)
-		b.WriteString(content)
+		b.WriteString(styles.RenderCenteredContent(styles.MutedText.Render("Loading books..."), v.width, v.contentHeight()))
 		return b.String()
 	}
 
 	// Error state
 	if v.err != nil {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.ErrorStyle.Render("Error: "+v.err.Error()),
-		)
-		b.WriteString(content)
+		b.WriteString(styles.RenderCenteredContent(styles.ErrorStyle.Render("Error: "+v.err.Error()), v.width, v.contentHeight()))
 		return b.String()
 	}
 
 	// Empty state
 	if len(v.books) == 0 {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render("No books found"),
-		)
-		b.WriteString(content)
+		b.WriteString(styles.RenderCenteredContent(styles.MutedText.Render("No books found"), v.width, v.contentHeight()))
+		return b.String()
+	}
+
+	if v.gridMode {
+		b.WriteString(v.renderGrid())
 		return b.String()
 	}
 
 	// Book list
+	var lines []string
 	visibleLines := v.visibleLines()
 	for i := v.offset; i < min(v.offset+visibleLines, len(v.books)); i++ {
-		book := v.books[i]
-		line := v.renderBookLine(book, i == v.cursor)
-		b.WriteString(line + "\n")
+		lines = append(lines, v.renderBookLine(v.books[i], i == v.cursor))
 	}
-
-	// Footer
-	b.WriteString("\n")
-	b.WriteString(v.renderFooter())
+	b.WriteString(strings.Join(lines, "\n"))
 
 	return b.String()
 }
 
+// renderSearchBar renders the search input box
+func (v *LibraryView) renderSearchBar() string {
+	return styles.InputFieldFocused.Render(v.searchInput.View())
+}
+
 // SetSize implements View
 func (v *LibraryView) SetSize(width, height int) {
 	v.width = width
@@ -669,20 +1011,33 @@ func (v *LibraryView) GetTermMode() terminal.TermImageMode {
 	return v.termMode
 }
 
-// renderHeader renders a clean header bar
+// renderHeader renders the header bar content
 func (v *LibraryView) renderHeader() string {
 	// Title based on mode
 	title := "Library"
+	if v.sharedMode {
+		title = "Shared with Me"
+	}
 	if v.queueMode {
 		title = "Reading Queue"
 	} else if v.favoritesMode {
 		title = "Favorites"
 	} else if v.recentlyReadMode {
 		title = "Recently Read"
+	} else if v.recentlyAdded {
+		title = "Recently Added"
 	} else if v.filterAuthor != "" {
 		title = "Author: " + truncateText(v.filterAuthor, 20)
 	} else if v.filterSeries != "" {
 		title = "Series: " + truncateText(v.filterSeries, 20)
+	} else if v.filterTag != "" {
+		title = "Tag: " + truncateText(v.filterTag, 20)
+	} else if status, ok := config.ParseReadStatus(v.filterStatus); ok {
+		title = status.Label()
+	} else if v.minRating > 0 {
+		title = fmt.Sprintf("Rated %s+", strings.Repeat("★", v.minRating))
+	} else if v.showArchived {
+		title = "Archived"
 	} else {
 		switch v.contentType {
 		case models.ContentTypeBook:
@@ -692,35 +1047,26 @@ func (v *LibraryView) renderHeader() string {
 		}
 	}
 
-	// Left side: title
-	leftPart := styles.BookTitle.Render(title)
-
 	// Right side: sort + page info
-	sortDir := "↑"
+	sortLabel, sortDir := v.sortBy.Label(), "↑"
 	if !v.sortAsc {
 		sortDir = "↓"
 	}
-	totalPages := (v.total + v.pageSize - 1) / v.pageSize
-	if totalPages < 1 {
-		totalPages = 1
+	if v.recentlyAdded {
+		sortLabel, sortDir = sortDate.Label(), "↓"
 	}
-	rightPart := styles.MutedText.Render(fmt.Sprintf("%s %s  %d/%d", v.sortBy.Label(), sortDir, v.page, totalPages))
-
-	// Search indicator in middle if active
-	searchPart := ""
-	if v.searchInput.Value() != "" {
-		searchPart = styles.SecondaryText.Render(" [" + truncateText(v.searchInput.Value(), 15) + "]")
+	right := fmt.Sprintf("%s %s  %s", sortLabel, sortDir, v.pageRange())
+	if v.browse != browseList {
+		title, right = v.browseTitle()
 	}
 
-	left := leftPart + searchPart
-	right := rightPart
-
-	gap := v.width - lipgloss.Width(left) - lipgloss.Width(right)
-	if gap < 0 {
-		gap = 0
+	// Search indicator after the title if active
+	left := title
+	if v.searchInput.Value() != "" && v.browse == browseList {
+		left += " [" + truncateText(v.searchInput.Value(), 15) + "]"
 	}
 
-	return left + strings.Repeat(" ", gap) + right
+	return styles.HeaderContent(left, right, v.width)
 }
 
 // renderBookLine renders a single book line
@@ -771,6 +1117,12 @@ func (v *LibraryView) renderBookLineTextOnly(book models.Book, selected bool) st
 		}
 	}
 
+	// Upload date when showing recently added books
+	addedPart := ""
+	if v.recentlyAdded && !book.UploadedAt.IsZero() {
+		addedPart = book.UploadedAt.Format("Jan 2")
+	}
+
 	// Type indicator (only when showing all content types)
 	typePart := ""
 	if v.contentType == "" && book.ContentType != "" {
@@ -793,8 +1145,11 @@ func (v *LibraryView) renderBookLineTextOnly(book models.Book, selected bool) st
 	// Calculate how much space we have
 	// Format: Title | Author | Series | [indicators]
 	rightMeta := ""
-	if indicatorPart != "" || typePart != "" {
+	if indicatorPart != "" || typePart != "" || addedPart != "" {
 		metaParts := []string{}
+		if addedPart != "" {
+			metaParts = append(metaParts, addedPart)
+		}
 		if typePart != "" {
 			metaParts = append(metaParts, typePart)
 		}
@@ -809,8 +1164,11 @@ func (v *LibraryView) renderBookLineTextOnly(book models.Book, selected bool) st
 	sepLen := lipgloss.Width(separator)
 	rightMetaLen := lipgloss.Width(rightMeta)
 
+	// Reading progress column, once any book has been read
+	progressPart := v.progressCell(book)
+
 	// Calculate space for each column
-	availableForContent := contentWidth - rightMetaLen
+	availableForContent := contentWidth - rightMetaLen - lipgloss.Width(progressPart)
 	if availableForContent < 30 {
 		availableForContent = 30
 	}
@@ -841,15 +1199,16 @@ func (v *LibraryView) renderBookLineTextOnly(book models.Book, selected bool) st
 	seriesStr = padRight(seriesStr, seriesCol)
 
 	// Build final line
-	line := titleStr + separator + authorStr + separator + seriesStr + rightMeta
+	line := titleStr + separator + authorStr + separator + seriesStr + progressPart + rightMeta
 
 	// Apply styling based on selection
+	prefix := v.selectorPrefix(book, selected)
 	if selected {
 		// Selected: cyan foreground with arrow indicator
-		return styles.SecondaryText.Render("▸ ") + styles.SecondaryText.Bold(true).Render(line)
+		return styles.SecondaryText.Render(prefix) + styles.SecondaryText.Bold(true).Render(line)
 	}
 	// Not selected: dim text
-	return "  " + styles.MutedText.Render(line)
+	return styles.SecondaryText.Render(prefix) + styles.MutedText.Render(line)
 }
 
 // renderBookLineWithThumbnail renders a book line with cover thumbnail and aligned details
@@ -938,9 +1297,8 @@ func (v *LibraryView) renderBookLineWithThumbnail(book models.Book, selected boo
 	fullLine := lipgloss.JoinHorizontal(lipgloss.Top, leftCol, rightCol)
 
 	// Selection styling
-	selector := "  "
+	selector := v.selectorPrefix(book, selected)
 	if selected {
-		selector = "▸ "
 		return styles.ListItemSelected.Width(v.width).Render(selector + fullLine)
 	}
 	return styles.ListItem.Width(v.width).Render(selector + fullLine)
@@ -949,7 +1307,9 @@ func (v *LibraryView) renderBookLineWithThumbnail(book models.Book, selected boo
 // renderFooter renders the footer help
 func (v *LibraryView) renderFooter() string {
 	var help []string
-	if v.queueMode {
+	if v.browse != browseList {
+		help = v.renderBrowseFooter()
+	} else if v.queueMode {
 		help = []string{
 			styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
 			styles.HelpKey.Render("J/K") + styles.Help.Render(" reorder"),
@@ -958,7 +1318,7 @@ func (v *LibraryView) renderFooter() string {
 			styles.HelpKey.Render("W") + styles.Help.Render(" exit"),
 			styles.HelpKey.Render("q") + styles.Help.Render(" quit"),
 		}
-	} else if v.filterAuthor != "" || v.filterSeries != "" {
+	} else if v.filterAuthor != "" || v.filterSeries != "" || v.filterTag != "" || v.filterStatus != "" || v.minRating > 0 || v.showArchived {
 		// Show filter-specific help when a filter is active
 		help = []string{
 			styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
@@ -981,19 +1341,41 @@ func (v *LibraryView) renderFooter() string {
 		}
 	}
 
+	switch {
+	case v.savingFilter:
+		help = []string{v.renderSaveFilterPrompt()}
+	case v.jumpingPage:
+		help = []string{v.renderPageJumpPrompt()}
+	case v.statusMsg != "":
+		help = []string{styles.SecondaryText.Render(v.statusMsg)}
+	case v.download != nil:
+		help = []string{styles.SecondaryText.Render(v.download.progress())}
+	case len(v.marked) > 0:
+		help = []string{
+			styles.SecondaryText.Render(fmt.Sprintf("%d marked", len(v.marked))),
+			styles.HelpKey.Render("space") + styles.Help.Render(" mark"),
+			styles.HelpKey.Render("f") + styles.Help.Render(" fav"),
+			styles.HelpKey.Render("w") + styles.Help.Render(" queue"),
+			styles.HelpKey.Render("+") + styles.Help.Render(" collection"),
+			styles.HelpKey.Render("d") + styles.Help.Render(" delete"),
+			styles.HelpKey.Render("esc") + styles.Help.Render(" clear"),
+		}
+	}
+
 	// Add theme indicator
 	themeName := styles.CurrentTheme().Name
+	if v.config != nil && v.config.AutoTheme {
+		themeName = "auto: " + themeName
+	}
 	themeIndicator := styles.MutedText.Render(" [" + themeName + "] ") + styles.HelpKey.Render("T") + styles.Help.Render(" theme")
 
 	helpText := strings.Join(help, "  ")
-	gap := v.width - lipgloss.Width(helpText) - lipgloss.Width(themeIndicator)
+	gap := v.width - styles.FooterBar.GetHorizontalFrameSize() - lipgloss.Width(helpText) - lipgloss.Width(themeIndicator)
 	if gap < 0 {
 		gap = 0
 	}
 
-	// Use consistent FooterBar styling
-	content := helpText + strings.Repeat(" ", gap) + themeIndicator
-	return styles.FooterBar.Width(v.width).Render(content)
+	return helpText + strings.Repeat(" ", gap) + themeIndicator
 }
 
 // renderDeleteConfirmation renders the delete confirmation dialog
@@ -1034,19 +1416,91 @@ func (v *LibraryView) deleteBookCmd(bookID string) tea.Cmd {
 
 // loadBooks fetches books from the API
 func (v *LibraryView) loadBooks() tea.Cmd {
+	return v.loadBooksContext(context.Background())
+}
+
+// listMode reports whether the library shows one of the local lists (recent
+// reads, favorites or the queue) rather than a page of the server's books
+func (v *LibraryView) listMode() bool {
+	return v.config != nil && (v.recentlyReadMode || v.favoritesMode || v.queueMode)
+}
+
+// loadBooksContext loads books with a request that is dropped if ctx is cancelled.
+// The search and filters are read now: the view keeps changing, e.g. as the
+// search is typed, while the request runs.
+func (v *LibraryView) loadBooksContext(ctx context.Context) tea.Cmd {
+	seen := v.seenBooks
+	sortBy, order := v.sortBy.String(), "asc"
+	if !v.sortAsc {
+		order = "desc"
+	}
+	if v.recentlyAdded {
+		sortBy, order = sortDate.String(), "desc"
+	}
+	filters := parseSearchQuery(v.searchInput.Value(), v.contentType)
+	page, pageSize, contentType := v.page, v.pageSize, v.contentType
+	sharedMode, listMode := v.sharedMode, v.listMode()
+	recentlyReadMode, favoritesMode, queueMode := v.recentlyReadMode, v.favoritesMode, v.queueMode
+	filterStatus, minRating, showArchived := v.filterStatus, v.minRating, v.showArchived
+	filterAuthor, filterSeries, filterTag := v.filterAuthor, v.filterSeries, v.filterTag
+	cfg, client := v.config, v.client
 	return func() tea.Msg {
-		order := "asc"
-		if !v.sortAsc {
-			order = "desc"
+		var resp *models.BooksResponse
+		var err error
+		switch {
+		case sharedMode:
+			resp, err = v.listSharedBooks(page, pageSize, filters.Search, contentType)
+		case listMode:
+			// Favorites, the queue and recent reads can be anywhere in the library
+			var books []models.Book
+			books, err = client.ListAllBooks(ctx, sortBy, order, filters)
+			resp = &models.BooksResponse{Books: books, Total: len(books)}
+		default:
+			resp, err = client.ListBooksFiltered(ctx, page, pageSize, sortBy, order, filters)
+		}
+		if errors.Is(err, context.Canceled) {
+			return nil // Superseded by a newer search
 		}
-		resp, err := v.client.ListBooks(v.page, v.pageSize, v.sortBy.String(), order, v.searchInput.Value(), v.contentType)
 		if err != nil {
 			return booksLoadedMsg{err: err}
 		}
 
+		// Apply author:, series: and format: here too in case the server ignored them
+		if matched := filterByFields(resp.Books, filters); len(matched) < len(resp.Books) {
+			resp.Books = matched
+			resp.Total = len(matched)
+		}
+
+		// Fall back to close matches among books already loaded if the server has none
+		fuzzy := false
+		if query := filters.Search; query != "" && len(resp.Books) == 0 && page == 1 && !sharedMode {
+			resp.Books = filterByFields(fuzzyFallback(seen, query, contentType), filters)
+			resp.Total = len(resp.Books)
+			fuzzy = len(resp.Books) > 0
+		}
+
+		// Narrow every mode below by read status if that filter is active
+		if filterStatus != "" {
+			resp.Books = filterByStatus(cfg, resp.Books, filterStatus)
+			resp.Total = len(resp.Books)
+		}
+		if minRating > 0 {
+			resp.Books = filterByRating(cfg, resp.Books, minRating)
+			resp.Total = len(resp.Books)
+		}
+
+		// Archived books are hidden everywhere except the archive itself
+		var hidden int
+		resp.Books, hidden = filterArchived(cfg, resp.Books, showArchived)
+		if showArchived {
+			resp.Total = len(resp.Books)
+		} else {
+			resp.Total -= hidden
+		}
+
 		// Filter by recently read if in that mode
-		if v.recentlyReadMode && v.config != nil {
-			recentIDs := v.config.GetRecentlyReadIDs()
+		if recentlyReadMode && cfg != nil {
+			recentIDs := cfg.GetRecentlyReadIDs()
 			recentIDSet := make(map[string]bool)
 			for _, id := range recentIDs {
 				recentIDSet[id] = true
@@ -1071,8 +1525,8 @@ func (v *LibraryView) loadBooks() tea.Cmd {
 		}
 
 		// Filter by favorites if in that mode
-		if v.favoritesMode && v.config != nil {
-			favoriteIDs := v.config.GetFavoriteIDs()
+		if favoritesMode && cfg != nil {
+			favoriteIDs := cfg.GetFavoriteIDs()
 			favoriteIDSet := make(map[string]bool)
 			for _, id := range favoriteIDs {
 				favoriteIDSet[id] = true
@@ -1089,8 +1543,8 @@ func (v *LibraryView) loadBooks() tea.Cmd {
 		}
 
 		// Filter by reading queue if in that mode (maintain queue order)
-		if v.queueMode && v.config != nil {
-			queueIDs := v.config.GetQueueIDs()
+		if queueMode && cfg != nil {
+			queueIDs := cfg.GetQueueIDs()
 			bookByID := make(map[string]models.Book)
 			for _, book := range resp.Books {
 				bookByID[book.ID] = book
@@ -1107,10 +1561,10 @@ func (v *LibraryView) loadBooks() tea.Cmd {
 		}
 
 		// Filter by author if filter is active
-		if v.filterAuthor != "" {
+		if filterAuthor != "" {
 			filteredBooks := make([]models.Book, 0)
 			for _, book := range resp.Books {
-				if book.Author == v.filterAuthor {
+				if book.Author == filterAuthor {
 					filteredBooks = append(filteredBooks, book)
 				}
 			}
@@ -1118,17 +1572,28 @@ func (v *LibraryView) loadBooks() tea.Cmd {
 		}
 
 		// Filter by series if filter is active
-		if v.filterSeries != "" {
+		if filterSeries != "" {
 			filteredBooks := make([]models.Book, 0)
 			for _, book := range resp.Books {
-				if book.Series == v.filterSeries {
+				if book.Series == filterSeries {
 					filteredBooks = append(filteredBooks, book)
 				}
 			}
 			return booksLoadedMsg{books: filteredBooks, total: len(filteredBooks)}
 		}
 
-		return booksLoadedMsg{books: resp.Books, total: resp.Total}
+		// Filter by tag if filter is active
+		if filterTag != "" {
+			filteredBooks := make([]models.Book, 0)
+			for _, book := range resp.Books {
+				if slices.Contains(bookTags(cfg, book), filterTag) {
+					filteredBooks = append(filteredBooks, book)
+				}
+			}
+			return booksLoadedMsg{books: filteredBooks, total: len(filteredBooks)}
+		}
+
+		return booksLoadedMsg{books: resp.Books, total: resp.Total, fuzzy: fuzzy}
 	}
 }
 
@@ -1158,13 +1623,18 @@ func (v *LibraryView) updateOffset() {
 	}
 }
 
-// visibleLines returns the number of visible book lines
-func (v *LibraryView) visibleLines() int {
-	// Account for header, footer, and margins
-	availableHeight := v.height - 5
+// contentHeight returns the number of lines available below the search bar
+func (v *LibraryView) contentHeight() int {
+	availableHeight := styles.ContentHeight(v.height)
 	if v.searchMode {
-		availableHeight--
+		availableHeight -= lipgloss.Height(v.renderSearchBar())
 	}
+	return max(1, availableHeight)
+}
+
+// visibleLines returns the number of visible book lines
+func (v *LibraryView) visibleLines() int {
+	availableHeight := v.contentHeight()
 
 	// If covers are shown, each item takes multiple lines
 	if v.showCovers && v.termMode != terminal.TermModeNone {
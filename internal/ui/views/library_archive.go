@@ -0,0 +1,46 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// filterArchived keeps the books that are archived (archived true) or those
+// that are not, returning how many were dropped
+func filterArchived(cfg *config.Config, books []models.Book, archived bool) ([]models.Book, int) {
+	if cfg == nil || (len(cfg.Archived) == 0 && !archived) {
+		return books, 0
+	}
+	kept := make([]models.Book, 0, len(books))
+	for _, book := range books {
+		if cfg.IsArchived(book.ID) == archived {
+			kept = append(kept, book)
+		}
+	}
+	return kept, len(books) - len(kept)
+}
+
+// toggleArchived archives the marked books (or the one under the cursor), hiding
+// them from the library without deleting them from the server. If all are
+// already archived they are restored instead.
+func (v *LibraryView) toggleArchived() tea.Cmd {
+	books := v.targetBooks()
+	if len(books) == 0 || v.config == nil {
+		return nil
+	}
+	archive := false
+	for _, book := range books {
+		archive = archive || !v.config.IsArchived(book.ID)
+	}
+	if err := v.config.SetArchived(bookIDs(books), archive); err != nil {
+		v.err = err
+		return nil
+	}
+	v.statusMsg = "Restored " + countBooks(len(books))
+	if archive {
+		v.statusMsg = "Archived " + countBooks(len(books)) + " (Z shows the archive)"
+	}
+	v.clearMarks()
+	return v.loadBooks()
+}
@@ -0,0 +1,378 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// browseMode selects how the library lists books
+type browseMode int
+
+const (
+	browseList    browseMode = iota // Flat, paginated list
+	browseAuthors                   // Books grouped under their authors
+	browseSeries                    // Books grouped by series in reading order
+	browseModes                     // Number of modes, for cycling
+)
+
+// bookGroup is a heading in the browser with the books listed under it
+type bookGroup struct {
+	name  string
+	books []models.Book
+}
+
+// groupRow is a line in the browser: a group heading, or one of its books when
+// the group is expanded
+type groupRow struct {
+	group int
+	book  int // -1 for the heading
+}
+
+// booksGroupedMsg is sent when the books for a browse mode are loaded
+type booksGroupedMsg struct {
+	mode   browseMode
+	groups []bookGroup
+	err    error
+}
+
+// cycleBrowseMode switches between the flat list and the grouped browsers
+func (v *LibraryView) cycleBrowseMode() tea.Cmd {
+	v.browse = (v.browse + 1) % browseModes
+	v.groups = nil
+	v.browseCursor = 0
+	v.browseOffset = 0
+	if v.browse == browseList {
+		return nil
+	}
+	return v.loadGroups()
+}
+
+// loadGroups fetches the books for the current browse mode
+func (v *LibraryView) loadGroups() tea.Cmd {
+	mode := v.browse
+	v.groupsLoading = true
+	return func() tea.Msg {
+		if mode == browseSeries {
+			bySeries, err := v.client.GetBooksBySeries()
+			if err != nil {
+				return booksGroupedMsg{mode: mode, err: err}
+			}
+			return booksGroupedMsg{mode: mode, groups: seriesGroups(bySeries)}
+		}
+		byAuthor, err := v.client.GetBooksByAuthor()
+		if err != nil {
+			return booksGroupedMsg{mode: mode, err: err}
+		}
+		return booksGroupedMsg{mode: mode, groups: authorGroups(byAuthor)}
+	}
+}
+
+// authorGroups sorts authors by name and each author's books by title
+func authorGroups(byAuthor map[string][]models.Book) []bookGroup {
+	groups := make([]bookGroup, 0, len(byAuthor))
+	for author, books := range byAuthor {
+		if author == "" {
+			author = "Unknown author"
+		}
+		books = append([]models.Book(nil), books...)
+		sort.SliceStable(books, func(i, j int) bool {
+			return strings.ToLower(books[i].Title) < strings.ToLower(books[j].Title)
+		})
+		groups = append(groups, bookGroup{name: author, books: books})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].name) < strings.ToLower(groups[j].name)
+	})
+	return groups
+}
+
+// seriesGroups sorts series by name and each series' books into reading order
+func seriesGroups(bySeries map[string][]models.Book) []bookGroup {
+	groups := make([]bookGroup, 0, len(bySeries))
+	for series, books := range bySeries {
+		if series == "" {
+			continue // Books outside any series
+		}
+		books = append([]models.Book(nil), books...)
+		sort.SliceStable(books, func(i, j int) bool {
+			if books[i].SeriesIndex != books[j].SeriesIndex {
+				return books[i].SeriesIndex < books[j].SeriesIndex
+			}
+			return strings.ToLower(books[i].Title) < strings.ToLower(books[j].Title)
+		})
+		groups = append(groups, bookGroup{name: series, books: books})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].name) < strings.ToLower(groups[j].name)
+	})
+	return groups
+}
+
+// nextInSeries returns the index of the first book in a series not yet finished,
+// or -1 if all are
+func (v *LibraryView) nextInSeries(group bookGroup) int {
+	for i, book := range group.books {
+		if v.readStatus(book) != config.StatusFinished {
+			return i
+		}
+	}
+	return -1
+}
+
+// readStatus returns how far the user has got with a book
+func (v *LibraryView) readStatus(book models.Book) config.ReadStatus {
+	if v.config == nil {
+		return config.StatusUnread
+	}
+	return v.config.GetReadStatus(book.ID)
+}
+
+// handleBooksGrouped shows the loaded groups if the browse mode hasn't changed since
+func (v *LibraryView) handleBooksGrouped(msg booksGroupedMsg) {
+	if msg.mode != v.browse {
+		return
+	}
+	v.groupsLoading = false
+	if msg.err != nil {
+		v.err = msg.err
+		return
+	}
+	v.err = nil
+	v.groups = msg.groups
+	v.browseCursor = max(0, min(v.browseCursor, len(v.groupRows())-1))
+	v.updateBrowseOffset()
+}
+
+// groupRows returns the browser's lines: each heading, followed by its books when expanded
+func (v *LibraryView) groupRows() []groupRow {
+	var rows []groupRow
+	for i, group := range v.groups {
+		rows = append(rows, groupRow{group: i, book: -1})
+		if v.expandedGroups[group.name] {
+			for j := range group.books {
+				rows = append(rows, groupRow{group: i, book: j})
+			}
+		}
+	}
+	return rows
+}
+
+// selectedGroupRow returns the browser line under the cursor
+func (v *LibraryView) selectedGroupRow() (groupRow, bool) {
+	rows := v.groupRows()
+	if v.browseCursor >= 0 && v.browseCursor < len(rows) {
+		return rows[v.browseCursor], true
+	}
+	return groupRow{}, false
+}
+
+// selectedGroupBook returns the book under the browser cursor, if it is on one
+func (v *LibraryView) selectedGroupBook() (models.Book, bool) {
+	row, ok := v.selectedGroupRow()
+	if !ok || row.book < 0 {
+		return models.Book{}, false
+	}
+	return v.groups[row.group].books[row.book], true
+}
+
+// moveBrowseCursor moves the browser cursor by delta lines
+func (v *LibraryView) moveBrowseCursor(delta int) {
+	v.browseCursor = max(0, min(v.browseCursor+delta, len(v.groupRows())-1))
+	v.updateBrowseOffset()
+}
+
+// updateBrowseOffset scrolls the browser to keep the cursor visible
+func (v *LibraryView) updateBrowseOffset() {
+	visible := v.contentHeight()
+	if v.browseCursor < v.browseOffset {
+		v.browseOffset = v.browseCursor
+	} else if v.browseCursor >= v.browseOffset+visible {
+		v.browseOffset = v.browseCursor - visible + 1
+	}
+}
+
+// setGroupExpanded shows or hides the books of the group under the cursor,
+// keeping the cursor on its heading
+func (v *LibraryView) setGroupExpanded(expanded bool) {
+	row, ok := v.selectedGroupRow()
+	if !ok {
+		return
+	}
+	name := v.groups[row.group].name
+	if expanded {
+		v.expandedGroups[name] = true
+	} else {
+		delete(v.expandedGroups, name)
+	}
+	for i, r := range v.groupRows() {
+		if r.group == row.group && (r.book == row.book || !expanded) {
+			v.browseCursor = i
+			break
+		}
+	}
+	v.updateBrowseOffset()
+}
+
+// handleBrowseKeys handles keys in a grouped browser. It reports false for keys
+// that act the same as in the list, like theme and view switching.
+func (v *LibraryView) handleBrowseKeys(key string) (View, tea.Cmd, bool) {
+	switch key {
+	case "j", "down":
+		v.moveBrowseCursor(1)
+	case "k", "up":
+		v.moveBrowseCursor(-1)
+	case "g", "home":
+		v.browseCursor = 0
+		v.browseOffset = 0
+	case "G", "end":
+		v.moveBrowseCursor(len(v.groupRows()))
+	case "ctrl+d", "pgdown":
+		v.moveBrowseCursor(v.contentHeight() / 2)
+	case "ctrl+u", "pgup":
+		v.moveBrowseCursor(-v.contentHeight() / 2)
+	case "l", "right":
+		v.setGroupExpanded(true)
+	case "h", "left":
+		v.setGroupExpanded(false)
+	case "enter":
+		if row, ok := v.selectedGroupRow(); ok && row.book < 0 {
+			v.setGroupExpanded(!v.expandedGroups[v.groups[row.group].name])
+			return v, nil, true
+		}
+		view, cmd := v.handleBookAction(key)
+		return view, cmd, true
+	case "i", "f", "w", "D":
+		view, cmd := v.handleBookAction(key)
+		return view, cmd, true
+	case "M":
+		v.markNextStatus()
+	case "r":
+		return v, v.loadGroups(), true
+	case "B":
+		return v, v.cycleBrowseMode(), true
+	case "T", "ctrl+t", "c", "a", "H":
+		return v, nil, false
+	}
+	return v, nil, true
+}
+
+// browseTitle returns the header title and count for the current browse mode
+func (v *LibraryView) browseTitle() (string, string) {
+	if v.browse == browseSeries {
+		return "Series", fmt.Sprintf("%d series", len(v.groups))
+	}
+	return "Authors", fmt.Sprintf("%d authors", len(v.groups))
+}
+
+// renderBrowser renders the grouped book list
+func (v *LibraryView) renderBrowser() string {
+	if v.groupsLoading && v.groups == nil {
+		return styles.RenderCenteredContent(styles.MutedText.Render("Loading books..."), v.width, v.contentHeight())
+	}
+	if v.err != nil {
+		return styles.RenderCenteredContent(styles.ErrorStyle.Render("Error: "+v.err.Error()), v.width, v.contentHeight())
+	}
+	if len(v.groups) == 0 {
+		return styles.RenderCenteredContent(styles.MutedText.Render("No books found"), v.width, v.contentHeight())
+	}
+
+	rows := v.groupRows()
+	var lines []string
+	for i := v.browseOffset; i < min(v.browseOffset+v.contentHeight(), len(rows)); i++ {
+		lines = append(lines, v.renderGroupRow(rows[i], i == v.browseCursor))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderGroupRow renders a group heading with its book count, or a book indented under it
+func (v *LibraryView) renderGroupRow(row groupRow, selected bool) string {
+	group := v.groups[row.group]
+	prefix := "  "
+	if selected {
+		prefix = styles.SecondaryText.Render("▸ ")
+	}
+
+	if row.book < 0 {
+		marker := "+ "
+		if v.expandedGroups[group.name] {
+			marker = "- "
+		}
+		name := truncateText(group.name, max(10, v.width-20))
+		count := styles.MutedText.Render(fmt.Sprintf("  (%d)", len(group.books)))
+		if v.browse == browseSeries {
+			finished := 0
+			for _, book := range group.books {
+				if v.readStatus(book) == config.StatusFinished {
+					finished++
+				}
+			}
+			count = styles.MutedText.Render(fmt.Sprintf("  (%d/%d read)", finished, len(group.books)))
+		}
+		if selected {
+			return prefix + styles.MutedText.Render(marker) + styles.SecondaryText.Bold(true).Render(name) + count
+		}
+		return prefix + styles.MutedText.Render(marker) + name + count
+	}
+
+	if v.browse == browseSeries {
+		return prefix + v.renderSeriesEntry(group, row.book, selected)
+	}
+	book := group.books[row.book]
+	title := truncateText(book.Title, max(10, v.width-16))
+	indicator := ""
+	if v.config != nil {
+		if pos := v.config.GetQueuePosition(book.ID); pos > 0 {
+			indicator = fmt.Sprintf("  [%d]", pos)
+		} else if v.config.IsFavorite(book.ID) {
+			indicator = "  ★"
+		}
+	}
+	if selected {
+		return prefix + "    " + styles.SecondaryText.Bold(true).Render(title) + styles.MutedText.Render(indicator)
+	}
+	return prefix + "    " + styles.MutedText.Render(title+indicator)
+}
+
+// renderBrowseFooter returns the key hints for the grouped browser
+func (v *LibraryView) renderBrowseFooter() []string {
+	return []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
+		styles.HelpKey.Render("h/l") + styles.Help.Render(" fold"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" open"),
+		styles.HelpKey.Render("i") + styles.Help.Render(" info"),
+		styles.HelpKey.Render("B") + styles.Help.Render(" browse"),
+		styles.HelpKey.Render("q") + styles.Help.Render(" quit"),
+	}
+}
+
+// renderSeriesEntry renders a book in a series with its number and read status:
+// ✓ finished, ◐ being read, ○ unread, with the next one to read marked
+func (v *LibraryView) renderSeriesEntry(group bookGroup, index int, selected bool) string {
+	book := group.books[index]
+	marker := "○ "
+	switch v.readStatus(book) {
+	case config.StatusFinished:
+		marker = "✓ "
+	case config.StatusReading:
+		marker = "◐ "
+	}
+	number := ""
+	if book.SeriesIndex > 0 {
+		number = fmt.Sprintf("#%g ", book.SeriesIndex)
+	}
+	next := ""
+	if v.nextInSeries(group) == index {
+		next = "  ← next"
+	}
+	title := truncateText(book.Title, max(10, v.width-24))
+	if selected {
+		return "    " + styles.MutedText.Render(marker+number) + styles.SecondaryText.Bold(true).Render(title) + styles.SecondaryText.Render(next)
+	}
+	return "    " + styles.MutedText.Render(marker+number+title) + styles.SecondaryText.Render(next)
+}
@@ -0,0 +1,104 @@
+package views
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// coverImageTypes are the image files offered when replacing a cover
+var coverImageTypes = []string{".jpg", ".jpeg", ".png"}
+
+// coverUploadedMsg is sent when a replacement cover has been uploaded
+type coverUploadedMsg struct {
+	bookID string
+	title  string
+	err    error
+}
+
+// openCoverPicker shows a file picker for the book under the cursor's new cover
+func (v *LibraryView) openCoverPicker() tea.Cmd {
+	book, ok := v.getSelectedBook()
+	if !ok {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	fp := filepicker.New()
+	fp.AllowedTypes = coverImageTypes
+	fp.CurrentDirectory = cwd
+	fp.ShowPermissions = false
+	fp.ShowSize = true
+	fp.Height = max(5, styles.ContentHeight(v.height)-8) // Leave room for the dialog title and help
+
+	v.coverPicker = fp
+	v.coverBook = &book
+	v.pickingCover = true
+	return v.coverPicker.Init()
+}
+
+// updateCoverPicker passes keys and directory listings to the cover file picker
+func (v *LibraryView) updateCoverPicker(msg tea.Msg) (View, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			v.pickingCover = false
+			v.coverBook = nil
+			return v, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	v.coverPicker, cmd = v.coverPicker.Update(msg)
+	if didSelect, path := v.coverPicker.DidSelectFile(msg); didSelect {
+		book := *v.coverBook
+		v.pickingCover = false
+		v.coverBook = nil
+		v.statusMsg = "Uploading cover for " + book.Title + "..."
+		return v, v.uploadCoverCmd(book, path)
+	}
+	return v, cmd
+}
+
+// uploadCoverCmd uploads path as the cover of book
+func (v *LibraryView) uploadCoverCmd(book models.Book, path string) tea.Cmd {
+	return func() tea.Msg {
+		err := v.client.UploadBookCover(book.ID, path)
+		return coverUploadedMsg{bookID: book.ID, title: book.Title, err: err}
+	}
+}
+
+// handleCoverUploaded drops the book's cached thumbnails so the new cover is fetched
+func (v *LibraryView) handleCoverUploaded(msg coverUploadedMsg) tea.Cmd {
+	if msg.err != nil {
+		v.statusMsg = ""
+		v.err = msg.err
+		return nil
+	}
+	delete(v.coverCache, msg.bookID)
+	delete(v.gridCoverCache, msg.bookID)
+	v.statusMsg = "Cover updated for " + msg.title
+	return v.loadVisibleCovers()
+}
+
+// renderCoverPicker renders the file picker for a replacement cover
+func (v *LibraryView) renderCoverPicker() string {
+	title := "Replace Cover"
+	if v.coverBook != nil {
+		title = fmt.Sprintf("Replace Cover: %s", truncateText(v.coverBook.Title, 40))
+	}
+	dialog := styles.Dialog.Width(min(70, v.width-4)).Render(
+		styles.DialogTitle.Render(title) + "\n\n" +
+			v.coverPicker.View() + "\n\n" +
+			styles.HelpKey.Render("enter") + styles.Help.Render(" upload  ") +
+			styles.HelpKey.Render("esc") + styles.Help.Render(" cancel"),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
@@ -0,0 +1,159 @@
+package views
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Scores for how a query word matches a word of a book's title, author or series.
+// Typos score lowest so closer matches are listed first.
+const (
+	fuzzyExact       = 100
+	fuzzyPrefix      = 80
+	fuzzySubstring   = 60
+	fuzzySubsequence = 40
+	fuzzyTypo        = 30
+)
+
+// fuzzyWords splits text into lowercase words of letters and digits
+func fuzzyWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// fuzzyScore scores how well query matches text, fzf-style: every query word has to
+// match some word of text exactly, as a prefix or substring, as a subsequence
+// ("jrr" in "jrrtolkien"), or within a typo or two ("tolkein" for "tolkien").
+// It reports false if any query word doesn't match.
+func fuzzyScore(query, text string) (int, bool) {
+	words := fuzzyWords(text)
+	total := 0
+	for _, q := range fuzzyWords(query) {
+		best := 0
+		for _, w := range words {
+			best = max(best, fuzzyWordScore(q, w))
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total, total > 0
+}
+
+// fuzzyWordScore scores one query word against one word of text, 0 for no match
+func fuzzyWordScore(q, w string) int {
+	switch {
+	case q == w:
+		return fuzzyExact
+	case strings.HasPrefix(w, q):
+		return fuzzyPrefix
+	case strings.Contains(w, q):
+		return fuzzySubstring
+	}
+	if gaps, ok := subsequenceGaps(q, w); ok && len([]rune(q)) > 1 {
+		return max(1, fuzzySubsequence-gaps)
+	}
+	allowed := 1
+	if len([]rune(q)) > 5 {
+		allowed = 2
+	}
+	if d := typoDistance(q, w); d <= allowed && len([]rune(q)) > 2 {
+		return fuzzyTypo - 10*d
+	}
+	return 0
+}
+
+// subsequenceGaps reports whether q's letters appear in order in w, and how many
+// letters of w are skipped between the first and last of them
+func subsequenceGaps(q, w string) (int, bool) {
+	qr := []rune(q)
+	i, start, gaps := 0, -1, 0
+	for j, r := range []rune(w) {
+		if i == len(qr) {
+			break
+		}
+		if r == qr[i] {
+			if start < 0 {
+				start = j
+			}
+			i++
+		} else if start >= 0 {
+			gaps++
+		}
+	}
+	return gaps, i == len(qr)
+}
+
+// typoDistance is the number of single-letter insertions, deletions, substitutions
+// and swaps of neighbouring letters that turn a into b
+func typoDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev2 := make([]int, len(br)+1)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(br)]
+}
+
+// fuzzyMatchBooks returns the books whose title, author or series match query,
+// best matches first
+func fuzzyMatchBooks(books []models.Book, query string) []models.Book {
+	type scored struct {
+		book  models.Book
+		score int
+	}
+	var matches []scored
+	for _, book := range books {
+		if score, ok := fuzzyScore(query, book.Title+" "+book.Author+" "+book.Series); ok {
+			matches = append(matches, scored{book, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	result := make([]models.Book, len(matches))
+	for i, m := range matches {
+		result[i] = m.book
+	}
+	return result
+}
+
+// rememberBooks adds loaded books to those searched when the server finds no match
+func (v *LibraryView) rememberBooks(books []models.Book) {
+	for _, book := range books {
+		if !v.seenIDs[book.ID] {
+			v.seenIDs[book.ID] = true
+			v.seenBooks = append(v.seenBooks, book)
+		}
+	}
+}
+
+// fuzzyFallback matches query against the books seen so far of the shown content type
+func fuzzyFallback(seen []models.Book, query, contentType string) []models.Book {
+	var books []models.Book
+	for _, book := range seen {
+		if contentType == "" || book.ContentType == contentType {
+			books = append(books, book)
+		}
+	}
+	return fuzzyMatchBooks(books, query)
+}
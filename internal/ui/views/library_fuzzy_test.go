@@ -0,0 +1,50 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        int
+		ok          bool
+	}{
+		{"dune", "Dune Messiah", fuzzyExact, true},
+		{"mess", "Dune Messiah", fuzzyPrefix, true},
+		{"siah", "Dune Messiah", fuzzySubstring, true},
+		{"jrt", "JRRTolkien", fuzzySubsequence - 1, true},
+		{"tolkein", "J.R.R. Tolkien", fuzzyTypo - 10, true},
+		{"tokein", "J.R.R. Tolkien", fuzzyTypo - 20, true},
+		{"dnue", "Dune", fuzzyTypo - 10, true},
+		{"herbert dune", "Dune Frank Herbert", 2 * fuzzyExact, true},
+		{"dune zzz", "Dune Frank Herbert", 0, false},
+		{"xyz", "Dune", 0, false},
+		{"", "Dune", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := fuzzyScore(tt.query, tt.text)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("fuzzyScore(%q, %q) = %d, %v; want %d, %v", tt.query, tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyFallbackRanksMatches(t *testing.T) {
+	seen := []models.Book{
+		{ID: "1", Title: "Dunes of the Desert", ContentType: models.ContentTypeBook},
+		{ID: "2", Title: "Emma", Author: "Jane Austen", ContentType: models.ContentTypeBook},
+		{ID: "3", Title: "Dune", Author: "Frank Herbert", ContentType: models.ContentTypeBook},
+		{ID: "4", Title: "Dune", ContentType: models.ContentTypeComic},
+	}
+	got := fuzzyFallback(seen, "dune", models.ContentTypeBook)
+	var ids []string
+	for _, book := range got {
+		ids = append(ids, book.ID)
+	}
+	if len(ids) != 2 || ids[0] != "3" || ids[1] != "1" {
+		t.Errorf("matches = %v, want [3 1]", ids)
+	}
+}
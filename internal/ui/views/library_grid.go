@@ -0,0 +1,116 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Cover grid layout
+const (
+	gridCellWidth  = 16                 // Columns per grid cell
+	gridCoverWidth = gridCellWidth - 2  // Columns for the cover image
+	gridCoverLines = 8                  // Lines for the cover image
+	gridCellHeight = gridCoverLines + 2 // Cover, title and a blank line
+)
+
+// gridColumns returns how many covers fit across the screen
+func (v *LibraryView) gridColumns() int {
+	return max(1, v.width/gridCellWidth)
+}
+
+// gridRows returns how many rows of covers fit on screen
+func (v *LibraryView) gridRows() int {
+	return max(1, v.contentHeight()/gridCellHeight)
+}
+
+// ensureGridVisible scrolls the grid so the cursor's row is on screen
+func (v *LibraryView) ensureGridVisible() {
+	row := v.cursor / v.gridColumns()
+	if row < v.gridOffset {
+		v.gridOffset = row
+	}
+	if row >= v.gridOffset+v.gridRows() {
+		v.gridOffset = row - v.gridRows() + 1
+	}
+}
+
+// visibleGridRange returns the first and past-the-end book indexes on screen
+func (v *LibraryView) visibleGridRange() (int, int) {
+	cols := v.gridColumns()
+	start := v.gridOffset * cols
+	return start, min(len(v.books), start+cols*v.gridRows())
+}
+
+// handleGridNavigation moves the cursor around the grid, returns true if handled
+func (v *LibraryView) handleGridNavigation(key string) bool {
+	if len(v.books) == 0 {
+		return false
+	}
+	cols, rows := v.gridColumns(), v.gridRows()
+	switch key {
+	case "h", "left":
+		v.cursor--
+	case "l", "right":
+		v.cursor++
+	case "k", "up":
+		v.cursor -= cols
+	case "j", "down":
+		v.cursor += cols
+	case "ctrl+u", "pgup":
+		v.cursor -= cols * rows
+	case "ctrl+d", "pgdown":
+		v.cursor += cols * rows
+	case "g", "home":
+		v.cursor = 0
+	case "G", "end":
+		v.cursor = len(v.books) - 1
+	default:
+		return false
+	}
+	v.cursor = max(0, min(v.cursor, len(v.books)-1))
+	v.ensureGridVisible()
+	return true
+}
+
+// renderGrid renders the visible books as a wall of covers
+func (v *LibraryView) renderGrid() string {
+	cols := v.gridColumns()
+	start, end := v.visibleGridRange()
+
+	var rows []string
+	for rowStart := start; rowStart < end; rowStart += cols {
+		var cells []string
+		for i := rowStart; i < min(rowStart+cols, end); i++ {
+			cells = append(cells, v.renderGridCell(v.books[i], i == v.cursor))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderGridCell renders one cover with its title underneath
+func (v *LibraryView) renderGridCell(book models.Book, selected bool) string {
+	cover, ok := v.gridCoverCache[book.ID]
+	if !ok {
+		cover = styles.MutedText.Render("[...]")
+	}
+	coverCell := lipgloss.NewStyle().
+		Width(gridCellWidth).
+		Height(gridCoverLines).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(cover)
+
+	label := truncateText(book.Title, gridCoverWidth)
+	labelStyle := styles.MutedText
+	if selected || v.marked[book.ID] {
+		label = v.selectorPrefix(book, selected) + truncateText(book.Title, gridCoverWidth-2)
+	}
+	if selected {
+		labelStyle = styles.HelpKey
+	}
+	title := labelStyle.Width(gridCellWidth).Align(lipgloss.Center).Render(label)
+	return lipgloss.NewStyle().Height(gridCellHeight).Render(lipgloss.JoinVertical(lipgloss.Center, coverCell, title))
+}
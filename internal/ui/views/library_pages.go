@@ -0,0 +1,76 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// totalPages returns the number of library pages, at least 1
+func (v *LibraryView) totalPages() int {
+	return max(1, (v.total+v.pageSize-1)/v.pageSize)
+}
+
+// pageRange describes the books on this page, e.g. "51–100 of 412"
+func (v *LibraryView) pageRange() string {
+	if v.total == 0 || len(v.books) == 0 {
+		return "0 of " + strconv.Itoa(v.total)
+	}
+	first := (v.page-1)*v.pageSize + 1
+	if v.infiniteScroll() {
+		first = 1 // Every page so far is in the list
+	}
+	return fmt.Sprintf("%d–%d of %d", first, first+len(v.books)-1, v.total)
+}
+
+// startPageJump prompts for a page number to go to
+func (v *LibraryView) startPageJump() tea.Cmd {
+	if v.totalPages() < 2 {
+		return nil
+	}
+	v.jumpingPage = true
+	v.pageInput.SetValue("")
+	v.pageInput.Placeholder = fmt.Sprintf("1-%d", v.totalPages())
+	v.pageInput.Focus()
+	return textinput.Blink
+}
+
+// handlePageJumpKeys handles keys while typing a page number
+func (v *LibraryView) handlePageJumpKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.jumpingPage = false
+		v.pageInput.Blur()
+		return v, nil
+	case "enter":
+		v.jumpingPage = false
+		v.pageInput.Blur()
+		page, err := strconv.Atoi(strings.TrimSpace(v.pageInput.Value()))
+		if err != nil {
+			return v, nil
+		}
+		page = max(1, min(page, v.totalPages()))
+		if page == v.page {
+			return v, nil
+		}
+		v.page = page
+		v.cursor = 0
+		v.offset = 0
+		v.gridOffset = 0
+		return v, v.loadBooks()
+	}
+	var cmd tea.Cmd
+	v.pageInput, cmd = v.pageInput.Update(msg)
+	return v, cmd
+}
+
+// renderPageJumpPrompt renders the page number prompt for the footer
+func (v *LibraryView) renderPageJumpPrompt() string {
+	return styles.SecondaryText.Render("Go to page: ") + v.pageInput.View() + "  " +
+		styles.HelpKey.Render("enter") + styles.Help.Render(" go  ") +
+		styles.HelpKey.Render("esc") + styles.Help.Render(" cancel")
+}
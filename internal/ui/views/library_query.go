@@ -0,0 +1,86 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// parseSearchQuery splits a library search into free text and field filters:
+// author:tolkien, series:dune and format:cbz. Values with spaces can be quoted,
+// as in author:"le guin". Other words, including unknown prefixes, stay in the
+// free text.
+func parseSearchQuery(input, contentType string) api.BookFilters {
+	filters := api.BookFilters{ContentType: contentType}
+	var text []string
+	for _, token := range splitQuery(input) {
+		field, value, ok := strings.Cut(token, ":")
+		if !ok || value == "" {
+			text = append(text, token)
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(field) {
+		case "author":
+			filters.Author = value
+		case "series":
+			filters.Series = value
+		case "format":
+			filters.Format = strings.ToLower(value)
+		default:
+			text = append(text, token)
+		}
+	}
+	filters.Search = strings.Join(text, " ")
+	return filters
+}
+
+// splitQuery splits a search on spaces, keeping quoted runs together
+func splitQuery(input string) []string {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// hasFieldFilters reports whether a search uses any field prefix
+func hasFieldFilters(filters api.BookFilters) bool {
+	return filters.Author != "" || filters.Series != "" || filters.Format != ""
+}
+
+// filterByFields keeps the books matching the field filters (case-insensitive
+// substrings, exact formats), for servers that ignore them
+func filterByFields(books []models.Book, filters api.BookFilters) []models.Book {
+	if !hasFieldFilters(filters) {
+		return books
+	}
+	contains := func(field, want string) bool {
+		return want == "" || strings.Contains(strings.ToLower(field), strings.ToLower(want))
+	}
+	kept := make([]models.Book, 0, len(books))
+	for _, book := range books {
+		if contains(book.Author, filters.Author) && contains(book.Series, filters.Series) &&
+			(filters.Format == "" || strings.EqualFold(book.FileFormat, filters.Format)) {
+			kept = append(kept, book)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,56 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  api.BookFilters
+	}{
+		{"dune", api.BookFilters{Search: "dune"}},
+		{"author:herbert", api.BookFilters{Author: "herbert"}},
+		{`Author:"le guin" earthsea`, api.BookFilters{Search: "earthsea", Author: "le guin"}},
+		{"series:dune format:EPUB messiah", api.BookFilters{Search: "messiah", Series: "dune", Format: "epub"}},
+		{"title:dune author:", api.BookFilters{Search: "title:dune author:"}},
+		{"  two   spaces  ", api.BookFilters{Search: "two spaces"}},
+	}
+	for _, tt := range tests {
+		tt.want.ContentType = models.ContentTypeComic
+		if got := parseSearchQuery(tt.input, models.ContentTypeComic); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSearchQuery(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFilterByFields(t *testing.T) {
+	books := []models.Book{
+		{ID: "1", Author: "Frank Herbert", Series: "Dune", FileFormat: "epub"},
+		{ID: "2", Author: "Frank Herbert", Series: "Dune", FileFormat: "pdf"},
+		{ID: "3", Author: "Ursula K. Le Guin", Series: "Earthsea", FileFormat: "epub"},
+	}
+	tests := []struct {
+		filters api.BookFilters
+		want    []string
+	}{
+		{api.BookFilters{Search: "anything"}, []string{"1", "2", "3"}},
+		{api.BookFilters{Author: "herbert"}, []string{"1", "2"}},
+		{api.BookFilters{Author: "le guin", Format: "epub"}, []string{"3"}},
+		{api.BookFilters{Series: "dune", Format: "EPUB"}, []string{"1"}},
+		{api.BookFilters{Format: "ep"}, []string{}},
+	}
+	for _, tt := range tests {
+		ids := []string{}
+		for _, book := range filterByFields(books, tt.filters) {
+			ids = append(ids, book.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("filterByFields(%+v) = %v, want %v", tt.filters, ids, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+)
+
+// refreshCheckLimit is how many of the newest uploads each change check looks at
+const refreshCheckLimit = 100
+
+// libraryPollMsg is the timer for the next check for server-side changes
+type libraryPollMsg struct {
+	seq int
+}
+
+// libraryChangesMsg carries the state of the library on the server
+type libraryChangesMsg struct {
+	seq    int
+	total  int
+	newest time.Time
+	added  int // Uploads since the last check
+	err    error
+}
+
+// checkForChanges starts a new chain of checks for books added or removed on
+// the server, e.g. uploads from another device. Each check schedules the next
+// while the library is shown; any earlier chain is abandoned.
+func (v *LibraryView) checkForChanges() tea.Cmd {
+	if v.config == nil || v.config.GetRefreshInterval() == 0 {
+		return nil
+	}
+	v.pollSeq++
+	return v.fetchChanges(v.pollSeq)
+}
+
+// fetchChanges asks the server for its book count and newest uploads. The
+// server has no change feed, so this polls.
+func (v *LibraryView) fetchChanges(seq int) tea.Cmd {
+	since := v.lastUpload
+	return func() tea.Msg {
+		resp, err := v.client.ListBooks(1, refreshCheckLimit, sortDate.String(), "desc", "", "")
+		if err != nil {
+			return libraryChangesMsg{seq: seq, err: err}
+		}
+		msg := libraryChangesMsg{seq: seq, total: resp.Total}
+		for _, book := range resp.Books {
+			if book.UploadedAt.After(msg.newest) {
+				msg.newest = book.UploadedAt
+			}
+			if !since.IsZero() && book.UploadedAt.After(since) {
+				msg.added++
+			}
+		}
+		return msg
+	}
+}
+
+// schedulePoll waits for the next check. Messages only reach the library while
+// it is shown, so the chain stops when another view opens.
+func (v *LibraryView) schedulePoll(seq int) tea.Cmd {
+	interval := v.config.GetRefreshInterval()
+	if LowPower {
+		interval *= 5
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return libraryPollMsg{seq: seq}
+	})
+}
+
+// handleLibraryPoll runs a scheduled check if it belongs to the current chain
+func (v *LibraryView) handleLibraryPoll(msg libraryPollMsg) tea.Cmd {
+	if msg.seq != v.pollSeq {
+		return nil
+	}
+	return v.fetchChanges(msg.seq)
+}
+
+// handleLibraryChanges reloads the list when books were added or removed on the
+// server, with a notice of how many are new. The first check only records where
+// the library stands. Failed checks are silent and retried on the next one.
+func (v *LibraryView) handleLibraryChanges(msg libraryChangesMsg) tea.Cmd {
+	if msg.seq != v.pollSeq {
+		return nil
+	}
+	next := v.schedulePoll(msg.seq)
+	if msg.err != nil {
+		return next
+	}
+	known, prevTotal := v.polled, v.polledTotal
+	changed := msg.total != prevTotal || msg.newest.After(v.lastUpload)
+	v.polled = true
+	v.polledTotal = msg.total
+	if msg.newest.After(v.lastUpload) {
+		v.lastUpload = msg.newest
+	}
+	if !known || !changed {
+		return next
+	}
+
+	added := msg.added
+	if added == 0 && msg.total > prevTotal {
+		added = msg.total - prevTotal // Server without upload dates
+	}
+	switch {
+	case added == 1:
+		return tea.Batch(next, v.reloadForChanges("1 new book"))
+	case added > 1:
+		return tea.Batch(next, v.reloadForChanges(fmt.Sprintf("%d new books", added)))
+	default:
+		return tea.Batch(next, v.reloadForChanges("Library updated"))
+	}
+}
+
+// handleServerEvent reloads the list when the server reports a book added or
+// deleted elsewhere
+func (v *LibraryView) handleServerEvent(msg ServerEventMsg) tea.Cmd {
+	switch msg.Event.Type {
+	case api.EventBookAdded:
+		return v.reloadForChanges("1 new book")
+	case api.EventBookDeleted:
+		return v.reloadForChanges("Library updated")
+	}
+	return nil
+}
+
+// reloadForChanges shows status and reloads the list, unless that would pull
+// it out from under a prompt or a partly scrolled-in list
+func (v *LibraryView) reloadForChanges(status string) tea.Cmd {
+	v.statusMsg = status
+	if v.CapturingKeys() || v.loading || v.infiniteScroll() {
+		v.statusMsg += " (r to refresh)"
+		return nil
+	}
+	if v.browse != browseList {
+		return v.loadGroups()
+	}
+	return v.loadBooks()
+}
@@ -0,0 +1,51 @@
+package views
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// loadMoreThreshold is how close the cursor gets to the last loaded book before
+// infinite scroll fetches the next page
+const loadMoreThreshold = 5
+
+// infiniteScroll reports whether pages are appended as the cursor nears the end
+func (v *LibraryView) infiniteScroll() bool {
+	return v.config != nil && v.config.InfiniteScroll
+}
+
+// maybeLoadMore fetches the next page to append when infinite scroll is on and the
+// cursor is near the last loaded book
+func (v *LibraryView) maybeLoadMore() tea.Cmd {
+	if !v.infiniteScroll() || v.loadingMore || v.loading || !v.hasNextPage() {
+		return nil
+	}
+	if v.cursor < len(v.books)-loadMoreThreshold {
+		return nil
+	}
+	v.loadingMore = true
+	v.page++
+	load := v.loadBooks()
+	return func() tea.Msg {
+		msg := load()
+		if loaded, ok := msg.(booksLoadedMsg); ok {
+			loaded.appended = true
+			return loaded
+		}
+		return msg
+	}
+}
+
+// handleMoreBooksLoaded appends the next page of books to the list
+func (v *LibraryView) handleMoreBooksLoaded(msg booksLoadedMsg) tea.Cmd {
+	if !v.loadingMore {
+		return nil // The list was reloaded since
+	}
+	v.loadingMore = false
+	if msg.err != nil {
+		v.page-- // Try the same page again next time
+		v.statusMsg = "Failed to load more: " + msg.err.Error()
+		return nil
+	}
+	v.books = append(v.books, msg.books...)
+	v.total = msg.total
+	v.rememberBooks(msg.books)
+	return v.loadVisibleCovers()
+}
@@ -0,0 +1,50 @@
+package views
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchDebounce is how long typing must pause before the library reloads with the query
+const searchDebounce = 300 * time.Millisecond
+
+// searchDebouncedMsg fires when typing has paused; only the latest edit's message searches
+type searchDebouncedMsg struct {
+	seq int
+}
+
+// queueLiveSearch cancels any search in flight and schedules a new one for when
+// typing pauses
+func (v *LibraryView) queueLiveSearch() tea.Cmd {
+	v.stopLiveSearch()
+	v.searchSeq++
+	seq := v.searchSeq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebouncedMsg{seq: seq}
+	})
+}
+
+// stopLiveSearch cancels the search in flight and any scheduled one
+func (v *LibraryView) stopLiveSearch() {
+	v.searchSeq++
+	if v.cancelSearch != nil {
+		v.cancelSearch()
+		v.cancelSearch = nil
+	}
+}
+
+// handleSearchDebounced reloads page 1 with the query once typing has paused
+func (v *LibraryView) handleSearchDebounced(msg searchDebouncedMsg) tea.Cmd {
+	if msg.seq != v.searchSeq {
+		return nil // More was typed since
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancelSearch = cancel
+	v.page = 1
+	v.cursor = 0
+	v.offset = 0
+	v.gridOffset = 0
+	return v.loadBooksContext(ctx)
+}
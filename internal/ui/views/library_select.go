@@ -0,0 +1,304 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// libraryCollectionsLoadedMsg is sent when the collection picker's list is loaded
+type libraryCollectionsLoadedMsg struct {
+	collections []models.Collection
+	err         error
+}
+
+// booksAddedToCollectionMsg is sent when books have been added to a collection
+type booksAddedToCollectionMsg struct {
+	collection string
+	added      int
+	err        error
+}
+
+// maxConcurrentDeletes caps the delete requests a batch delete runs at once
+const maxConcurrentDeletes = 4
+
+// booksDeletedMsg is sent when a batch delete finishes
+type booksDeletedMsg struct {
+	deleted int
+	failed  []models.Book
+	err     error // First failure, if any
+}
+
+// toggleMark marks or unmarks the book under the cursor and moves to the next one
+func (v *LibraryView) toggleMark() {
+	book, ok := v.getSelectedBook()
+	if !ok {
+		return
+	}
+	if v.marked[book.ID] {
+		delete(v.marked, book.ID)
+	} else {
+		v.marked[book.ID] = true
+	}
+	if v.gridMode {
+		v.handleGridNavigation("l")
+	} else {
+		v.moveCursor(1)
+	}
+}
+
+// clearMarks unmarks every book
+func (v *LibraryView) clearMarks() {
+	v.marked = make(map[string]bool)
+}
+
+// targetBooks returns the marked books on this page, or the book under the cursor if none are marked
+func (v *LibraryView) targetBooks() []models.Book {
+	var books []models.Book
+	for _, book := range v.books {
+		if v.marked[book.ID] {
+			books = append(books, book)
+		}
+	}
+	if len(books) == 0 {
+		if book, ok := v.getSelectedBook(); ok {
+			books = append(books, book)
+		}
+	}
+	return books
+}
+
+// countBooks returns "1 book" or "n books"
+func countBooks(n int) string {
+	if n == 1 {
+		return "1 book"
+	}
+	return fmt.Sprintf("%d books", n)
+}
+
+// bookIDs returns the IDs of books
+func bookIDs(books []models.Book) []string {
+	ids := make([]string, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+	return ids
+}
+
+// handleBatchAction applies f (favorite), w (queue) or d (delete) to all marked books.
+// Favorite and queue add every book unless all are already in, in which case they are removed.
+func (v *LibraryView) handleBatchAction(key string) (View, tea.Cmd) {
+	books := v.targetBooks()
+	switch key {
+	case "f":
+		if v.config == nil {
+			return v, nil
+		}
+		favorite := false
+		for _, book := range books {
+			favorite = favorite || !v.config.IsFavorite(book.ID)
+		}
+		if err := v.config.SetFavorites(bookIDs(books), favorite); err != nil {
+			v.err = err
+			return v, nil
+		}
+		v.statusMsg = "Unfavorited " + countBooks(len(books))
+		if favorite {
+			v.statusMsg = "Favorited " + countBooks(len(books))
+		}
+		v.clearMarks()
+	case "w":
+		if v.config == nil {
+			return v, nil
+		}
+		queued := false
+		for _, book := range books {
+			queued = queued || !v.config.IsInQueue(book.ID)
+		}
+		if err := v.config.SetQueued(bookIDs(books), queued); err != nil {
+			v.err = err
+			return v, nil
+		}
+		v.statusMsg = "Removed " + countBooks(len(books)) + " from the queue"
+		if queued {
+			v.statusMsg = "Queued " + countBooks(len(books))
+		}
+		v.clearMarks()
+	case "d":
+		v.confirmDelete = true
+	}
+	return v, nil
+}
+
+// deleteBooksCmd deletes books, up to maxConcurrentDeletes at once, collecting every failure
+func (v *LibraryView) deleteBooksCmd(books []models.Book) tea.Cmd {
+	return func() tea.Msg {
+		errs := make([]error, len(books))
+		_ = api.ForEach(context.Background(), len(books), maxConcurrentDeletes, func(ctx context.Context, i int) error {
+			errs[i] = v.client.DeleteBook(books[i].ID)
+			return nil // Every book is tried; failures are reported per book
+		})
+
+		var msg booksDeletedMsg
+		for i, err := range errs {
+			if err == nil {
+				msg.deleted++
+				continue
+			}
+			msg.failed = append(msg.failed, books[i])
+			if msg.err == nil {
+				msg.err = err
+			}
+		}
+		return msg
+	}
+}
+
+// handleBooksDeleted reports how many books were deleted and which failed, leaving
+// the failed ones marked to retry, and reloads the list
+func (v *LibraryView) handleBooksDeleted(msg booksDeletedMsg) tea.Cmd {
+	v.clearMarks()
+	v.statusMsg = "Deleted " + countBooks(msg.deleted)
+	if len(msg.failed) > 0 {
+		var titles []string
+		for _, book := range msg.failed {
+			v.marked[book.ID] = true
+			titles = append(titles, truncateText(book.Title, 30))
+		}
+		v.statusMsg += fmt.Sprintf(", %d failed (%s): %s", len(msg.failed), strings.Join(titles, ", "), msg.err)
+	}
+	return v.loadBooks()
+}
+
+// openCollectionPicker lists collections to add the target books to
+func (v *LibraryView) openCollectionPicker() tea.Cmd {
+	if len(v.targetBooks()) == 0 {
+		return nil
+	}
+	v.pickingCollection = true
+	v.collections = nil
+	v.collectionCursor = 0
+	return func() tea.Msg {
+		resp, err := v.client.ListCollections()
+		if err != nil {
+			return libraryCollectionsLoadedMsg{err: err}
+		}
+		return libraryCollectionsLoadedMsg{collections: resp.Collections}
+	}
+}
+
+// handleCollectionPickerKeys handles keys while choosing a collection
+func (v *LibraryView) handleCollectionPickerKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		v.collectionCursor = min(v.collectionCursor+1, max(0, len(v.collections)-1))
+	case "k", "up":
+		v.collectionCursor = max(0, v.collectionCursor-1)
+	case "enter":
+		if v.collectionCursor >= len(v.collections) {
+			return v, nil
+		}
+		v.pickingCollection = false
+		return v, v.addToCollectionCmd(v.collections[v.collectionCursor], bookIDs(v.targetBooks()))
+	case "esc", "q":
+		v.pickingCollection = false
+	}
+	return v, nil
+}
+
+// addToCollectionCmd adds books to a collection, stopping at the first failure
+func (v *LibraryView) addToCollectionCmd(collection models.Collection, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		for i, id := range ids {
+			if err := v.client.AddBookToCollection(collection.ID, id); err != nil {
+				return booksAddedToCollectionMsg{collection: collection.Name, added: i, err: err}
+			}
+		}
+		return booksAddedToCollectionMsg{collection: collection.Name, added: len(ids)}
+	}
+}
+
+// handleBooksAddedToCollection reports the result of adding books to a collection
+func (v *LibraryView) handleBooksAddedToCollection(msg booksAddedToCollectionMsg) {
+	if msg.err != nil {
+		v.err = msg.err
+		return
+	}
+	v.clearMarks()
+	v.statusMsg = fmt.Sprintf("Added %s to %s", countBooks(msg.added), msg.collection)
+}
+
+// selectorPrefix returns the two-column prefix for a book: the cursor arrow and its mark
+func (v *LibraryView) selectorPrefix(book models.Book, selected bool) string {
+	prefix := " "
+	if selected {
+		prefix = "▸"
+	}
+	if v.marked[book.ID] {
+		return prefix + "✓"
+	}
+	return prefix + " "
+}
+
+// renderBatchDeleteConfirmation renders the confirmation for deleting all marked books
+func (v *LibraryView) renderBatchDeleteConfirmation() string {
+	books := v.targetBooks()
+	maxTitles := max(5, v.height-14) // As many as fit around the dialog text
+	var titles []string
+	for i, book := range books {
+		if i == maxTitles && len(books) > maxTitles+1 {
+			titles = append(titles, styles.MutedText.Render(fmt.Sprintf("...and %d more", len(books)-i)))
+			break
+		}
+		titles = append(titles, styles.BookTitle.Render(truncateText(book.Title, 44)))
+	}
+
+	dialog := styles.Dialog.Width(50).Render(
+		styles.DialogTitle.Render(fmt.Sprintf("Delete %s?", countBooks(len(books)))) + "\n\n" +
+			strings.Join(titles, "\n") + "\n\n" +
+			styles.ErrorStyle.Render("This action cannot be undone.") + "\n\n" +
+			styles.Help.Render("Press ") +
+			styles.HelpKey.Render("y") +
+			styles.Help.Render(" to confirm, ") +
+			styles.HelpKey.Render("n") +
+			styles.Help.Render(" to cancel"),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// renderCollectionPicker renders the list of collections to add books to
+func (v *LibraryView) renderCollectionPicker() string {
+	var lines []string
+	switch {
+	case v.collections == nil:
+		lines = append(lines, styles.MutedText.Render("Loading collections..."))
+	case len(v.collections) == 0:
+		lines = append(lines, styles.MutedText.Render("No collections yet (press c to manage them)"))
+	}
+	for i, collection := range v.collections {
+		if i == v.collectionCursor {
+			lines = append(lines, styles.SecondaryText.Render("▸ ")+styles.SecondaryText.Bold(true).Render(truncateText(collection.Name, 40)))
+		} else {
+			lines = append(lines, "  "+styles.MutedText.Render(truncateText(collection.Name, 40)))
+		}
+	}
+
+	dialog := styles.Dialog.Width(50).Render(
+		styles.DialogTitle.Render(fmt.Sprintf("Add %s to Collection", countBooks(len(v.targetBooks())))) + "\n\n" +
+			strings.Join(lines, "\n") + "\n\n" +
+			styles.HelpKey.Render("enter") + styles.Help.Render(" add  ") +
+			styles.HelpKey.Render("esc") + styles.Help.Render(" cancel"),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// CapturingKeys implements KeyCapturer: the search prompt and dialogs handle q and esc themselves
+func (v *LibraryView) CapturingKeys() bool {
+	return v.searchMode || v.confirmDelete || v.pickingCollection || v.pickingCover || v.pickingTag || v.savingFilter || v.jumpingPage || v.showingStats
+}
@@ -0,0 +1,28 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/justyntemme/webby-t/internal/api/apitest"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+func TestDeleteBooksReportsEachFailure(t *testing.T) {
+	s := apitest.NewServer()
+	defer s.Close()
+	var books []models.Book
+	for _, title := range []string{"Dune", "Emma", "Ulysses", "Beloved", "Middlemarch"} {
+		books = append(books, s.AddBook(models.Book{Title: title}))
+	}
+	missing := models.Book{ID: "missing", Title: "Gone"}
+	books = append(books[:2], append([]models.Book{missing}, books[2:]...)...)
+
+	v := &LibraryView{client: s.Client("reader")}
+	msg := v.deleteBooksCmd(books)().(booksDeletedMsg)
+	if msg.deleted != 5 || len(msg.failed) != 1 || msg.failed[0].ID != "missing" || msg.err == nil {
+		t.Fatalf("deleted %d, failed %+v, err %v; want 5 deleted and the missing book failed", msg.deleted, msg.failed, msg.err)
+	}
+	if left, err := s.Client("reader").ListBooks(1, 20, "title", "asc", "", ""); err != nil || left.Total != 0 {
+		t.Fatalf("books left = %+v, %v", left, err)
+	}
+}
@@ -0,0 +1,30 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// listSharedBooks fetches the books other users have shared with me. The server
+// returns them all at once, so search, the content type filter and paging apply here.
+func (v *LibraryView) listSharedBooks(page, pageSize int, query, contentType string) (*models.BooksResponse, error) {
+	resp, err := v.client.GetSharedBooks()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.ToLower(query)
+	books := make([]models.Book, 0, len(resp.Books))
+	for _, book := range resp.Books {
+		if contentType != "" && book.ContentType != contentType {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(book.Title+" "+book.Author+" "+book.Series), query) {
+			continue
+		}
+		books = append(books, book)
+	}
+	start := min(len(books), (page-1)*pageSize)
+	end := min(len(books), start+pageSize)
+	return &models.BooksResponse{Books: books[start:end], Total: len(books)}, nil
+}
@@ -0,0 +1,145 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// parseSortField returns the sort field with the given API name, defaulting to title
+func parseSortField(name string) sortField {
+	for s := sortTitle; s <= sortDate; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+	return sortTitle
+}
+
+// smartCollection captures the current search, sort and filters under name
+func (v *LibraryView) smartCollection(name string) config.SmartCollection {
+	return config.SmartCollection{
+		Name:         name,
+		Search:       v.searchInput.Value(),
+		Sort:         v.sortBy.String(),
+		Descending:   !v.sortAsc,
+		ContentType:  v.contentType,
+		Author:       v.filterAuthor,
+		Series:       v.filterSeries,
+		Tag:          v.filterTag,
+		Status:       v.filterStatus,
+		Favorites:    v.favoritesMode,
+		Queue:        v.queueMode,
+		RecentlyRead: v.recentlyReadMode,
+		NewestFirst:  v.recentlyAdded,
+		MinRating:    v.minRating,
+		Shared:       v.sharedMode,
+		Archived:     v.showArchived,
+	}
+}
+
+// ApplySmartCollection restores the search, sort and filters saved in a smart
+// collection. The books are loaded when the library is next shown.
+func (v *LibraryView) ApplySmartCollection(collection config.SmartCollection) {
+	v.applyFilters(collection)
+	v.page = 1
+	v.cursor = 0
+	v.offset = 0
+	v.gridOffset = 0
+	v.statusMsg = "Showing " + collection.Name
+}
+
+// applyFilters sets the search, sort and filters from a smart collection
+func (v *LibraryView) applyFilters(collection config.SmartCollection) {
+	v.searchInput.SetValue(collection.Search)
+	v.sortBy = parseSortField(collection.Sort)
+	v.sortAsc = !collection.Descending
+	v.contentType = collection.ContentType
+	v.filterAuthor = collection.Author
+	v.filterSeries = collection.Series
+	v.filterTag = collection.Tag
+	v.filterStatus = collection.Status
+	v.favoritesMode = collection.Favorites
+	v.queueMode = collection.Queue
+	v.recentlyReadMode = collection.RecentlyRead
+	v.recentlyAdded = collection.NewestFirst
+	v.minRating = collection.MinRating
+	v.sharedMode = collection.Shared
+	v.showArchived = collection.Archived
+}
+
+// SaveState records the library's sort, filters, page and cursor in the config so
+// the next start returns to them
+func (v *LibraryView) SaveState() error {
+	if v.config == nil {
+		return nil
+	}
+	return v.config.SetLibraryState(config.LibraryState{
+		Filters: v.smartCollection(""),
+		Page:    v.page,
+		Cursor:  v.cursor,
+	})
+}
+
+// restoreState returns the library to where it was left last time
+func (v *LibraryView) restoreState() {
+	if v.config == nil || v.config.LibraryState == nil {
+		return
+	}
+	state := v.config.LibraryState
+	v.applyFilters(state.Filters)
+	v.page = max(1, state.Page)
+	v.cursor = max(0, state.Cursor)
+	if v.infiniteScroll() {
+		// Only the first page is loaded at the start
+		v.cursor = max(0, (v.page-1)*v.pageSize+v.cursor)
+		v.page = 1
+	}
+}
+
+// startSavingFilter prompts for a name to save the current filters under
+func (v *LibraryView) startSavingFilter() tea.Cmd {
+	if v.config == nil {
+		return nil
+	}
+	v.savingFilter = true
+	v.smartNameInput.SetValue("")
+	v.smartNameInput.Focus()
+	return textinput.Blink
+}
+
+// handleSaveFilterKeys handles keys while naming a smart collection
+func (v *LibraryView) handleSaveFilterKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.savingFilter = false
+		v.smartNameInput.Blur()
+		return v, nil
+	case "enter":
+		name := strings.TrimSpace(v.smartNameInput.Value())
+		if name == "" {
+			return v, nil
+		}
+		v.savingFilter = false
+		v.smartNameInput.Blur()
+		if err := v.config.SaveSmartCollection(v.smartCollection(name)); err != nil {
+			v.err = err
+			return v, nil
+		}
+		v.statusMsg = "Saved smart collection " + name
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.smartNameInput, cmd = v.smartNameInput.Update(msg)
+	return v, cmd
+}
+
+// renderSaveFilterPrompt renders the smart collection name prompt for the footer
+func (v *LibraryView) renderSaveFilterPrompt() string {
+	return styles.SecondaryText.Render("Save filters as: ") + v.smartNameInput.View() + "  " +
+		styles.HelpKey.Render("enter") + styles.Help.Render(" save  ") +
+		styles.HelpKey.Render("esc") + styles.Help.Render(" cancel")
+}
@@ -0,0 +1,70 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// libraryStatsMsg carries the library summary
+type libraryStatsMsg struct {
+	stats *models.LibraryStats
+	err   error
+}
+
+// openStats shows the library summary popup and fetches the numbers
+func (v *LibraryView) openStats() tea.Cmd {
+	v.showingStats = true
+	v.stats = nil
+	v.statsErr = nil
+	return func() tea.Msg {
+		stats, err := v.client.GetLibraryStats()
+		return libraryStatsMsg{stats: stats, err: err}
+	}
+}
+
+// handleLibraryStats fills in the popup if it is still open
+func (v *LibraryView) handleLibraryStats(msg libraryStatsMsg) {
+	if !v.showingStats {
+		return
+	}
+	v.stats, v.statsErr = msg.stats, msg.err
+}
+
+// renderStats renders the library summary popup
+func (v *LibraryView) renderStats() string {
+	var body string
+	switch {
+	case v.statsErr != nil:
+		body = styles.ErrorStyle.Render("Error: " + v.statsErr.Error())
+	case v.stats == nil:
+		body = styles.MutedText.Render("Counting books...")
+	default:
+		lines := []string{
+			statsLine("Books", fmt.Sprint(v.stats.Books)),
+			statsLine("Comics", fmt.Sprint(v.stats.Comics)),
+			statsLine("Total", fmt.Sprint(v.stats.Total)),
+			statsLine("Size on disk", formatFileSize(v.stats.TotalSize)),
+		}
+		if v.config != nil && len(v.config.Archived) > 0 {
+			lines = append(lines, statsLine("Archived here", fmt.Sprint(len(v.config.Archived))))
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	dialog := styles.Dialog.Width(40).Render(
+		styles.DialogTitle.Render("Library Summary") + "\n\n" +
+			body + "\n\n" +
+			styles.Help.Render("Press any key to close"),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// statsLine renders one label and value of the summary
+func statsLine(label, value string) string {
+	return styles.MutedText.Render(padRight(label, 16)) + value
+}
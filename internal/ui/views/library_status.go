@@ -0,0 +1,98 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// nextStatusFilter returns the read-status filter after current, cycling
+// off → unread → in progress → finished → off
+func nextStatusFilter(current string) string {
+	status, ok := config.ParseReadStatus(current)
+	switch {
+	case !ok:
+		return config.StatusUnread.String()
+	case status == config.StatusFinished:
+		return ""
+	default:
+		return (status + 1).String()
+	}
+}
+
+// filterByStatus returns the books whose read status is the named one
+func filterByStatus(cfg *config.Config, books []models.Book, name string) []models.Book {
+	status, ok := config.ParseReadStatus(name)
+	if !ok || cfg == nil {
+		return books
+	}
+	filtered := make([]models.Book, 0, len(books))
+	for _, book := range books {
+		if cfg.GetReadStatus(book.ID) == status {
+			filtered = append(filtered, book)
+		}
+	}
+	return filtered
+}
+
+// filterByRating returns the books rated at least minStars, best rated first
+func filterByRating(cfg *config.Config, books []models.Book, minStars int) []models.Book {
+	if cfg == nil {
+		return books
+	}
+	filtered := make([]models.Book, 0, len(books))
+	for _, book := range books {
+		if cfg.GetRating(book.ID) >= minStars {
+			filtered = append(filtered, book)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return cfg.GetRating(filtered[i].ID) > cfg.GetRating(filtered[j].ID)
+	})
+	return filtered
+}
+
+// markNextStatus sets the selected book's read status by hand to the one after its
+// current status: unread → in progress → finished → unread
+func (v *LibraryView) markNextStatus() {
+	book, ok := v.getSelectedBook()
+	if !ok || v.config == nil {
+		return
+	}
+	status := (v.config.GetReadStatus(book.ID) + 1) % (config.StatusFinished + 1)
+	if err := v.config.SetReadStatus(book.ID, status); err != nil {
+		v.err = err
+		return
+	}
+	v.statusMsg = "Marked " + truncateText(book.Title, 40) + " as " + strings.ToLower(status.Label())
+}
+
+// progressBarCells is the width of the mini progress bar in the library list
+const progressBarCells = 5
+
+// progressCell renders a book's reading progress for the library list as a mini
+// bar and percentage, blank for unread books. It is empty while no book has been
+// read, so the list keeps its full width until progress is recorded.
+func (v *LibraryView) progressCell(book models.Book) string {
+	if v.config == nil || (len(v.config.Progress) == 0 && len(v.config.StatusOverrides) == 0) {
+		return ""
+	}
+	fraction, _ := v.config.GetProgress(book.ID)
+	label := fmt.Sprintf("%3d%%", int(fraction*100))
+	switch v.config.GetReadStatus(book.ID) {
+	case config.StatusUnread:
+		return strings.Repeat(" ", progressBarCells+7)
+	case config.StatusFinished:
+		fraction, label = 1, "done"
+	}
+	return "  " + progressBar(fraction, progressBarCells) + " " + label
+}
+
+// progressBar renders a share read (0-1) as a bar of filled and empty cells
+func progressBar(fraction float64, cells int) string {
+	filled := max(0, min(cells, int(fraction*float64(cells)+0.5)))
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", cells-filled)
+}
@@ -0,0 +1,237 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// maxTagPickerLines is how many tags the picker shows at once
+const maxTagPickerLines = 12
+
+// tagsSavedMsg is sent when tags have been saved to the server
+type tagsSavedMsg struct {
+	tags        map[string][]string // New tags by book ID, for the books saved
+	unsupported bool                // The server doesn't store tags; save them locally instead
+	err         error
+}
+
+// bookTags returns a book's tags: those from the server plus any stored locally
+func bookTags(cfg *config.Config, book models.Book) []string {
+	tags := slices.Clone(book.Tags)
+	if cfg != nil {
+		for _, tag := range cfg.GetBookTags(book.ID) {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// openTagPicker lists known tags to apply to the target books or filter by
+func (v *LibraryView) openTagPicker() {
+	seen := make(map[string]bool)
+	var choices []string
+	add := func(tags []string) {
+		for _, tag := range tags {
+			if !seen[tag] {
+				seen[tag] = true
+				choices = append(choices, tag)
+			}
+		}
+	}
+	if v.config != nil {
+		add(v.config.AllBookTags())
+	}
+	for _, book := range v.books {
+		add(book.Tags)
+	}
+	sort.Strings(choices)
+
+	v.tagChoices = choices
+	v.tagCursor = 0
+	v.pickingTag = true
+}
+
+// handleTagPickerKeys handles keys while the tag picker is open
+func (v *LibraryView) handleTagPickerKeys(msg tea.KeyMsg) (View, tea.Cmd) {
+	if v.tagInput.Focused() {
+		switch msg.String() {
+		case "enter":
+			tag := strings.TrimSpace(v.tagInput.Value())
+			v.tagInput.Blur()
+			v.tagInput.SetValue("")
+			if tag == "" {
+				return v, nil
+			}
+			if !slices.Contains(v.tagChoices, tag) {
+				v.tagChoices = append(v.tagChoices, tag)
+				sort.Strings(v.tagChoices)
+			}
+			v.tagCursor = slices.Index(v.tagChoices, tag)
+			return v, v.setTag(tag, true)
+		case "esc":
+			v.tagInput.Blur()
+			v.tagInput.SetValue("")
+			return v, nil
+		}
+		var cmd tea.Cmd
+		v.tagInput, cmd = v.tagInput.Update(msg)
+		return v, cmd
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		v.tagCursor = min(v.tagCursor+1, max(0, len(v.tagChoices)-1))
+	case "k", "up":
+		v.tagCursor = max(0, v.tagCursor-1)
+	case "n":
+		v.tagInput.Focus()
+		return v, textinput.Blink
+	case " ":
+		if v.tagCursor < len(v.tagChoices) {
+			tag := v.tagChoices[v.tagCursor]
+			return v, v.setTag(tag, !v.allTagged(tag))
+		}
+	case "enter":
+		if v.tagCursor < len(v.tagChoices) {
+			v.pickingTag = false
+			v.filterTag = v.tagChoices[v.tagCursor]
+			v.filterAuthor = ""
+			v.filterSeries = ""
+			return v, v.resetAndLoadBooks()
+		}
+	case "esc", "q":
+		v.pickingTag = false
+	}
+	return v, nil
+}
+
+// allTagged reports whether every target book has tag
+func (v *LibraryView) allTagged(tag string) bool {
+	books := v.targetBooks()
+	for _, book := range books {
+		if !slices.Contains(bookTags(v.config, book), tag) {
+			return false
+		}
+	}
+	return len(books) > 0
+}
+
+// setTag adds tag to or removes it from the target books, on the server when it
+// stores tags and in the local config otherwise
+func (v *LibraryView) setTag(tag string, add bool) tea.Cmd {
+	updates := make(map[string][]string)
+	for _, book := range v.targetBooks() {
+		tags := bookTags(v.config, book)
+		if add && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+			sort.Strings(tags)
+		} else if !add {
+			tags = slices.DeleteFunc(tags, func(t string) bool { return t == tag })
+		}
+		updates[book.ID] = tags
+	}
+	if add {
+		v.statusMsg = fmt.Sprintf("Tagged %s with %s", countBooks(len(updates)), tag)
+	} else {
+		v.statusMsg = fmt.Sprintf("Removed %s from %s", tag, countBooks(len(updates)))
+	}
+
+	if v.localTags {
+		v.saveLocalTags(updates)
+		return nil
+	}
+	return func() tea.Msg {
+		saved := make(map[string][]string)
+		for id, tags := range updates {
+			if err := v.client.SetBookTags(id, tags); errors.Is(err, api.ErrNotSupported) {
+				return tagsSavedMsg{tags: updates, unsupported: true}
+			} else if err != nil {
+				return tagsSavedMsg{tags: saved, err: err}
+			}
+			saved[id] = tags
+		}
+		return tagsSavedMsg{tags: saved}
+	}
+}
+
+// handleTagsSaved records tags the server stored, or falls back to local tags
+func (v *LibraryView) handleTagsSaved(msg tagsSavedMsg) {
+	if msg.unsupported {
+		v.localTags = true
+		v.saveLocalTags(msg.tags)
+		return
+	}
+	for i, book := range v.books {
+		if tags, ok := msg.tags[book.ID]; ok {
+			v.books[i].Tags = tags
+			if v.config != nil {
+				_ = v.config.SetBookTags(book.ID, nil) // The server copy replaces any local tags
+			}
+		}
+	}
+	if msg.err != nil {
+		v.statusMsg = ""
+		v.err = msg.err
+	}
+}
+
+// saveLocalTags stores tags in the config for servers that don't keep them
+func (v *LibraryView) saveLocalTags(tags map[string][]string) {
+	if v.config == nil {
+		return
+	}
+	for id, bookTags := range tags {
+		if err := v.config.SetBookTags(id, bookTags); err != nil {
+			v.err = err
+			return
+		}
+	}
+}
+
+// renderTagPicker renders the tag list with marks for tags every target book has
+func (v *LibraryView) renderTagPicker() string {
+	var lines []string
+	if len(v.tagChoices) == 0 {
+		lines = append(lines, styles.MutedText.Render("No tags yet (press n to add one)"))
+	}
+	start := max(0, v.tagCursor-maxTagPickerLines+1)
+	for i := start; i < min(len(v.tagChoices), start+maxTagPickerLines); i++ {
+		tag := v.tagChoices[i]
+		check := "[ ] "
+		if v.allTagged(tag) {
+			check = "[✓] "
+		}
+		if i == v.tagCursor {
+			lines = append(lines, styles.SecondaryText.Render("▸ "+check)+styles.SecondaryText.Bold(true).Render(truncateText(tag, 36)))
+		} else {
+			lines = append(lines, "  "+styles.MutedText.Render(check+truncateText(tag, 36)))
+		}
+	}
+	if v.tagInput.Focused() {
+		lines = append(lines, "", v.tagInput.View())
+	}
+
+	dialog := styles.Dialog.Width(50).Render(
+		styles.DialogTitle.Render(fmt.Sprintf("Tags for %s", countBooks(len(v.targetBooks())))) + "\n\n" +
+			strings.Join(lines, "\n") + "\n\n" +
+			styles.HelpKey.Render("space") + styles.Help.Render(" toggle  ") +
+			styles.HelpKey.Render("n") + styles.Help.Render(" new  ") +
+			styles.HelpKey.Render("enter") + styles.Help.Render(" filter  ") +
+			styles.HelpKey.Render("esc") + styles.Help.Render(" close"),
+	)
+	return lipgloss.Place(v.width, v.height, lipgloss.Center, lipgloss.Center, dialog)
+}
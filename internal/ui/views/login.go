@@ -46,20 +46,20 @@ type LoginView struct {
 // NewLoginView creates a new login view
 func NewLoginView(client *api.Client, cfg *config.Config) *LoginView {
 	// Username input
-	usernameInput := textinput.New()
+	usernameInput := newTextInput()
 	usernameInput.Placeholder = "username"
 	usernameInput.Focus()
 	usernameInput.CharLimit = 50
 	usernameInput.Width = 30
 
 	// Email input (for registration)
-	emailInput := textinput.New()
+	emailInput := newTextInput()
 	emailInput.Placeholder = "email@example.com"
 	emailInput.CharLimit = 100
 	emailInput.Width = 30
 
 	// Password input
-	passwordInput := textinput.New()
+	passwordInput := newTextInput()
 	passwordInput.Placeholder = "password"
 	passwordInput.EchoMode = textinput.EchoPassword
 	passwordInput.EchoCharacter = '•'
@@ -77,6 +77,19 @@ func NewLoginView(client *api.Client, cfg *config.Config) *LoginView {
 	}
 }
 
+// PrefillUsername fills in the username and moves to the password, so a user
+// whose session expired only has to type that
+func (v *LoginView) PrefillUsername(username string) {
+	if username == "" || v.isRegistering {
+		return
+	}
+	v.usernameInput.SetValue(username)
+	v.usernameInput.Blur()
+	v.passwordInput.SetValue("")
+	v.passwordInput.Focus()
+	v.focusIndex = 1
+}
+
 // Init implements View
 func (v *LoginView) Init() tea.Cmd {
 	return textinput.Blink
@@ -117,6 +130,11 @@ func (v *LoginView) Update(msg tea.Msg) (View, tea.Cmd) {
 		case "ctrl+r":
 			v.toggleMode()
 			return v, nil
+
+		case "ctrl+p":
+			if len(v.config.Profiles) > 0 {
+				return v, SwitchTo(ViewProfiles)
+			}
 		}
 
 	case loginResultMsg:
@@ -219,6 +237,16 @@ func (v *LoginView) View() string {
 	}
 	b.WriteString(toggleStyle.Render(toggleText) + "\n")
 
+	// Server, when there are profiles to switch between
+	if len(v.config.Profiles) > 0 {
+		server := v.config.ServerURL
+		if v.config.Profile != "" {
+			server = v.config.Profile + " (" + server + ")"
+		}
+		b.WriteString("\n" + styles.MutedText.Render("Server: "+server) + "\n")
+		b.WriteString(styles.Help.Render("ctrl+p switch server") + "\n")
+	}
+
 	// Error message
 	if v.err != nil {
 		b.WriteString("\n" + styles.ErrorStyle.Render(v.err.Error()))
@@ -0,0 +1,132 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// ProfilesView lists the config's server profiles and switches between them
+type ProfilesView struct {
+	config *config.Config
+
+	profiles []config.Profile
+	cursor   int
+
+	width  int
+	height int
+}
+
+// NewProfilesView creates a new server profile picker
+func NewProfilesView(cfg *config.Config) *ProfilesView {
+	return &ProfilesView{
+		config: cfg,
+		width:  80,
+		height: 24,
+	}
+}
+
+// Init implements View
+func (v *ProfilesView) Init() tea.Cmd {
+	v.profiles = v.config.Profiles
+	v.cursor = 0
+	for i, profile := range v.profiles {
+		if profile.Name == v.config.Profile {
+			v.cursor = i
+		}
+	}
+	return nil
+}
+
+// Update implements View
+func (v *ProfilesView) Update(msg tea.Msg) (View, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if v.cursor < len(v.profiles)-1 {
+			v.cursor++
+		}
+	case "k", "up":
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case "g", "home":
+		v.cursor = 0
+	case "G", "end":
+		v.cursor = max(0, len(v.profiles)-1)
+	case "enter":
+		if v.cursor < len(v.profiles) {
+			name := v.profiles[v.cursor].Name
+			return v, func() tea.Msg { return SwitchProfileMsg{Name: name} }
+		}
+	case "esc":
+		if v.config.IsAuthenticated() {
+			return v, SwitchTo(ViewLibrary)
+		}
+		return v, SwitchTo(ViewLogin)
+	}
+	return v, nil
+}
+
+// View implements View
+func (v *ProfilesView) View() string {
+	count := fmt.Sprintf("%d profiles", len(v.profiles))
+	header := styles.HeaderContent("Servers", count, v.width)
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the profile list
+func (v *ProfilesView) renderContent() string {
+	if len(v.profiles) == 0 {
+		msg := `No profiles yet. Add them to "profiles" in the config, e.g.` + "\n" +
+			`[{"name": "home", "url": "http://nas:8080"}]`
+		return styles.RenderCenteredContent(styles.MutedText.Render(msg), v.width, styles.ContentHeight(v.height))
+	}
+
+	const nameWidth = 16
+	urlWidth := max(10, v.width-nameWidth-30)
+
+	var b strings.Builder
+	for i, profile := range v.profiles {
+		marker := "  "
+		if profile.Name == v.config.Profile {
+			marker = "● "
+		}
+		login := "not logged in"
+		if profile.Name == v.config.Profile && v.config.IsAuthenticated() {
+			login = "as " + v.config.Username
+		} else if profile.Token != "" {
+			login = "as " + profile.Username
+		}
+		line := marker + padRight(profile.Name, nameWidth) + padRight(profile.URL, urlWidth) + "  " + login
+
+		if i == v.cursor {
+			b.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
+		} else {
+			b.WriteString(styles.ListItem.Render("  "+line) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderFooter renders the footer help content
+func (v *ProfilesView) renderFooter() string {
+	help := []string{
+		styles.HelpKey.Render("j/k") + styles.Help.Render(" nav"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" switch"),
+		styles.HelpKey.Render("esc") + styles.Help.Render(" back"),
+	}
+	return strings.Join(help, "  ")
+}
+
+// SetSize implements View
+func (v *ProfilesView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
@@ -1,9 +1,13 @@
 package views
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
@@ -23,9 +27,34 @@ type ReaderView struct {
 	chapter  int
 
 	// Content
-	content    string
-	lines      []string
-	lineOffset int
+	content        string
+	lines          []string
+	lineOffset     int
+	paragraphLines []int // First wrapped line of each content line (split on "\n")
+
+	// Internal links (only for chapters delivered as HTML)
+	links         []chapterLink
+	anchors       map[string]int // Element id -> content line index
+	showLinks     bool
+	linkCursor    int
+	linkInput     string // Link number typed in the links overlay
+	pendingAnchor string // Anchor to scroll to after the next chapter loads
+
+	// Hideable header/footer
+	chromeHidden bool
+	chromePeek   bool // Chrome temporarily shown after navigation
+	chromePeekID int  // Ignores peek timers superseded by a later navigation
+
+	// Zen mode: no chrome, a narrow centered column, other paragraphs dimmed
+	zenMode bool
+
+	// Reading session for the history log
+	sessionStart        time.Time
+	sessionStartChapter int // -1 until the first chapter loads
+
+	// Jump list (ctrl+o / ctrl+i)
+	jumps     []jumpEntry
+	jumpIndex int // Position in jumps; len(jumps) when not navigating history
 
 	// State
 	loading         bool
@@ -37,9 +66,12 @@ type ReaderView struct {
 	hasPendingPos   bool    // Whether there's a pending position to restore
 
 	// Bookmarks
-	showBookmarks   bool
-	bookmarkCursor  int
-	bookmarkMsg     string // Temporary status message for bookmarks
+	showBookmarks     bool
+	bookmarkCursor    int
+	statusMsg         string // Temporary status message shown in the footer
+	bookmarkSort      bookmarkSort
+	bookmarkRenaming  bool
+	bookmarkNameInput textinput.Model
 
 	// Search
 	searchMode    bool          // Whether we're in search input mode
@@ -51,8 +83,15 @@ type ReaderView struct {
 	// Continuous scroll mode
 	continuousMode    bool              // Whether continuous scroll is enabled
 	allChapterContent []string          // All chapters combined (in continuous mode)
+	loadedChapters    []chapterContent  // Chapters allChapterContent was built from, for rewrapping
 	chapterBoundaries []chapterBoundary // Track where each chapter starts in continuous content
 
+	// Chapter being read in pieces (see reader_stream.go)
+	streaming    bool               // Part of the chapter is shown, the rest still arriving
+	streamSeq    int                // Ignores pieces of abandoned chapters
+	streamCancel context.CancelFunc // Stops the chapter being read
+	streamText   string
+
 	// Dimensions
 	width  int
 	height int
@@ -64,6 +103,24 @@ type chapterBoundary struct {
 	lineStart    int // First line of this chapter in allChapterContent
 }
 
+// bookmarkSort is the ordering used by the bookmarks overlay
+type bookmarkSort int
+
+const (
+	bookmarkSortChapter bookmarkSort = iota // Grouped by chapter, then position
+	bookmarkSortDate                        // Newest first
+	bookmarkSortCount
+)
+
+func (s bookmarkSort) String() string {
+	switch s {
+	case bookmarkSortDate:
+		return "date"
+	default:
+		return "chapter"
+	}
+}
+
 // searchMatch represents a single search match location
 type searchMatch struct {
 	lineIndex   int // Line number in wrapped content
@@ -73,17 +130,24 @@ type searchMatch struct {
 
 // NewReaderView creates a new reader view
 func NewReaderView(client *api.Client, cfg *config.Config) *ReaderView {
+	nameInput := newTextInput()
+	nameInput.Placeholder = "Bookmark name..."
+	nameInput.CharLimit = 60
+	nameInput.Width = 30
+
 	return &ReaderView{
-		client:    client,
-		config:    cfg,
-		textScale: cfg.GetTextScale(),
-		width:     80,
-		height:    24,
+		client:            client,
+		config:            cfg,
+		textScale:         cfg.GetTextScale(),
+		bookmarkNameInput: nameInput,
+		width:             80,
+		height:            24,
 	}
 }
 
 // SetBook sets the current book to read
 func (v *ReaderView) SetBook(book models.Book) {
+	v.StopLoading()
 	v.book = &book
 	v.chapter = 0
 	v.lineOffset = 0
@@ -93,11 +157,63 @@ func (v *ReaderView) SetBook(book models.Book) {
 	v.showTOC = false
 	v.pendingPosition = 0
 	v.hasPendingPos = false
+	v.links = nil
+	v.anchors = nil
+	v.showLinks = false
+	v.pendingAnchor = ""
+	v.jumps = nil
+	v.jumpIndex = 0
+	v.allChapterContent = nil
+	v.loadedChapters = nil
+	v.chapterBoundaries = nil
+	if v.config != nil {
+		v.textScale = v.config.BookTextScale(book.ID)
+		v.continuousMode = v.config.BookContinuous(book.ID)
+	}
+	v.sessionStart = time.Now()
+	v.sessionStartChapter = -1
+}
+
+// ResumeSession starts a new reading session in the open book, e.g. after
+// logging in again; it counts from the chapter loaded next
+func (v *ReaderView) ResumeSession() {
+	v.sessionStart = time.Now()
+	v.sessionStartChapter = -1
+}
+
+// minSessionDuration is the shortest visit recorded in the reading history
+const minSessionDuration = 5 * time.Second
+
+// EndSession records the current reading session in the history log
+func (v *ReaderView) EndSession() {
+	if v.book == nil || v.config == nil || v.sessionStart.IsZero() || v.sessionStartChapter < 0 {
+		return
+	}
+	session := config.ReadingSession{
+		BookID:       v.book.ID,
+		Title:        v.book.Title,
+		StartedAt:    v.sessionStart,
+		EndedAt:      time.Now(),
+		StartChapter: v.sessionStartChapter,
+		EndChapter:   v.getCurrentChapterFromLine(v.lineOffset),
+	}
+	v.sessionStart = time.Time{}
+	if session.Duration() >= minSessionDuration {
+		_ = v.config.AddReadingSession(session)
+	}
 }
 
 // SavePositionOnExit saves the current position (called when leaving reader)
-func (v *ReaderView) SavePositionOnExit() {
-	v.savePosition()
+func (v *ReaderView) SavePositionOnExit() error {
+	if v.book == nil {
+		return nil
+	}
+	chapter, position := v.currentPosition()
+	if v.config != nil && len(v.chapters) > 0 {
+		fraction := (float64(v.chapter) + float64(v.calculateProgress())/100) / float64(len(v.chapters))
+		_ = v.config.SetProgress(v.book.ID, fraction)
+	}
+	return v.client.SavePosition(v.book.ID, chapter, position)
 }
 
 // Message types
@@ -107,9 +223,10 @@ type tocLoadedMsg struct {
 }
 
 type chapterLoadedMsg struct {
-	content string
-	chapter int
-	err     error
+	content     string
+	contentType string
+	chapter     int
+	err         error
 }
 
 type positionLoadedMsg struct {
@@ -117,6 +234,22 @@ type positionLoadedMsg struct {
 	err      error
 }
 
+// positionSavedMsg is sent when a save-position request completes
+type positionSavedMsg struct {
+	err error
+}
+
+// clearStatusMsg clears the transient footer status message
+type clearStatusMsg struct{}
+
+// chromePeekEndMsg hides chrome again after it was shown briefly during navigation
+type chromePeekEndMsg struct {
+	id int
+}
+
+// chromePeekDuration is how long hidden chrome reappears after navigating
+const chromePeekDuration = 2 * time.Second
+
 // allChaptersLoadedMsg is sent when all chapters are loaded for continuous mode
 type allChaptersLoadedMsg struct {
 	chapters []chapterContent
@@ -146,7 +279,7 @@ func (v *ReaderView) Init() tea.Cmd {
 func (v *ReaderView) Update(msg tea.Msg) (View, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		v.bookmarkMsg = "" // Clear transient messages on any key
+		v.statusMsg = "" // Clear transient messages on any key
 		return v.handleKeyMsg(msg)
 	case tocLoadedMsg:
 		return v.handleTOCLoaded(msg)
@@ -154,8 +287,18 @@ func (v *ReaderView) Update(msg tea.Msg) (View, tea.Cmd) {
 		return v.handlePositionLoaded(msg)
 	case chapterLoadedMsg:
 		return v.handleChapterLoaded(msg)
+	case chapterPartMsg:
+		return v.handleChapterPart(msg)
 	case allChaptersLoadedMsg:
 		return v.handleAllChaptersLoaded(msg)
+	case positionSavedMsg:
+		return v.handlePositionSaved(msg)
+	case clearStatusMsg:
+		v.statusMsg = ""
+	case chromePeekEndMsg:
+		if msg.id == v.chromePeekID {
+			v.chromePeek = false
+		}
 	}
 	return v, nil
 }
@@ -168,6 +311,9 @@ func (v *ReaderView) handleKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 	if v.showBookmarks {
 		return v.updateBookmarks(msg)
 	}
+	if v.showLinks {
+		return v.updateLinks(msg)
+	}
 	if v.searchMode {
 		return v.updateSearchInput(msg)
 	}
@@ -209,7 +355,7 @@ func (v *ReaderView) handleReaderKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 	case "-", "_":
 		v.adjustTextScale(-config.TextScaleStep)
 	case "0":
-		v.setTextScale(config.DefaultTextScale)
+		v.setTextScale(v.config.GetTextScale())
 	case "B":
 		v.addBookmark()
 	case "b":
@@ -228,6 +374,21 @@ func (v *ReaderView) handleReaderKeyMsg(msg tea.KeyMsg) (View, tea.Cmd) {
 		}
 	case "c":
 		return v, v.toggleContinuousMode()
+	case "H":
+		v.chromeHidden = !v.chromeHidden
+		v.chromePeek = false
+	case "Z":
+		return v, v.toggleZenMode()
+	case "ctrl+o":
+		return v, v.jumpBack()
+	case "ctrl+i", "tab":
+		return v, v.jumpForward()
+	case "f":
+		if !v.continuousMode {
+			v.showLinks = true
+			v.linkCursor = 0
+			v.linkInput = ""
+		}
 	}
 	return v, nil
 }
@@ -280,13 +441,42 @@ func (v *ReaderView) handleChapterLoaded(msg chapterLoadedMsg) (View, tea.Cmd) {
 		return v, nil
 	}
 	v.content = msg.content
+	v.links = nil
+	v.anchors = nil
+	if isHTMLContent(msg.contentType) {
+		v.content, v.links, v.anchors = parseChapterHTML(msg.content)
+	}
 	v.chapter = msg.chapter
+	if v.sessionStartChapter < 0 {
+		v.sessionStartChapter = msg.chapter
+	}
 	v.wrapContent()
 	v.err = nil
 	v.restorePendingPosition()
+	if v.pendingAnchor != "" {
+		v.scrollToAnchor(v.pendingAnchor)
+		v.pendingAnchor = ""
+	}
+	if v.continuousMode && v.allChapterContent == nil {
+		// The book opens in continuous mode, starting from the resumed chapter
+		v.loading = true
+		return v, v.loadAllChapters()
+	}
 	return v, nil
 }
 
+// handlePositionSaved shows a transient confirmation, or an error if saving failed
+func (v *ReaderView) handlePositionSaved(msg positionSavedMsg) (View, tea.Cmd) {
+	if msg.err != nil {
+		return v, tea.Batch(
+			SendError(fmt.Errorf("failed to save position: %w", msg.err)),
+			tea.Tick(5*time.Second, func(time.Time) tea.Msg { return ClearErrorMsg{} }),
+		)
+	}
+	v.statusMsg = "Position saved"
+	return v, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })
+}
+
 // restorePendingPosition restores saved position after chapter loads
 func (v *ReaderView) restorePendingPosition() {
 	if !v.hasPendingPos || len(v.lines) == 0 {
@@ -337,6 +527,7 @@ func (v *ReaderView) updateTOC(msg tea.KeyMsg) (View, tea.Cmd) {
 		v.tocCursor = len(v.chapters) - 1
 	case "enter":
 		v.showTOC = false
+		v.recordJump()
 		return v, v.goToChapter(v.tocCursor)
 	}
 	return v, nil
@@ -356,38 +547,74 @@ func (v *ReaderView) View() string {
 		return v.renderBookmarks()
 	}
 
-	var b strings.Builder
+	if v.showLinks {
+		return v.renderLinks()
+	}
+
+	if v.zenMode {
+		return v.renderZen()
+	}
 
-	// Header
-	b.WriteString(v.renderHeader() + "\n")
+	if !v.chromeVisible() {
+		return styles.RenderChromeless(v.renderContent(), v.width, v.height)
+	}
+
+	// Footer or search input
+	footer := v.renderFooter()
+	if v.searchMode {
+		footer = v.renderSearchInput()
+	}
+
+	return styles.RenderLayout(v.renderHeader(), v.renderContent(), footer, v.width, v.height)
+}
 
+// chromeVisible returns true if the header and footer should be drawn
+func (v *ReaderView) chromeVisible() bool {
+	if v.zenMode {
+		return false
+	}
+	return !v.chromeHidden || v.chromePeek || v.searchMode || v.statusMsg != ""
+}
+
+// contentHeight returns the lines available for content with or without chrome
+func (v *ReaderView) contentHeight() int {
+	if v.chromeVisible() {
+		return styles.ContentHeight(v.height)
+	}
+	height := styles.ChromelessContentHeight(v.height)
+	if v.zenMode && v.zenStatusLine() != "" {
+		height--
+	}
+	return max(1, height)
+}
+
+// peekChrome briefly shows hidden chrome so the new position is visible
+func (v *ReaderView) peekChrome() tea.Cmd {
+	if !v.chromeHidden {
+		return nil
+	}
+	v.chromePeek = true
+	v.chromePeekID++
+	id := v.chromePeekID
+	return tea.Tick(chromePeekDuration, func(time.Time) tea.Msg {
+		return chromePeekEndMsg{id: id}
+	})
+}
+
+// renderContent renders the visible chapter lines or the loading/error state
+func (v *ReaderView) renderContent() string {
 	// Loading state
 	if v.loading {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.MutedText.Render("Loading..."),
-		)
-		b.WriteString(content)
-		return b.String()
+		return styles.RenderCenteredContent(styles.MutedText.Render("Loading..."), v.width, v.contentHeight())
 	}
 
 	// Error state
 	if v.err != nil {
-		content := lipgloss.Place(
-			v.width,
-			v.height-4,
-			lipgloss.Center,
-			lipgloss.Center,
-			styles.ErrorStyle.Render("Error: "+v.err.Error()),
-		)
-		b.WriteString(content)
-		return b.String()
+		return styles.RenderCenteredContent(styles.ErrorStyle.Render("Error: "+v.err.Error()), v.width, v.contentHeight())
 	}
 
 	// Content
+	var lines []string
 	visibleLines := v.visibleLines()
 	for i := v.lineOffset; i < min(v.lineOffset+visibleLines, len(v.lines)); i++ {
 		line := v.lines[i]
@@ -395,18 +622,9 @@ func (v *ReaderView) View() string {
 		if v.searchActive && len(v.searchMatches) > 0 {
 			line = v.highlightLine(i, line)
 		}
-		b.WriteString(styles.ReaderContent.Render(line) + "\n")
-	}
-
-	// Footer or search input
-	b.WriteString("\n")
-	if v.searchMode {
-		b.WriteString(v.renderSearchInput())
-	} else {
-		b.WriteString(v.renderFooter())
+		lines = append(lines, line)
 	}
-
-	return b.String()
+	return styles.ReaderContent.Render(strings.Join(lines, "\n"))
 }
 
 // SetSize implements View
@@ -414,11 +632,16 @@ func (v *ReaderView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
 	if v.content != "" {
-		v.wrapContent()
+		v.rewrapPreservingPosition()
 	}
 }
 
-// renderHeader renders the reader header with proper truncation
+// CapturingKeys implements KeyCapturer: overlays and the search prompt handle q and esc themselves
+func (v *ReaderView) CapturingKeys() bool {
+	return v.showTOC || v.showBookmarks || v.showLinks || v.searchMode
+}
+
+// renderHeader renders the reader header content with proper truncation
 func (v *ReaderView) renderHeader() string {
 	// Book title (truncated to 1/3 of width, unicode-safe)
 	maxTitleWidth := v.width / 3
@@ -426,7 +649,6 @@ func (v *ReaderView) renderHeader() string {
 		maxTitleWidth = 10
 	}
 	title := styles.TruncateText(v.book.Title, maxTitleWidth)
-	titlePart := styles.ReaderHeader.Render(" " + title + " ")
 
 	// Get current chapter (different logic for continuous mode)
 	currentChapter := v.chapter
@@ -439,7 +661,7 @@ func (v *ReaderView) renderHeader() string {
 	if len(v.chapters) > currentChapter && currentChapter >= 0 {
 		chapterTitle = styles.TruncateText(v.chapters[currentChapter].Title, 20)
 	}
-	chapterPart := styles.Help.Render(fmt.Sprintf(" Ch %d/%d: %s ", currentChapter+1, len(v.chapters), chapterTitle))
+	chapterPart := fmt.Sprintf("  Ch %d/%d: %s", currentChapter+1, len(v.chapters), chapterTitle)
 
 	// Chapter progress (within current chapter)
 	chapterProgress := v.calculateProgress()
@@ -452,20 +674,9 @@ func (v *ReaderView) renderHeader() string {
 	chapterBar := renderProgressBar(barWidth, float64(chapterProgress)/100.0)
 	bookBar := renderProgressBar(barWidth, float64(bookProgress)/100.0)
 
-	progressPart := styles.MutedText.Render("Ch:") + chapterBar +
-		styles.MutedText.Render(" Book:") + bookBar +
-		styles.ReaderProgress.Render(fmt.Sprintf(" %d%%", bookProgress))
-
-	// Combine
-	left := titlePart + chapterPart
-	right := progressPart
+	progressPart := fmt.Sprintf("Ch:%s Book:%s %d%%", chapterBar, bookBar, bookProgress)
 
-	gap := v.width - lipgloss.Width(left) - lipgloss.Width(right)
-	if gap < 0 {
-		gap = 0
-	}
-
-	return left + strings.Repeat(" ", gap) + right
+	return styles.HeaderContent(title+chapterPart, progressPart, v.width)
 }
 
 // calculateBookProgress returns overall book progress as percentage
@@ -534,14 +745,17 @@ func renderProgressBar(width int, progress float64) string {
 	return bar.String()
 }
 
-// renderFooter renders the reader footer with consistent styling
+// renderFooter renders the reader footer content
 func (v *ReaderView) renderFooter() string {
 	// Text scale indicator
 	scaleStr := fmt.Sprintf("%.0f%%", v.textScale*100)
 
-	// Show bookmark message if set
-	if v.bookmarkMsg != "" {
-		return styles.FooterBar.Width(v.width).Render(styles.SecondaryText.Render(v.bookmarkMsg))
+	// Show status message if set
+	if v.statusMsg != "" {
+		return styles.SecondaryText.Render(v.statusMsg)
+	}
+	if v.streaming {
+		return styles.MutedText.Render("Loading the rest of the chapter...")
 	}
 
 	// Show search status if search is active
@@ -557,8 +771,7 @@ func (v *ReaderView) renderFooter() string {
 			styles.HelpKey.Render("n/N") + styles.Help.Render(" next/prev"),
 			styles.HelpKey.Render("esc") + styles.Help.Render(" clear"),
 		}
-		content := styles.BookAuthor.Render(searchStatus) + matchInfo + "  " + strings.Join(help, "  ")
-		return styles.FooterBar.Width(v.width).Render(content)
+		return styles.BookAuthor.Render(searchStatus) + matchInfo + "  " + strings.Join(help, "  ")
 	}
 
 	// Mode indicator
@@ -572,11 +785,16 @@ func (v *ReaderView) renderFooter() string {
 		styles.HelpKey.Render("t") + styles.Help.Render(" toc"),
 		styles.HelpKey.Render("/") + styles.Help.Render(" find"),
 		styles.HelpKey.Render("b/B") + styles.Help.Render(" marks"),
-		styles.HelpKey.Render("c") + styles.Help.Render(" " + modeStr),
-		styles.HelpKey.Render("+/-") + styles.Help.Render(" " + scaleStr),
-		styles.HelpKey.Render("q") + styles.Help.Render(" back"),
 	}
-	return styles.FooterBar.Width(v.width).Render(strings.Join(help, "  "))
+	if len(v.links) > 0 && !v.continuousMode {
+		help = append(help, styles.HelpKey.Render("f")+styles.Help.Render(fmt.Sprintf(" links (%d)", len(v.links))))
+	}
+	help = append(help,
+		styles.HelpKey.Render("c")+styles.Help.Render(" "+modeStr),
+		styles.HelpKey.Render("+/-")+styles.Help.Render(" "+scaleStr),
+		styles.HelpKey.Render("q")+styles.Help.Render(" back"),
+	)
+	return strings.Join(help, "  ")
 }
 
 // renderSearchInput renders the search input bar
@@ -623,10 +841,10 @@ func (v *ReaderView) highlightLine(lineIdx int, line string) string {
 		matchText := line[m.startOffset:m.endOffset]
 		if isCurrentMatch {
 			// Current match - more prominent highlight
-			result.WriteString(lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0")).Bold(true).Render(matchText))
+			result.WriteString(styles.SearchMatchCurrent.Render(matchText))
 		} else {
 			// Other matches - subtle highlight
-			result.WriteString(lipgloss.NewStyle().Background(lipgloss.Color("8")).Foreground(lipgloss.Color("15")).Render(matchText))
+			result.WriteString(styles.SearchMatch.Render(matchText))
 		}
 		lastEnd = m.endOffset
 	}
@@ -681,9 +899,8 @@ func (v *ReaderView) renderTOC() string {
 	)
 }
 
-// wrapContent wraps content to fit the terminal width
-func (v *ReaderView) wrapContent() {
-	v.lines = nil
+// wrapWidth returns the maximum line length for the terminal width, text scale and zen mode
+func (v *ReaderView) wrapWidth() int {
 	// Apply text scale to width: larger scale = narrower lines (simulates bigger text)
 	// Scale of 1.0 = full width, 2.0 = half width, 0.5 = full width (capped)
 	baseWidth := v.width - 4 // Account for padding
@@ -694,9 +911,20 @@ func (v *ReaderView) wrapContent() {
 	if scaledWidth > baseWidth {
 		scaledWidth = baseWidth
 	}
-	maxWidth := scaledWidth
+	if v.zenMode && scaledWidth > zenColumnWidth {
+		scaledWidth = zenColumnWidth
+	}
+	return scaledWidth
+}
+
+// wrapContent wraps content to fit the terminal width
+func (v *ReaderView) wrapContent() {
+	v.lines = nil
+	v.paragraphLines = nil
+	maxWidth := v.wrapWidth()
 
 	for _, paragraph := range strings.Split(v.content, "\n") {
+		v.paragraphLines = append(v.paragraphLines, len(v.lines))
 		if paragraph == "" {
 			v.lines = append(v.lines, "")
 			continue
@@ -728,6 +956,60 @@ func (v *ReaderView) wrapContent() {
 	}
 }
 
+// rewrapPreservingPosition re-wraps content, keeping the first visible word at the top.
+// lineOffset refers to the old wrapping, so it is translated to a paragraph and
+// word index first and mapped back onto the new lines afterwards. In continuous
+// mode the whole book is rewrapped, and paragraphs are counted across it.
+func (v *ReaderView) rewrapPreservingPosition() {
+	wrap := v.wrapContent
+	if v.continuousMode && v.allChapterContent != nil {
+		wrap = v.wrapContinuous
+	}
+	if len(v.paragraphLines) == 0 {
+		wrap()
+		return
+	}
+	paragraph, word := v.topWord()
+	wrap()
+	v.scrollToWord(paragraph, word)
+}
+
+// topWord returns the paragraph index and word index within it at the top of the view
+func (v *ReaderView) topWord() (int, int) {
+	paragraph := 0
+	for i, start := range v.paragraphLines {
+		if start > v.lineOffset {
+			break
+		}
+		paragraph = i
+	}
+	word := 0
+	for i := v.paragraphLines[paragraph]; i < v.lineOffset && i < len(v.lines); i++ {
+		word += len(strings.Fields(v.lines[i]))
+	}
+	return paragraph, word
+}
+
+// scrollToWord scrolls so the line containing a paragraph's word is at the top
+func (v *ReaderView) scrollToWord(paragraph, word int) {
+	if paragraph >= len(v.paragraphLines) {
+		return
+	}
+	end := len(v.lines)
+	if paragraph+1 < len(v.paragraphLines) {
+		end = v.paragraphLines[paragraph+1]
+	}
+	line := v.paragraphLines[paragraph]
+	for seen := 0; line < end-1; line++ {
+		seen += len(strings.Fields(v.lines[line]))
+		if seen > word {
+			break
+		}
+	}
+	v.lineOffset = 0
+	v.scroll(line)
+}
+
 // scroll scrolls the content by delta lines
 func (v *ReaderView) scroll(delta int) {
 	v.lineOffset += delta
@@ -745,7 +1027,7 @@ func (v *ReaderView) scroll(delta int) {
 
 // visibleLines returns the number of visible content lines
 func (v *ReaderView) visibleLines() int {
-	lines := v.height - 5 // Header, footer, margins
+	lines := v.contentHeight() - styles.ReaderContent.GetVerticalPadding()
 	if lines < 1 {
 		lines = 1
 	}
@@ -775,18 +1057,6 @@ func (v *ReaderView) loadTOC() tea.Cmd {
 	}
 }
 
-// loadChapter loads a chapter's content
-func (v *ReaderView) loadChapter(chapter int) tea.Cmd {
-	v.loading = true
-	return func() tea.Msg {
-		content, err := v.client.GetChapterText(v.book.ID, chapter)
-		if err != nil {
-			return chapterLoadedMsg{err: err, chapter: chapter}
-		}
-		return chapterLoadedMsg{content: content.Content, chapter: chapter}
-	}
-}
-
 // loadPosition loads saved reading position
 func (v *ReaderView) loadPosition() tea.Cmd {
 	return func() tea.Msg {
@@ -797,19 +1067,29 @@ func (v *ReaderView) loadPosition() tea.Cmd {
 
 // goToChapter navigates to a specific chapter
 func (v *ReaderView) goToChapter(chapter int) tea.Cmd {
-	v.lineOffset = 0
 	// Save current position before leaving
-	go v.savePosition()
-	return v.loadChapter(chapter)
+	saveCmd := v.savePositionCmd()
+	v.lineOffset = 0
+	return tea.Batch(saveCmd, v.loadChapter(chapter), v.peekChrome())
 }
 
-// savePosition saves the current reading position
-func (v *ReaderView) savePosition() {
+// savePositionCmd returns a command that saves the current reading position.
+// The position is captured immediately so later navigation can't change it.
+func (v *ReaderView) savePositionCmd() tea.Cmd {
 	if v.book == nil {
-		return
+		return nil
+	}
+	bookID := v.book.ID
+	chapter, position := v.currentPosition()
+	return func() tea.Msg {
+		return positionSavedMsg{err: v.client.SavePosition(bookID, chapter, position)}
 	}
+}
+
+// currentPosition returns the chapter and fractional position to persist
+func (v *ReaderView) currentPosition() (string, float64) {
 	position := float64(v.lineOffset) / float64(max(1, len(v.lines)))
-	v.client.SavePosition(v.book.ID, fmt.Sprintf("%d", v.chapter), position)
+	return fmt.Sprintf("%d", v.chapter), position
 }
 
 // adjustTextScale changes text scale by delta
@@ -829,13 +1109,13 @@ func (v *ReaderView) setTextScale(scale float64) {
 		return
 	}
 	v.textScale = scale
-	// Save to config
-	if v.config != nil {
-		_ = v.config.SetTextScale(scale)
+	// Remember for this book
+	if v.config != nil && v.book != nil {
+		_ = v.config.SetBookTextScale(v.book.ID, scale)
 	}
 	// Rewrap content with new scale
 	if v.content != "" {
-		v.wrapContent()
+		v.rewrapPreservingPosition()
 	}
 }
 
@@ -851,9 +1131,9 @@ func (v *ReaderView) addBookmark() {
 	position := float64(v.lineOffset) / float64(max(1, len(v.lines)))
 	err := v.config.AddBookmark(v.book.ID, v.book.Title, v.chapter, chapterTitle, position, "")
 	if err != nil {
-		v.bookmarkMsg = "Failed to add bookmark"
+		v.statusMsg = "Failed to add bookmark"
 	} else {
-		v.bookmarkMsg = "Bookmark added"
+		v.statusMsg = "Bookmark added"
 	}
 }
 
@@ -861,6 +1141,10 @@ func (v *ReaderView) addBookmark() {
 func (v *ReaderView) updateBookmarks(msg tea.KeyMsg) (View, tea.Cmd) {
 	bookmarks := v.getBookmarksForCurrentBook()
 
+	if v.bookmarkRenaming {
+		return v.updateBookmarkRename(msg, bookmarks)
+	}
+
 	switch msg.String() {
 	case "esc", "b", "q":
 		v.showBookmarks = false
@@ -882,8 +1166,30 @@ func (v *ReaderView) updateBookmarks(msg tea.KeyMsg) (View, tea.Cmd) {
 		// Navigate to selected bookmark
 		if v.bookmarkCursor < len(bookmarks) {
 			v.showBookmarks = false
+			v.recordJump()
 			return v, v.goToBookmark(bookmarks[v.bookmarkCursor])
 		}
+	case "s":
+		// Toggle sort order, keeping the selected bookmark under the cursor
+		selectedID := ""
+		if v.bookmarkCursor < len(bookmarks) {
+			selectedID = bookmarks[v.bookmarkCursor].ID
+		}
+		v.bookmarkSort = (v.bookmarkSort + 1) % bookmarkSortCount
+		for i, bm := range v.getBookmarksForCurrentBook() {
+			if bm.ID == selectedID {
+				v.bookmarkCursor = i
+			}
+		}
+	case "r":
+		// Rename selected bookmark
+		if v.bookmarkCursor < len(bookmarks) && v.config != nil {
+			v.bookmarkRenaming = true
+			v.bookmarkNameInput.SetValue(bookmarks[v.bookmarkCursor].Name)
+			v.bookmarkNameInput.CursorEnd()
+			v.bookmarkNameInput.Focus()
+			return v, textinput.Blink
+		}
 	case "d", "x":
 		// Delete selected bookmark
 		if v.bookmarkCursor < len(bookmarks) && v.config != nil {
@@ -897,12 +1203,49 @@ func (v *ReaderView) updateBookmarks(msg tea.KeyMsg) (View, tea.Cmd) {
 	return v, nil
 }
 
-// getBookmarksForCurrentBook returns bookmarks for the current book
+// updateBookmarkRename handles input while renaming a bookmark
+func (v *ReaderView) updateBookmarkRename(msg tea.KeyMsg, bookmarks []config.Bookmark) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		v.bookmarkRenaming = false
+		v.bookmarkNameInput.Blur()
+		return v, nil
+	case "enter":
+		v.bookmarkRenaming = false
+		v.bookmarkNameInput.Blur()
+		if v.bookmarkCursor < len(bookmarks) {
+			name := strings.TrimSpace(v.bookmarkNameInput.Value())
+			if err := v.config.RenameBookmark(bookmarks[v.bookmarkCursor].ID, name); err != nil {
+				v.statusMsg = "Failed to rename bookmark"
+			}
+		}
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.bookmarkNameInput, cmd = v.bookmarkNameInput.Update(msg)
+	return v, cmd
+}
+
+// getBookmarksForCurrentBook returns bookmarks for the current book in the selected sort order
 func (v *ReaderView) getBookmarksForCurrentBook() []config.Bookmark {
 	if v.book == nil || v.config == nil {
 		return nil
 	}
-	return v.config.GetBookmarksForBook(v.book.ID)
+	bookmarks := v.config.GetBookmarksForBook(v.book.ID)
+	switch v.bookmarkSort {
+	case bookmarkSortChapter:
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			if bookmarks[i].Chapter != bookmarks[j].Chapter {
+				return bookmarks[i].Chapter < bookmarks[j].Chapter
+			}
+			return bookmarks[i].Position < bookmarks[j].Position
+		})
+	case bookmarkSortDate:
+		sort.SliceStable(bookmarks, func(i, j int) bool {
+			return bookmarks[i].CreatedAt.After(bookmarks[j].CreatedAt)
+		})
+	}
+	return bookmarks
 }
 
 // goToBookmark navigates to a bookmark
@@ -910,41 +1253,88 @@ func (v *ReaderView) goToBookmark(bookmark config.Bookmark) tea.Cmd {
 	// Store position to restore after chapter loads
 	v.pendingPosition = bookmark.Position
 	v.hasPendingPos = true
-	return v.loadChapter(bookmark.Chapter)
+	return tea.Batch(v.loadChapter(bookmark.Chapter), v.peekChrome())
+}
+
+// bookmarkRow is a line in the bookmarks overlay: a chapter header or a bookmark
+type bookmarkRow struct {
+	header string
+	index  int // Index into the sorted bookmarks (-1 for headers)
+}
+
+// bookmarkRows lays out bookmarks, adding chapter headers when sorted by chapter
+func (v *ReaderView) bookmarkRows(bookmarks []config.Bookmark) []bookmarkRow {
+	var rows []bookmarkRow
+	for i, bm := range bookmarks {
+		if v.bookmarkSort == bookmarkSortChapter && (i == 0 || bookmarks[i-1].Chapter != bm.Chapter) {
+			header := fmt.Sprintf("Chapter %d", bm.Chapter+1)
+			if bm.ChapterTitle != "" {
+				header += ": " + bm.ChapterTitle
+			}
+			rows = append(rows, bookmarkRow{header: header, index: -1})
+		}
+		rows = append(rows, bookmarkRow{index: i})
+	}
+	return rows
+}
+
+// bookmarkLabel returns the display text for a bookmark
+func (v *ReaderView) bookmarkLabel(bm config.Bookmark) string {
+	progress := fmt.Sprintf("%.0f%%", bm.Position*100)
+	if v.bookmarkSort == bookmarkSortChapter {
+		// Chapter is shown in the group header
+		if bm.Name != "" {
+			return fmt.Sprintf("%s [%s]", bm.Name, progress)
+		}
+		return progress
+	}
+
+	label := bm.Name
+	if label == "" {
+		label = fmt.Sprintf("Ch %d", bm.Chapter+1)
+		if bm.ChapterTitle != "" {
+			label = fmt.Sprintf("Ch %d: %s", bm.Chapter+1, styles.TruncateText(bm.ChapterTitle, 20))
+		}
+	}
+	return fmt.Sprintf("%s [%s] %s", label, progress, bm.CreatedAt.Format("Jan 2"))
 }
 
 // renderBookmarks renders the bookmarks overlay
 func (v *ReaderView) renderBookmarks() string {
 	var b strings.Builder
 
-	b.WriteString(styles.DialogTitle.Render("Bookmarks") + "\n\n")
+	b.WriteString(styles.DialogTitle.Render("Bookmarks") + styles.MutedText.Render(" by "+v.bookmarkSort.String()) + "\n\n")
 
 	bookmarks := v.getBookmarksForCurrentBook()
 
 	if len(bookmarks) == 0 {
 		b.WriteString(styles.MutedText.Render("No bookmarks for this book.\n\nPress B to add a bookmark."))
 	} else {
-		// Calculate visible range
+		rows := v.bookmarkRows(bookmarks)
+
+		// Calculate visible range around the selected row
+		cursorRow := 0
+		for i, row := range rows {
+			if row.index == v.bookmarkCursor {
+				cursorRow = i
+			}
+		}
 		maxVisible := v.height - 10
 		offset := 0
-		if v.bookmarkCursor >= maxVisible {
-			offset = v.bookmarkCursor - maxVisible + 1
+		if cursorRow >= maxVisible {
+			offset = cursorRow - maxVisible + 1
 		}
 
-		for i := offset; i < min(offset+maxVisible, len(bookmarks)); i++ {
-			bm := bookmarks[i]
-			chapterLabel := fmt.Sprintf("Ch %d", bm.Chapter+1)
-			if bm.ChapterTitle != "" {
-				title := bm.ChapterTitle
-				if len(title) > 20 {
-					title = title[:17] + "..."
-				}
-				chapterLabel = fmt.Sprintf("Ch %d: %s", bm.Chapter+1, title)
+		labelWidth := min(50, v.width-4) - 12
+		for i := offset; i < min(offset+maxVisible, len(rows)); i++ {
+			row := rows[i]
+			if row.index < 0 {
+				b.WriteString(styles.BookAuthor.Render(styles.TruncateText(row.header, labelWidth+4)) + "\n")
+				continue
 			}
-			progress := fmt.Sprintf("%.0f%%", bm.Position*100)
-			line := fmt.Sprintf("%s [%s]", chapterLabel, progress)
 
-			if i == v.bookmarkCursor {
+			line := styles.TruncateText(v.bookmarkLabel(bookmarks[row.index]), labelWidth)
+			if row.index == v.bookmarkCursor {
 				b.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
 			} else {
 				b.WriteString(styles.ListItem.Render("  "+line) + "\n")
@@ -952,7 +1342,12 @@ func (v *ReaderView) renderBookmarks() string {
 		}
 	}
 
-	b.WriteString("\n" + styles.Help.Render("j/k navigate • enter go • d delete • esc close"))
+	if v.bookmarkRenaming {
+		b.WriteString("\n" + styles.SecondaryText.Render("Name: ") + v.bookmarkNameInput.View())
+		b.WriteString("\n" + styles.Help.Render("enter save • esc cancel"))
+	} else {
+		b.WriteString("\n" + styles.Help.Render("j/k navigate • enter go • r rename • s sort • d delete • esc close"))
+	}
 
 	dialog := styles.Dialog.Width(min(50, v.width-4)).Render(b.String())
 
@@ -1064,6 +1459,11 @@ func (v *ReaderView) scrollToMatch(matchIdx int) {
 	match := v.searchMatches[matchIdx]
 	visibleLines := v.visibleLines()
 
+	// Only jumps that move the view are worth returning to
+	if match.lineIndex < v.lineOffset || match.lineIndex >= v.lineOffset+visibleLines {
+		v.recordJump()
+	}
+
 	// If match is above visible area, scroll up
 	if match.lineIndex < v.lineOffset {
 		v.lineOffset = match.lineIndex
@@ -1086,6 +1486,9 @@ func (v *ReaderView) clearSearch() {
 func (v *ReaderView) toggleContinuousMode() tea.Cmd {
 	v.continuousMode = !v.continuousMode
 	v.clearSearch() // Clear search when switching modes
+	if v.config != nil && v.book != nil {
+		_ = v.config.SetBookContinuous(v.book.ID, v.continuousMode)
+	}
 
 	if v.continuousMode {
 		// Switch to continuous mode - load all chapters
@@ -1100,25 +1503,29 @@ func (v *ReaderView) toggleContinuousMode() tea.Cmd {
 
 	// Clear continuous mode data
 	v.allChapterContent = nil
+	v.loadedChapters = nil
 	v.chapterBoundaries = nil
 
 	// Load the current chapter
 	return v.loadChapter(v.chapter)
 }
 
-// loadAllChapters loads content from all chapters for continuous mode
+// loadAllChapters loads content from all chapters for continuous mode, a few
+// at a time
 func (v *ReaderView) loadAllChapters() tea.Cmd {
+	bookID, count := v.book.ID, len(v.chapters)
 	return func() tea.Msg {
-		var chapters []chapterContent
-		for i := 0; i < len(v.chapters); i++ {
-			content, err := v.client.GetChapterText(v.book.ID, i)
+		chapters := make([]chapterContent, count)
+		err := api.ForEach(context.Background(), count, api.DefaultParallelism, func(ctx context.Context, i int) error {
+			content, err := v.client.GetChapterText(bookID, i)
 			if err != nil {
-				return allChaptersLoadedMsg{err: err}
+				return err
 			}
-			chapters = append(chapters, chapterContent{
-				index:   i,
-				content: content.Content,
-			})
+			chapters[i] = chapterContent{index: i, content: content.Content}
+			return nil
+		})
+		if err != nil {
+			return allChaptersLoadedMsg{err: err}
 		}
 		return allChaptersLoadedMsg{chapters: chapters}
 	}
@@ -1126,21 +1533,27 @@ func (v *ReaderView) loadAllChapters() tea.Cmd {
 
 // buildContinuousContent combines all chapters into a single scrollable view
 func (v *ReaderView) buildContinuousContent(chapters []chapterContent) {
+	v.loadedChapters = chapters
+	v.wrapContinuous()
+
+	// Try to maintain position in the current chapter
+	if v.chapter < len(v.chapterBoundaries) {
+		v.lineOffset = v.chapterBoundaries[v.chapter].lineStart
+	} else {
+		v.lineOffset = 0
+	}
+}
+
+// wrapContinuous wraps the loaded chapters, with a header before each, to fit
+// the terminal width
+func (v *ReaderView) wrapContinuous() {
 	v.allChapterContent = nil
 	v.chapterBoundaries = nil
 
-	// Apply text scale to width
-	baseWidth := v.width - 4
-	scaledWidth := int(float64(baseWidth) / v.textScale)
-	if scaledWidth < 20 {
-		scaledWidth = 20
-	}
-	if scaledWidth > baseWidth {
-		scaledWidth = baseWidth
-	}
-	maxWidth := scaledWidth
+	v.paragraphLines = nil
+	maxWidth := v.wrapWidth()
 
-	for _, ch := range chapters {
+	for _, ch := range v.loadedChapters {
 		// Record chapter boundary
 		v.chapterBoundaries = append(v.chapterBoundaries, chapterBoundary{
 			chapterIndex: ch.index,
@@ -1156,10 +1569,14 @@ func (v *ReaderView) buildContinuousContent(chapters []chapterContent) {
 			chapterTitle = fmt.Sprintf("Chapter %d", ch.index+1)
 		}
 		header := fmt.Sprintf("━━━ %s ━━━", chapterTitle)
-		v.allChapterContent = append(v.allChapterContent, "", header, "")
+		for _, line := range []string{"", header, ""} {
+			v.paragraphLines = append(v.paragraphLines, len(v.allChapterContent))
+			v.allChapterContent = append(v.allChapterContent, line)
+		}
 
 		// Wrap and add chapter content
 		for _, paragraph := range strings.Split(ch.content, "\n") {
+			v.paragraphLines = append(v.paragraphLines, len(v.allChapterContent))
 			if paragraph == "" {
 				v.allChapterContent = append(v.allChapterContent, "")
 				continue
@@ -1192,13 +1609,6 @@ func (v *ReaderView) buildContinuousContent(chapters []chapterContent) {
 
 	// Use continuous content as lines
 	v.lines = v.allChapterContent
-
-	// Try to maintain position in the current chapter
-	if v.chapter < len(v.chapterBoundaries) {
-		v.lineOffset = v.chapterBoundaries[v.chapter].lineStart
-	} else {
-		v.lineOffset = 0
-	}
 }
 
 // getCurrentChapterFromLine determines which chapter a line belongs to
@@ -0,0 +1,96 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumps limits how many positions the jump list remembers
+const maxJumps = 100
+
+// jumpEntry is a reading position recorded before a jump
+type jumpEntry struct {
+	chapter  int
+	position float64 // Fraction of the chapter (0-1)
+}
+
+// chapterPosition returns the current chapter and the fraction of it scrolled past.
+// In continuous mode the position is relative to the chapter under the cursor.
+func (v *ReaderView) chapterPosition() (int, float64) {
+	if !v.continuousMode || len(v.chapterBoundaries) == 0 {
+		return v.chapter, float64(v.lineOffset) / float64(max(1, len(v.lines)))
+	}
+	chapter := v.getCurrentChapterFromLine(v.lineOffset)
+	start, end := v.chapterLineRange(chapter)
+	return chapter, float64(v.lineOffset-start) / float64(max(1, end-start))
+}
+
+// chapterLineRange returns the first and past-the-end lines of a chapter in continuous mode
+func (v *ReaderView) chapterLineRange(chapter int) (int, int) {
+	for i, b := range v.chapterBoundaries {
+		if b.chapterIndex != chapter {
+			continue
+		}
+		end := len(v.lines)
+		if i+1 < len(v.chapterBoundaries) {
+			end = v.chapterBoundaries[i+1].lineStart
+		}
+		return b.lineStart, end
+	}
+	return 0, len(v.lines)
+}
+
+// recordJump saves the current position before a jump, dropping any forward history
+func (v *ReaderView) recordJump() {
+	if v.book == nil || len(v.lines) == 0 {
+		return
+	}
+	chapter, position := v.chapterPosition()
+	v.jumps = append(v.jumps[:v.jumpIndex], jumpEntry{chapter: chapter, position: position})
+	if len(v.jumps) > maxJumps {
+		v.jumps = v.jumps[len(v.jumps)-maxJumps:]
+	}
+	v.jumpIndex = len(v.jumps)
+}
+
+// jumpBack returns to the previous position in the jump list (ctrl+o)
+func (v *ReaderView) jumpBack() tea.Cmd {
+	if v.jumpIndex == 0 {
+		v.statusMsg = "Already at oldest jump"
+		return nil
+	}
+	// Remember where we are so ctrl+i can come back here
+	if v.jumpIndex == len(v.jumps) {
+		chapter, position := v.chapterPosition()
+		v.jumps = append(v.jumps, jumpEntry{chapter: chapter, position: position})
+	}
+	v.jumpIndex--
+	return v.goToJump(v.jumps[v.jumpIndex])
+}
+
+// jumpForward moves to the next position in the jump list (ctrl+i / tab)
+func (v *ReaderView) jumpForward() tea.Cmd {
+	if v.jumpIndex >= len(v.jumps)-1 {
+		v.statusMsg = "Already at newest jump"
+		return nil
+	}
+	v.jumpIndex++
+	return v.goToJump(v.jumps[v.jumpIndex])
+}
+
+// goToJump restores a jump list position, loading its chapter if needed
+func (v *ReaderView) goToJump(jump jumpEntry) tea.Cmd {
+	if v.continuousMode && len(v.chapterBoundaries) > 0 {
+		start, end := v.chapterLineRange(jump.chapter)
+		v.lineOffset = 0
+		v.scroll(start + int(jump.position*float64(end-start)))
+		return nil
+	}
+	if jump.chapter == v.chapter && len(v.lines) > 0 {
+		v.lineOffset = 0
+		v.scroll(int(jump.position * float64(len(v.lines))))
+		return nil
+	}
+	v.pendingPosition = jump.position
+	v.hasPendingPos = true
+	return v.goToChapter(jump.chapter)
+}
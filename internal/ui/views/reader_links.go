@@ -0,0 +1,295 @@
+package views
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// chapterLink is an internal link found in a chapter, shown as a numbered target
+type chapterLink struct {
+	number int    // 1-based number rendered after the link text
+	text   string // Link text
+	href   string // Target href (chapter file and/or #anchor)
+}
+
+// blockElements start a new paragraph when converting chapter HTML to text
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "section": true, "article": true, "aside": true,
+	"dt": true, "dd": true, "pre": true, "hr": true, "figure": true,
+}
+
+// skippedElements have content that is never displayed
+var skippedElements = map[string]bool{
+	"head": true, "title": true, "script": true, "style": true,
+}
+
+// isHTMLContent returns true if a chapter content type is (X)HTML markup
+func isHTMLContent(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "html")
+}
+
+// parseChapterHTML converts chapter markup to plain text paragraphs.
+// Internal links are numbered and marked as "text[n]", and element ids are
+// mapped to the index of the content line (split on "\n") they appear in.
+func parseChapterHTML(markup string) (string, []chapterLink, map[string]int) {
+	decoder := xml.NewDecoder(strings.NewReader(markup))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var (
+		lines     []string
+		paragraph strings.Builder
+		links     []chapterLink
+		anchors   = make(map[string]int)
+		skipDepth int
+		inLink    bool
+		linkHref  string
+		linkText  strings.Builder
+	)
+
+	flush := func() {
+		text := strings.Join(strings.Fields(paragraph.String()), " ")
+		paragraph.Reset()
+		if text == "" {
+			return
+		}
+		lines = append(lines, text, "")
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Malformed markup: keep whatever was converted so far
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if skippedElements[name] {
+				skipDepth++
+				continue
+			}
+			if blockElements[name] {
+				flush()
+			}
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "id" || (name == "a" && attr.Name.Local == "name") {
+					anchors[attr.Value] = len(lines)
+				}
+			}
+			if name == "a" {
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "href" && isInternalHref(attr.Value) {
+						inLink = true
+						linkHref = attr.Value
+						linkText.Reset()
+					}
+				}
+			}
+		case xml.EndElement:
+			name := strings.ToLower(t.Name.Local)
+			if skippedElements[name] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if name == "a" && inLink {
+				inLink = false
+				link := chapterLink{
+					number: len(links) + 1,
+					text:   strings.Join(strings.Fields(linkText.String()), " "),
+					href:   linkHref,
+				}
+				links = append(links, link)
+				paragraph.WriteString(fmt.Sprintf("[%d]", link.number))
+			}
+			if blockElements[name] {
+				flush()
+			}
+		case xml.CharData:
+			if skipDepth > 0 {
+				continue
+			}
+			paragraph.Write(t)
+			if inLink {
+				linkText.Write(t)
+			}
+		}
+	}
+	flush()
+
+	return strings.Join(lines, "\n"), links, anchors
+}
+
+// isInternalHref returns true for links that point inside the book
+func isInternalHref(href string) bool {
+	if href == "" {
+		return false
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" && u.Host == ""
+}
+
+// resolveLink maps a link href to a chapter index and anchor.
+// Returns false if the href does not match any chapter in the TOC.
+func (v *ReaderView) resolveLink(href string) (int, string, bool) {
+	target, anchor, _ := strings.Cut(href, "#")
+	if target == "" {
+		return v.chapter, anchor, true
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	base := path.Base(target)
+	for i, ch := range v.chapters {
+		chHref, _, _ := strings.Cut(ch.Href, "#")
+		if path.Base(chHref) == base {
+			return i, anchor, true
+		}
+	}
+	return 0, "", false
+}
+
+// followLink jumps to the chapter and anchor a link points at
+func (v *ReaderView) followLink(link chapterLink) tea.Cmd {
+	chapter, anchor, ok := v.resolveLink(link.href)
+	if !ok {
+		v.statusMsg = "Link target not found: " + link.href
+		return nil
+	}
+	v.recordJump()
+	if chapter == v.chapter {
+		if !v.scrollToAnchor(anchor) {
+			v.lineOffset = 0
+		}
+		return nil
+	}
+	v.pendingAnchor = anchor
+	return v.goToChapter(chapter)
+}
+
+// scrollToAnchor scrolls to the line containing an element id, returns false if unknown
+func (v *ReaderView) scrollToAnchor(anchor string) bool {
+	if anchor == "" {
+		return false
+	}
+	idx, ok := v.anchors[anchor]
+	if !ok || idx >= len(v.paragraphLines) {
+		return false
+	}
+	v.lineOffset = 0
+	v.scroll(v.paragraphLines[idx])
+	return true
+}
+
+// updateLinks handles the links list navigation
+func (v *ReaderView) updateLinks(msg tea.KeyMsg) (View, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "f", "q":
+		v.showLinks = false
+		v.linkInput = ""
+	case "j", "down":
+		if v.linkCursor < len(v.links)-1 {
+			v.linkCursor++
+		}
+		v.linkInput = ""
+	case "k", "up":
+		if v.linkCursor > 0 {
+			v.linkCursor--
+		}
+		v.linkInput = ""
+	case "g", "home":
+		v.linkCursor = 0
+	case "G", "end":
+		if len(v.links) > 0 {
+			v.linkCursor = len(v.links) - 1
+		}
+	case "backspace":
+		if len(v.linkInput) > 0 {
+			v.linkInput = v.linkInput[:len(v.linkInput)-1]
+		}
+	case "enter":
+		if v.linkCursor < len(v.links) {
+			v.showLinks = false
+			v.linkInput = ""
+			return v, v.followLink(v.links[v.linkCursor])
+		}
+	default:
+		// Typing a link number selects it
+		if key := msg.String(); len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+			v.linkInput += key
+			if n, err := strconv.Atoi(v.linkInput); err == nil && n >= 1 && n <= len(v.links) {
+				v.linkCursor = n - 1
+			}
+		}
+	}
+	return v, nil
+}
+
+// renderLinks renders the links overlay
+func (v *ReaderView) renderLinks() string {
+	var b strings.Builder
+
+	b.WriteString(styles.DialogTitle.Render("Links") + "\n\n")
+
+	if len(v.links) == 0 {
+		b.WriteString(styles.MutedText.Render("No links in this chapter."))
+	} else {
+		maxVisible := v.height - 10
+		offset := 0
+		if v.linkCursor >= maxVisible {
+			offset = v.linkCursor - maxVisible + 1
+		}
+
+		for i := offset; i < min(offset+maxVisible, len(v.links)); i++ {
+			link := v.links[i]
+			text := link.text
+			if text == "" {
+				text = link.href
+			}
+			line := fmt.Sprintf("[%d] %s", link.number, styles.TruncateText(text, 40))
+
+			if i == v.linkCursor {
+				b.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString(styles.ListItem.Render("  "+line) + "\n")
+			}
+		}
+	}
+
+	help := "j/k navigate • 0-9 number • enter follow • esc close"
+	if v.linkInput != "" {
+		help = "#" + v.linkInput + "  " + help
+	}
+	b.WriteString("\n" + styles.Help.Render(help))
+
+	dialog := styles.Dialog.Width(min(60, v.width-4)).Render(b.String())
+
+	return lipgloss.Place(
+		v.width,
+		v.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		dialog,
+	)
+}
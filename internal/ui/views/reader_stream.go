@@ -0,0 +1,110 @@
+package views
+
+import (
+	"context"
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+)
+
+// Chapters are read in pieces so a long one shows as soon as its start arrives.
+// Pieces double in size up to chapterMaxPart, limiting how often the growing
+// text is rewrapped.
+const (
+	chapterFirstPart = 32 << 10
+	chapterMaxPart   = 1 << 20
+)
+
+// chapterPartMsg carries the next piece of a chapter being read
+type chapterPartMsg struct {
+	seq     int
+	chapter int
+	stream  *api.ChapterStream
+	text    string
+	size    int // Size of this piece; the next is twice as big
+	done    bool
+	err     error
+}
+
+// loadChapter starts reading a chapter, abandoning any chapter still arriving
+func (v *ReaderView) loadChapter(chapter int) tea.Cmd {
+	v.loading = true
+	v.StopLoading()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.streamSeq++
+	v.streamCancel = cancel
+	v.streamText = ""
+	seq := v.streamSeq
+	return func() tea.Msg {
+		stream, err := v.client.OpenChapterText(ctx, v.book.ID, chapter)
+		if err != nil {
+			return chapterPartMsg{seq: seq, chapter: chapter, err: err}
+		}
+		return readChapterPart(seq, chapter, stream, chapterFirstPart)
+	}
+}
+
+// readChapterPart reads the next piece of a chapter
+func readChapterPart(seq, chapter int, stream *api.ChapterStream, size int) tea.Msg {
+	text, err := stream.Next(size)
+	msg := chapterPartMsg{seq: seq, chapter: chapter, stream: stream, text: text, size: size}
+	switch {
+	case err == io.EOF:
+		msg.done = true
+	case err != nil:
+		msg.err = err
+	}
+	if err != nil {
+		stream.Close()
+	}
+	return msg
+}
+
+// StopLoading cancels the chapter being read, if any
+func (v *ReaderView) StopLoading() {
+	if v.streamCancel != nil {
+		v.streamCancel()
+		v.streamCancel = nil
+	}
+	v.streaming = false
+}
+
+// handleChapterPart shows plain text as it arrives and asks for the next piece.
+// HTML is only parsed once complete; the whole chapter then goes through
+// handleChapterLoaded, which restores any saved position.
+func (v *ReaderView) handleChapterPart(msg chapterPartMsg) (View, tea.Cmd) {
+	if msg.seq != v.streamSeq {
+		return v, nil
+	}
+	if msg.err != nil || msg.done {
+		v.StopLoading()
+		contentType := ""
+		if msg.stream != nil {
+			contentType = msg.stream.ContentType
+		}
+		return v.handleChapterLoaded(chapterLoadedMsg{
+			content:     v.streamText,
+			contentType: contentType,
+			chapter:     msg.chapter,
+			err:         msg.err,
+		})
+	}
+
+	v.streamText += msg.text
+	if !isHTMLContent(msg.stream.ContentType) && v.streamText != "" {
+		v.loading = false
+		v.streaming = true
+		v.err = nil
+		v.chapter = msg.chapter
+		v.content = v.streamText
+		v.links = nil
+		v.anchors = nil
+		v.wrapContent()
+	}
+
+	seq, stream, size := msg.seq, msg.stream, min(msg.size*2, chapterMaxPart)
+	return v, func() tea.Msg {
+		return readChapterPart(seq, msg.chapter, stream, size)
+	}
+}
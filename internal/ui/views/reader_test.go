@@ -0,0 +1,44 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/justyntemme/webby-t/internal/config"
+)
+
+func TestContinuousRewrapKeepsPlace(t *testing.T) {
+	v := NewReaderView(nil, &config.Config{})
+	v.continuousMode = true
+	v.content = "current chapter"
+	var chapters []chapterContent
+	for c := range 2 {
+		var paragraphs []string
+		for p := range 30 {
+			var words []string
+			for w := range 40 {
+				words = append(words, fmt.Sprintf("c%dp%dw%d", c, p, w))
+			}
+			paragraphs = append(paragraphs, strings.Join(words, " "))
+		}
+		chapters = append(chapters, chapterContent{index: c, content: strings.Join(paragraphs, "\n")})
+	}
+	v.buildContinuousContent(chapters)
+
+	const target = "c1p3w20"
+	top := slices.IndexFunc(v.lines, func(line string) bool { return strings.Contains(line, target) })
+	if top < 0 {
+		t.Fatalf("%s not found", target)
+	}
+	v.lineOffset = top
+
+	v.setTextScale(config.MaxTextScale)
+	if !strings.Contains(v.lines[v.lineOffset], target) {
+		t.Errorf("top line after rewrapping = %q, want the one with %s", v.lines[v.lineOffset], target)
+	}
+	if len(v.chapterBoundaries) != 2 || !strings.Contains(v.lines[0]+v.lines[1], "Chapter 1") {
+		t.Errorf("rewrapped %d chapters, want the whole book", len(v.chapterBoundaries))
+	}
+}
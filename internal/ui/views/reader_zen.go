@@ -0,0 +1,87 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+)
+
+// zenColumnWidth caps the line length in zen mode for a comfortable measure
+const zenColumnWidth = 66
+
+// toggleZenMode switches distraction-free reading on or off, keeping the position.
+// Continuous mode re-fetches the book to rewrap it at the new width.
+func (v *ReaderView) toggleZenMode() tea.Cmd {
+	v.zenMode = !v.zenMode
+	if v.continuousMode {
+		v.chapter = v.getCurrentChapterFromLine(v.lineOffset)
+		v.loading = true
+		return v.loadAllChapters()
+	}
+	if v.content != "" {
+		v.rewrapPreservingPosition()
+	}
+	return nil
+}
+
+// zenStatusLine returns the single line shown under the text in zen mode, if any
+func (v *ReaderView) zenStatusLine() string {
+	switch {
+	case v.searchMode:
+		return v.renderSearchInput()
+	case v.statusMsg != "":
+		return styles.SecondaryText.Render(v.statusMsg)
+	}
+	return ""
+}
+
+// currentParagraph returns the line range of the paragraph being read: the first
+// non-blank paragraph that starts at or overlaps the top of the view
+func (v *ReaderView) currentParagraph() (int, int) {
+	for i, start := range v.paragraphLines {
+		end := len(v.lines)
+		if i+1 < len(v.paragraphLines) {
+			end = v.paragraphLines[i+1]
+		}
+		if end <= v.lineOffset || strings.TrimSpace(strings.Join(v.lines[start:end], "")) == "" {
+			continue
+		}
+		return start, end
+	}
+	return 0, 0
+}
+
+// renderZen renders a centered column of text with everything but the current paragraph dimmed
+func (v *ReaderView) renderZen() string {
+	var content string
+	switch {
+	case v.loading:
+		content = styles.RenderCenteredContent(styles.MutedText.Render("Loading..."), v.width, v.contentHeight())
+	case v.err != nil:
+		content = styles.RenderCenteredContent(styles.ErrorStyle.Render("Error: "+v.err.Error()), v.width, v.contentHeight())
+	default:
+		start, end := v.currentParagraph()
+		var lines []string
+		for i := v.lineOffset; i < min(v.lineOffset+v.visibleLines(), len(v.lines)); i++ {
+			line := v.lines[i]
+			if v.searchActive && len(v.searchMatches) > 0 {
+				line = v.highlightLine(i, line)
+			} else if i < start || i >= end {
+				line = styles.MutedText.Render(line)
+			}
+			lines = append(lines, line)
+		}
+
+		// Pad to a fixed column so the text doesn't shift as line lengths change
+		available := v.width - styles.ReaderContent.GetHorizontalPadding()
+		column := lipgloss.NewStyle().Width(min(v.wrapWidth(), available)).Render(strings.Join(lines, "\n"))
+		content = styles.ReaderContent.Render(lipgloss.PlaceHorizontal(available, lipgloss.Center, column))
+	}
+
+	if status := v.zenStatusLine(); status != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, lipgloss.NewStyle().Height(v.contentHeight()).Render(content), status)
+	}
+	return styles.RenderChromeless(content, v.width, v.height)
+}
@@ -0,0 +1,385 @@
+package views
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
+	"github.com/justyntemme/webby-t/pkg/models"
+)
+
+// Settings form elements, in focus order
+const (
+	settingsEmail = iota
+	settingsSaveEmail
+	settingsCurrentPassword
+	settingsNewPassword
+	settingsConfirmPassword
+	settingsChangePassword
+	settingsDeletePassword
+	settingsDeleteAccount
+	settingsFields
+)
+
+var errPasswordMismatch = errors.New("new passwords don't match")
+
+// Account settings results
+type accountLoadedMsg struct {
+	user *models.User
+	err  error
+}
+
+type emailUpdatedMsg struct {
+	user *models.User
+	err  error
+}
+
+type passwordChangedMsg struct {
+	token string
+	err   error
+}
+
+type accountDeletedMsg struct {
+	err error
+}
+
+// SettingsView lets the user change their email and password, or delete their
+// account, without the web UI
+type SettingsView struct {
+	client *api.Client
+	config *config.Config
+
+	user   *models.User
+	inputs [settingsFields]*textinput.Model // Nil for buttons
+
+	emailInput           textinput.Model
+	currentPasswordInput textinput.Model
+	newPasswordInput     textinput.Model
+	confirmPasswordInput textinput.Model
+	deletePasswordInput  textinput.Model
+
+	focusIndex    int
+	loading       bool
+	confirmDelete bool // Delete was pressed once; enter again deletes
+	statusMsg     string
+	err           error
+
+	width  int
+	height int
+}
+
+// NewSettingsView creates a new account settings view
+func NewSettingsView(client *api.Client, cfg *config.Config) *SettingsView {
+	v := &SettingsView{
+		client:               client,
+		config:               cfg,
+		emailInput:           newTextInput(),
+		currentPasswordInput: newPasswordInput("current password"),
+		newPasswordInput:     newPasswordInput("new password"),
+		confirmPasswordInput: newPasswordInput("new password again"),
+		deletePasswordInput:  newPasswordInput("password"),
+		width:                80,
+		height:               24,
+	}
+	v.emailInput.Placeholder = "email@example.com"
+	v.emailInput.CharLimit = 100
+	v.emailInput.Width = 30
+
+	v.inputs[settingsEmail] = &v.emailInput
+	v.inputs[settingsCurrentPassword] = &v.currentPasswordInput
+	v.inputs[settingsNewPassword] = &v.newPasswordInput
+	v.inputs[settingsConfirmPassword] = &v.confirmPasswordInput
+	v.inputs[settingsDeletePassword] = &v.deletePasswordInput
+	return v
+}
+
+// newPasswordInput returns a masked input for a password
+func newPasswordInput(placeholder string) textinput.Model {
+	input := newTextInput()
+	input.Placeholder = placeholder
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.CharLimit = 100
+	input.Width = 30
+	return input
+}
+
+// Init implements View
+func (v *SettingsView) Init() tea.Cmd {
+	for _, input := range v.inputs {
+		if input != nil && input.EchoMode == textinput.EchoPassword {
+			input.SetValue("")
+		}
+	}
+	v.focusIndex = settingsEmail
+	v.confirmDelete = false
+	v.statusMsg, v.err = "", nil
+	v.updateFocus()
+	v.loading = true
+	return tea.Batch(textinput.Blink, v.loadAccount())
+}
+
+// loadAccount fetches the user to show their current email
+func (v *SettingsView) loadAccount() tea.Cmd {
+	return func() tea.Msg {
+		user, err := v.client.GetCurrentUser()
+		return accountLoadedMsg{user: user, err: err}
+	}
+}
+
+// Update implements View
+func (v *SettingsView) Update(msg tea.Msg) (View, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return v, SwitchTo(ViewLibrary)
+		case "tab", "down":
+			v.moveFocus(1)
+			return v, nil
+		case "shift+tab", "up":
+			v.moveFocus(-1)
+			return v, nil
+		case "enter":
+			return v, v.activate()
+		}
+
+	case accountLoadedMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		v.user = msg.user
+		v.emailInput.SetValue(msg.user.Email)
+		return v, nil
+
+	case emailUpdatedMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		v.user = msg.user
+		v.statusMsg = "Email updated"
+		return v, nil
+
+	case passwordChangedMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		if msg.token != "" {
+			// Other sessions were ended; keep this one
+			v.config.SetToken(msg.token)
+		}
+		v.currentPasswordInput.SetValue("")
+		v.newPasswordInput.SetValue("")
+		v.confirmPasswordInput.SetValue("")
+		v.statusMsg = "Password changed"
+		return v, nil
+
+	case accountDeletedMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return v, nil
+		}
+		return v, func() tea.Msg { return LogoutMsg{} }
+	}
+
+	if input := v.inputs[v.focusIndex]; input != nil {
+		var cmd tea.Cmd
+		*input, cmd = input.Update(msg)
+		if _, ok := msg.(tea.KeyMsg); ok {
+			v.confirmDelete = false
+		}
+		return v, cmd
+	}
+	return v, nil
+}
+
+// moveFocus moves focus by delta form elements, wrapping around
+func (v *SettingsView) moveFocus(delta int) {
+	v.focusIndex = (v.focusIndex + delta + settingsFields) % settingsFields
+	v.confirmDelete = false
+	v.updateFocus()
+}
+
+// updateFocus focuses the input under the cursor, if any
+func (v *SettingsView) updateFocus() {
+	for i, input := range v.inputs {
+		if input == nil {
+			continue
+		}
+		if i == v.focusIndex {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
+// activate submits the section of the focused element, or moves to the next
+// field from an input
+func (v *SettingsView) activate() tea.Cmd {
+	if v.loading {
+		return nil
+	}
+	switch v.focusIndex {
+	case settingsEmail, settingsSaveEmail:
+		return v.saveEmail()
+	case settingsChangePassword:
+		return v.changePassword()
+	case settingsDeleteAccount:
+		return v.deleteAccount()
+	}
+	v.moveFocus(1)
+	return nil
+}
+
+// saveEmail sends the new email address
+func (v *SettingsView) saveEmail() tea.Cmd {
+	email := strings.TrimSpace(v.emailInput.Value())
+	if email == "" {
+		v.err = errEmptyFields
+		return nil
+	}
+	v.start()
+	return func() tea.Msg {
+		user, err := v.client.UpdateEmail(email)
+		return emailUpdatedMsg{user: user, err: err}
+	}
+}
+
+// changePassword checks the new password was typed the same twice and sends it
+func (v *SettingsView) changePassword() tea.Cmd {
+	current, newPassword := v.currentPasswordInput.Value(), v.newPasswordInput.Value()
+	if current == "" || newPassword == "" {
+		v.err = errEmptyFields
+		return nil
+	}
+	if newPassword != v.confirmPasswordInput.Value() {
+		v.err = errPasswordMismatch
+		return nil
+	}
+	v.start()
+	return func() tea.Msg {
+		token, err := v.client.ChangePassword(current, newPassword)
+		return passwordChangedMsg{token: token, err: err}
+	}
+}
+
+// deleteAccount asks for confirmation on the first press and deletes on the second
+func (v *SettingsView) deleteAccount() tea.Cmd {
+	password := v.deletePasswordInput.Value()
+	if password == "" {
+		v.err = errEmptyFields
+		return nil
+	}
+	if !v.confirmDelete {
+		v.confirmDelete = true
+		v.statusMsg, v.err = "", nil
+		return nil
+	}
+	v.confirmDelete = false
+	v.start()
+	return func() tea.Msg {
+		return accountDeletedMsg{err: v.client.DeleteAccount(password)}
+	}
+}
+
+// start marks a request as in flight, clearing the last result
+func (v *SettingsView) start() {
+	v.loading = true
+	v.statusMsg, v.err = "", nil
+}
+
+// CapturingKeys implements KeyCapturer: the inputs take q and ?, and esc leaves
+// the form
+func (v *SettingsView) CapturingKeys() bool {
+	return true
+}
+
+// View implements View
+func (v *SettingsView) View() string {
+	right := ""
+	if v.user != nil {
+		right = v.user.Username
+	}
+	header := styles.HeaderContent("Account Settings", right, v.width)
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
+
+// renderContent renders the email, password and delete sections
+func (v *SettingsView) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HelpKey.Render("Email") + "\n")
+	b.WriteString(v.renderInput(settingsEmail) + "\n")
+	b.WriteString(v.renderButton(settingsSaveEmail, "Save email") + "\n\n")
+
+	b.WriteString(styles.HelpKey.Render("Change password") + "\n")
+	b.WriteString(v.renderInput(settingsCurrentPassword) + "\n")
+	b.WriteString(v.renderInput(settingsNewPassword) + "\n")
+	b.WriteString(v.renderInput(settingsConfirmPassword) + "\n")
+	b.WriteString(v.renderButton(settingsChangePassword, "Change password") + "\n\n")
+
+	b.WriteString(styles.HelpKey.Render("Delete account") + "\n")
+	b.WriteString(styles.MutedText.Render("Removes your account and all your books from the server") + "\n")
+	b.WriteString(v.renderInput(settingsDeletePassword) + "\n")
+	label := "Delete account"
+	if v.confirmDelete {
+		label = "Press enter again to delete"
+	}
+	b.WriteString(v.renderButton(settingsDeleteAccount, label) + "\n")
+
+	switch {
+	case v.loading:
+		b.WriteString("\n" + styles.MutedText.Render("Working..."))
+	case v.err != nil:
+		b.WriteString("\n" + styles.ErrorStyle.Render(v.err.Error()))
+	case v.statusMsg != "":
+		b.WriteString("\n" + styles.SuccessStyle.Render(v.statusMsg))
+	}
+
+	return styles.ContentPanel.Render(b.String())
+}
+
+// renderInput renders a form input, highlighted when focused
+func (v *SettingsView) renderInput(index int) string {
+	style := styles.InputField
+	if v.focusIndex == index {
+		style = styles.InputFieldFocused
+	}
+	return style.Render(v.inputs[index].View())
+}
+
+// renderButton renders a form button, highlighted when focused
+func (v *SettingsView) renderButton(index int, label string) string {
+	if v.focusIndex == index {
+		return styles.ButtonFocused.Render(label)
+	}
+	return styles.Button.Render(label)
+}
+
+// renderFooter renders the footer help content
+func (v *SettingsView) renderFooter() string {
+	help := []string{
+		styles.HelpKey.Render("tab/↑/↓") + styles.Help.Render(" move"),
+		styles.HelpKey.Render("enter") + styles.Help.Render(" save"),
+		styles.HelpKey.Render("esc") + styles.Help.Render(" back"),
+	}
+	return strings.Join(help, "  ")
+}
+
+// SetSize implements View
+func (v *SettingsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -8,8 +9,8 @@ import (
 
 	"github.com/charmbracelet/bubbles/filepicker"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
 	"github.com/justyntemme/webby-t/internal/ui/styles"
 	"github.com/justyntemme/webby-t/pkg/models"
 )
@@ -17,6 +18,7 @@ import (
 // UploadView displays a file picker for uploading epubs
 type UploadView struct {
 	client     *api.Client
+	config     *config.Config
 	filepicker filepicker.Model
 	selected   string
 	uploading  bool
@@ -46,7 +48,7 @@ type uploadCompleteMsg struct {
 type clearResultMsg struct{}
 
 // NewUploadView creates a new upload view
-func NewUploadView(client *api.Client) *UploadView {
+func NewUploadView(client *api.Client, cfg *config.Config) *UploadView {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -63,6 +65,7 @@ func NewUploadView(client *api.Client) *UploadView {
 
 	return &UploadView{
 		client:     client,
+		config:     cfg,
 		filepicker: fp,
 		width:      80,
 		height:     24,
@@ -136,14 +139,16 @@ type clearErrorMsg struct{}
 
 // View implements View
 func (v *UploadView) View() string {
-	var b strings.Builder
+	header := styles.HeaderContent("Add Book", "", v.width)
+	return styles.RenderLayout(header, v.renderContent(), v.renderFooter(), v.width, v.height)
+}
 
-	// Header
-	b.WriteString(styles.TitleBar.Render(" Add Book ") + "\n\n")
+// renderContent renders instructions, upload status, and the file picker
+func (v *UploadView) renderContent() string {
+	var b strings.Builder
 
 	// Instructions
-	b.WriteString(styles.Help.Render("Navigate to a file (.epub, .pdf, .cbz, .cbr) and press Enter to upload") + "\n")
-	b.WriteString(styles.Help.Render("Press Esc to go back") + "\n\n")
+	b.WriteString(styles.Help.Render("Navigate to a file (.epub, .pdf, .cbz, .cbr) and press Enter to upload") + "\n\n")
 
 	// Show uploading state
 	if v.uploading {
@@ -168,32 +173,24 @@ func (v *UploadView) View() string {
 	// File picker
 	b.WriteString(v.filepicker.View())
 
-	// Footer
-	b.WriteString("\n\n")
+	return styles.ContentPanel.Render(b.String())
+}
+
+// renderFooter renders the footer help content
+func (v *UploadView) renderFooter() string {
 	help := []string{
 		styles.HelpKey.Render("↑/↓") + styles.Help.Render(" navigate"),
 		styles.HelpKey.Render("enter") + styles.Help.Render(" select"),
 		styles.HelpKey.Render("esc") + styles.Help.Render(" back"),
 	}
-	b.WriteString(strings.Join(help, "  "))
-
-	// Center the content
-	content := styles.Dialog.Width(v.width - 4).Render(b.String())
-
-	return lipgloss.Place(
-		v.width,
-		v.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		content,
-	)
+	return strings.Join(help, "  ")
 }
 
 // SetSize implements View
 func (v *UploadView) SetSize(width, height int) {
 	v.width = width
 	v.height = height
-	v.filepicker.Height = height - 15 // Leave room for header/footer
+	v.filepicker.Height = styles.ContentHeight(height) - 6 // Leave room for instructions and status
 	if v.filepicker.Height < 5 {
 		v.filepicker.Height = 5
 	}
@@ -202,7 +199,7 @@ func (v *UploadView) SetSize(width, height int) {
 // uploadFile uploads the selected file
 func (v *UploadView) uploadFile(path string) tea.Cmd {
 	return func() tea.Msg {
-		book, err := v.client.UploadBook(path)
+		book, err := v.client.UploadBookResumable(context.Background(), path, v.config)
 		return uploadCompleteMsg{book: book, err: err}
 	}
 }
@@ -1,7 +1,16 @@
 package views
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/justyntemme/webby-t/internal/api"
+	"github.com/justyntemme/webby-t/internal/config"
+	"github.com/justyntemme/webby-t/internal/ui/styles"
 	"github.com/justyntemme/webby-t/pkg/models"
 )
 
@@ -19,6 +28,9 @@ const (
 	ViewSettings
 	ViewComic
 	ViewBookDetails
+	ViewHistory
+	ViewHome
+	ViewProfiles
 )
 
 // String returns the name of the view
@@ -44,6 +56,12 @@ func (v ViewType) String() string {
 		return "Comic Viewer"
 	case ViewBookDetails:
 		return "Book Details"
+	case ViewHistory:
+		return "History"
+	case ViewHome:
+		return "Home"
+	case ViewProfiles:
+		return "Profiles"
 	default:
 		return "Unknown"
 	}
@@ -57,6 +75,18 @@ type View interface {
 	SetSize(width, height int)
 }
 
+// KeyCapturer is implemented by views that sometimes need keys the app would
+// otherwise handle globally (q, esc, ?), e.g. while an overlay or text input is open
+type KeyCapturer interface {
+	CapturingKeys() bool
+}
+
+// BackHandler is implemented by views with a subview that q and esc close
+// before the app would leave the view. Other keys, and key remaps, work as usual.
+type BackHandler interface {
+	HandlingBack() bool
+}
+
 // Message types for inter-view communication
 
 // LoginSuccessMsg is sent when login succeeds
@@ -78,6 +108,16 @@ type ShowBookDetailsMsg struct {
 	Book models.Book
 }
 
+// ApplySmartCollectionMsg is sent to show the library with a smart collection's filters
+type ApplySmartCollectionMsg struct {
+	Collection config.SmartCollection
+}
+
+// SwitchProfileMsg is sent to switch to another server profile
+type SwitchProfileMsg struct {
+	Name string
+}
+
 // ErrorMsg is sent when an error occurs
 type ErrorMsg struct {
 	Err error
@@ -91,11 +131,83 @@ type SwitchViewMsg struct {
 	View ViewType
 }
 
+// ServerEventMsg is a change made elsewhere that the server pushed, such as a
+// book uploaded from another device
+type ServerEventMsg struct {
+	Event api.Event
+}
+
 // ThemeChangedMsg is sent when the theme is changed
 type ThemeChangedMsg struct {
 	ThemeName string
 }
 
+// LowPower disables cursor blinking to avoid periodic redraws.
+// Set it before constructing views.
+var LowPower bool
+
+// newTextInput creates a text input, with a static cursor in low-power mode
+func newTextInput() textinput.Model {
+	input := textinput.New()
+	if LowPower {
+		input.Cursor.SetMode(cursor.CursorStatic)
+	}
+	return input
+}
+
+// numberPrompt is a footer prompt for a whole number in a range, such as a page
+type numberPrompt struct {
+	input textinput.Model
+}
+
+// newNumberPrompt creates a prompt for numbers of up to digits digits
+func newNumberPrompt(digits int) numberPrompt {
+	input := newTextInput()
+	input.Prompt = ""
+	input.CharLimit = digits
+	input.TextStyle = styles.BookAuthor
+	return numberPrompt{input: input}
+}
+
+// open clears the prompt for a number from lo to hi, with the error shown for
+// anything else
+func (p *numberPrompt) open(lo, hi int, outOfRange string) tea.Cmd {
+	p.input.Validate = func(value string) error {
+		if n, err := strconv.Atoi(value); err != nil || n < lo || n > hi {
+			return errors.New(outOfRange)
+		}
+		return nil
+	}
+	p.input.SetValue("")
+	return p.input.Focus()
+}
+
+// update passes a key to the input, ignoring anything typed but digits
+func (p *numberPrompt) update(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyRunes && strings.Trim(string(msg.Runes), "0123456789") != "" {
+		return nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd
+}
+
+// number returns the number typed, reporting false while it is out of range
+func (p *numberPrompt) number() (int, bool) {
+	n, err := strconv.Atoi(p.input.Value())
+	return n, err == nil && p.input.Err == nil
+}
+
+// view renders the input and suffix followed by hint, or by the range once
+// something out of range is typed
+func (p *numberPrompt) view(suffix, hint string) string {
+	prompt := p.input.View() + suffix + "  "
+	if p.input.Err != nil && p.input.Value() != "" {
+		return prompt + styles.ErrorStyle.Render(p.input.Err.Error())
+	}
+	return prompt + styles.Help.Render(hint)
+}
+
 // Helper functions to create messages
 
 // SendError creates an error message command
@@ -0,0 +1,41 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNumberPrompt(t *testing.T) {
+	p := newNumberPrompt(3)
+	p.open(100, 800, "Zoom must be 100-800%")
+	for _, r := range "1x50" {
+		p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := p.input.Value(); got != "150" {
+		t.Fatalf("value = %q, want 150 with letters ignored", got)
+	}
+	if n, ok := p.number(); !ok || n != 150 {
+		t.Errorf("number() = %d, %v; want 150", n, ok)
+	}
+	p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7")})
+	if got := p.input.Value(); got != "150" {
+		t.Errorf("value = %q, want it kept to 3 digits", got)
+	}
+
+	p.update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	if _, ok := p.number(); ok {
+		t.Error("9 accepted, want it out of range")
+	}
+	if view := p.view("%", "enter set"); !strings.Contains(view, "Zoom must be 100-800%") {
+		t.Errorf("view = %q, want the range error", view)
+	}
+
+	// Reopening clears the last number
+	p.open(1, 20, "Page must be 1-20")
+	if p.input.Value() != "" || strings.Contains(p.view("", "enter go"), "must be") {
+		t.Errorf("reopened prompt = %q", p.view("", "enter go"))
+	}
+}
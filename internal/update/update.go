@@ -0,0 +1,113 @@
+// Package update checks GitHub releases for newer versions of webby-t.
+package update
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the running version, set at build time with
+// -ldflags "-X github.com/justyntemme/webby-t/internal/update.Version=v1.2.3"
+var Version = "dev"
+
+// ReleasesURL is the GitHub API endpoint for the latest release
+const ReleasesURL = "https://api.github.com/repos/justyntemme/webby-t/releases/latest"
+
+// checkTimeout bounds the release lookup so a slow network never delays startup
+const checkTimeout = 5 * time.Second
+
+// Release is the subset of the GitHub release response we use
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"` // Release notes (markdown)
+	HTMLURL string `json:"html_url"`
+}
+
+// Latest fetches the most recent published release
+func Latest() (*Release, error) {
+	client := &http.Client{Timeout: checkTimeout}
+	req, err := http.NewRequest(http.MethodGet, ReleasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "webby-t/"+Version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update check failed: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("update check failed: %w", err)
+	}
+	return &release, nil
+}
+
+// IsNewer reports whether latest is a higher version than current.
+// Development builds never report updates.
+func IsNewer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	next, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if next[i] != cur[i] {
+			return next[i] > cur[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses "v1.2.3" (or "1.2", ignoring any "-rc1" suffix) into major, minor, patch
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// ChangelogExcerpt returns the first maxLines non-empty lines of the release notes
+func (r *Release) ChangelogExcerpt(maxLines int) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(r.Body, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(lines) == maxLines {
+			lines = append(lines, "...")
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // User represents a webby user
 type User struct {
@@ -36,6 +39,7 @@ type Book struct {
 	ContentType string    `json:"content_type"`
 	FileFormat  string    `json:"file_format,omitempty"`
 	UploadedAt  time.Time `json:"uploaded_at"`
+	Tags        []string  `json:"tags,omitempty"` // Set by servers that store tags
 }
 
 // IsComic returns true if the book is a comic
@@ -48,6 +52,30 @@ func (b *Book) IsCBZ() bool {
 	return b.FileFormat == FileFormatCBZ || b.FileFormat == FileFormatCBR
 }
 
+// IsPDF returns true if the book is a PDF
+func (b *Book) IsPDF() bool {
+	return b.FileFormat == FileFormatPDF
+}
+
+// FileName returns a safe file name for the book's original file, e.g. "Dune.epub"
+func (b *Book) FileName() string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, b.Title)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		name = b.ID
+	}
+	ext := FileFormatEPUB
+	if b.FileFormat != "" {
+		ext = strings.ToLower(b.FileFormat)
+	}
+	return name + "." + ext
+}
+
 // Chapter represents a chapter in the table of contents
 type Chapter struct {
 	Index int    `json:"index"`
@@ -68,6 +96,7 @@ type ReadingPosition struct {
 type Collection struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
+	ParentID  string    `json:"parent_id,omitempty"` // Empty for top-level collections
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -88,6 +117,14 @@ type BooksResponse struct {
 	Limit int    `json:"limit"`
 }
 
+// LibraryStats summarizes the user's library
+type LibraryStats struct {
+	Total     int   `json:"total"`
+	Books     int   `json:"books"`
+	Comics    int   `json:"comics"`
+	TotalSize int64 `json:"total_size"` // Bytes on disk
+}
+
 // TOCResponse represents the table of contents response
 type TOCResponse struct {
 	Chapters []Chapter `json:"chapters"`